@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gintugas/database"
+	routers "gintugas/modules"
+	"gintugas/modules/components/transfer/model"
+	transferService "gintugas/modules/components/transfer/service"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ============================
+// CLI: PORTFOLIO EXPORT / IMPORT
+// ============================
+
+const portfolioUsage = `Usage:
+  gintugas portfolio export [-format json|yaml] [-media] [-o file]
+  gintugas portfolio import [-mode merge|replace] [-dry-run] [-fresh-ids] file
+
+Import membaca JSON, YAML, atau zip hasil export (termasuk media). "-" berarti stdin/stdout.
+`
+
+// runPortfolioCommand menjalankan operasi yang sama dengan /api/admin/export dan /api/admin/import
+func runPortfolioCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, portfolioUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "export":
+		return runPortfolioExport(args[1:])
+	case "import":
+		return runPortfolioImport(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown portfolio command %q\n\n%s", args[0], portfolioUsage)
+		return 2
+	}
+}
+
+func runPortfolioExport(args []string) int {
+	flags := flag.NewFlagSet("portfolio export", flag.ContinueOnError)
+	format := flags.String("format", model.FormatJSON, "json atau yaml")
+	media := flags.Bool("media", false, "bundel file media ke dalam zip")
+	output := flags.String("o", "", "file tujuan (default: nama dari export, - untuk stdout)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	sqlDB, service, err := openTransferService(false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	defer sqlDB.Close()
+
+	file, err := service.Export(context.Background(), model.ExportOptions{Format: *format, Media: *media})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Export gagal: %v\n", err)
+		return 1
+	}
+
+	target := *output
+	if target == "" {
+		target = file.Name
+	}
+	if target == "-" {
+		if _, err := os.Stdout.Write(file.Data); err != nil {
+			return 1
+		}
+		return 0
+	}
+	if err := os.WriteFile(target, file.Data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Gagal menyimpan %s: %v\n", target, err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "✅ Portfolio diexport ke %s (%d bytes)\n", target, len(file.Data))
+	return 0
+}
+
+func runPortfolioImport(args []string) int {
+	flags := flag.NewFlagSet("portfolio import", flag.ContinueOnError)
+	mode := flags.String("mode", model.ImportModeMerge, "merge atau replace")
+	dryRun := flags.Bool("dry-run", false, "validasi saja tanpa menyimpan perubahan")
+	freshIDs := flags.Bool("fresh-ids", false, "beri UUID baru ke baris yang tidak cocok dengan data yang ada")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprint(os.Stderr, portfolioUsage)
+		return 2
+	}
+
+	var data []byte
+	var err error
+	if flags.Arg(0) == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(flags.Arg(0))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Gagal membaca %s: %v\n", flags.Arg(0), err)
+		return 1
+	}
+
+	sqlDB, service, err := openTransferService(true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	defer sqlDB.Close()
+
+	report, err := service.Import(context.Background(), data, model.ImportOptions{
+		Mode:     *mode,
+		DryRun:   *dryRun,
+		FreshIDs: *freshIDs,
+	})
+	if report != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(report)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Import gagal: %v\n", err)
+		if errors.Is(err, transferService.ErrImportRejected) {
+			return 3
+		}
+		return 1
+	}
+	if report.DryRun {
+		fmt.Fprintln(os.Stderr, "✅ Dry run selesai, tidak ada perubahan yang disimpan")
+	} else {
+		fmt.Fprintln(os.Stderr, "✅ Portfolio berhasil diimport")
+	}
+	return 0
+}
+
+// openTransferService membuka database tanpa log startup server. Import menjalankan migrasi
+// lebih dulu agar database baru langsung memiliki semua tabel.
+func openTransferService(migrate bool) (*sql.DB, transferService.TransferService, error) {
+	sqlDB, err := sql.Open("postgres", getDatabaseURL())
+	if err != nil {
+		return nil, nil, fmt.Errorf("gagal membuka database: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := sqlDB.PingContext(ctx); err != nil {
+		sqlDB.Close()
+		return nil, nil, fmt.Errorf("gagal terhubung ke database: %v", err)
+	}
+
+	if migrate {
+		if err := database.DBMigrate(sqlDB); err != nil {
+			sqlDB.Close()
+			return nil, nil, err
+		}
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		sqlDB.Close()
+		return nil, nil, fmt.Errorf("gagal setup GORM: %v", err)
+	}
+	return sqlDB, routers.NewTransferService(gormDB), nil
+}
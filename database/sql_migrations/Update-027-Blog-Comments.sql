@@ -0,0 +1,33 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- BLOG POST COMMENT SETTINGS
+-- ============================
+
+ALTER TABLE portfolio_blog_posts
+    ADD COLUMN comments_enabled          BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN comments_close_after_days INTEGER NOT NULL DEFAULT 0; -- 0 = tidak pernah ditutup otomatis
+
+-- ============================
+-- BLOG COMMENTS TABLE
+-- ============================
+
+CREATE TABLE blog_comments (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id         UUID NOT NULL REFERENCES portfolio_blog_posts(id) ON DELETE CASCADE,
+    parent_id       UUID REFERENCES blog_comments(id) ON DELETE CASCADE,
+    author_name     VARCHAR(100) NOT NULL,
+    author_email    VARCHAR(150) NOT NULL,
+    content         TEXT NOT NULL,
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, approved, spam
+    ip_address      VARCHAR(45),
+    user_agent      TEXT,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_blog_comments_post_status ON blog_comments(post_id, status);
+CREATE INDEX idx_blog_comments_parent ON blog_comments(parent_id);
+
+-- +migrate StatementEnd
@@ -0,0 +1,57 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- BLOG SLUG HISTORY TABLE
+-- ============================
+
+CREATE TABLE blog_post_slug_history (
+    slug            VARCHAR(200) PRIMARY KEY,
+    post_id         UUID NOT NULL REFERENCES portfolio_blog_posts(id) ON DELETE CASCADE,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_blog_post_slug_history_post ON blog_post_slug_history(post_id);
+
+-- ============================
+-- PROJECT SLUGS
+-- ============================
+
+ALTER TABLE portfolio_projects ADD COLUMN slug VARCHAR(200);
+
+-- Isi slug untuk project yang sudah ada dari judul, tambahkan suffix -2, -3 jika bentrok.
+-- Suffix dicek terhadap semua slug yang sudah terisi, sehingga judul "Foo 2" dan dua judul "Foo"
+-- tidak menghasilkan "foo-2" dua kali.
+DO $$
+DECLARE
+    project   RECORD;
+    base_slug TEXT;
+    candidate TEXT;
+    counter   INTEGER;
+BEGIN
+    FOR project IN SELECT id, title FROM portfolio_projects ORDER BY created_at, id LOOP
+        base_slug := LEFT(COALESCE(NULLIF(TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(project.title), '[^a-z0-9]+', '-', 'g')), ''), 'project'), 190);
+        candidate := base_slug;
+        counter := 1;
+
+        WHILE EXISTS (SELECT 1 FROM portfolio_projects WHERE slug = candidate) LOOP
+            counter := counter + 1;
+            candidate := base_slug || '-' || counter;
+        END LOOP;
+
+        UPDATE portfolio_projects SET slug = candidate WHERE id = project.id;
+    END LOOP;
+END $$;
+
+ALTER TABLE portfolio_projects ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE portfolio_projects ADD CONSTRAINT portfolio_projects_slug_key UNIQUE (slug);
+
+CREATE TABLE project_slug_history (
+    slug            VARCHAR(200) PRIMARY KEY,
+    project_id      UUID NOT NULL REFERENCES portfolio_projects(id) ON DELETE CASCADE,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_project_slug_history_project ON project_slug_history(project_id);
+
+-- +migrate StatementEnd
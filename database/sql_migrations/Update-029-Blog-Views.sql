@@ -0,0 +1,39 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- BLOG VIEW ANALYTICS
+-- ============================
+
+-- Pengunjung unik per post per hari (hash IP + User-Agent, bukan data mentah)
+CREATE TABLE blog_post_view_visitors (
+    post_id         UUID NOT NULL REFERENCES portfolio_blog_posts(id) ON DELETE CASCADE,
+    view_date       DATE NOT NULL,
+    visitor_hash    CHAR(64) NOT NULL,
+    PRIMARY KEY (post_id, view_date, visitor_hash)
+);
+
+CREATE INDEX idx_blog_post_view_visitors_date ON blog_post_view_visitors(view_date);
+
+-- Agregasi view harian per post
+CREATE TABLE blog_post_views_daily (
+    post_id         UUID NOT NULL REFERENCES portfolio_blog_posts(id) ON DELETE CASCADE,
+    view_date       DATE NOT NULL,
+    views           INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, view_date)
+);
+
+CREATE INDEX idx_blog_post_views_daily_date ON blog_post_views_daily(view_date);
+
+-- Agregasi referrer harian per post (hanya host, tanpa path/query)
+CREATE TABLE blog_post_referrers_daily (
+    post_id         UUID NOT NULL REFERENCES portfolio_blog_posts(id) ON DELETE CASCADE,
+    view_date       DATE NOT NULL,
+    referrer        VARCHAR(255) NOT NULL,
+    views           INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, view_date, referrer)
+);
+
+CREATE INDEX idx_blog_post_referrers_daily_date ON blog_post_referrers_daily(view_date);
+
+-- +migrate StatementEnd
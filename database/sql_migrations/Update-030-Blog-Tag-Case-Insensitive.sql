@@ -0,0 +1,33 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- CASE-INSENSITIVE BLOG TAGS
+-- ============================
+
+-- Gabungkan tag yang hanya berbeda huruf besar/kecil ke tag tertua
+CREATE TEMP TABLE blog_tag_duplicates AS
+SELECT id AS duplicate_id, keep_id
+FROM (
+    SELECT id,
+           FIRST_VALUE(id) OVER (PARTITION BY LOWER(TRIM(name)) ORDER BY created_at, id) AS keep_id
+    FROM blog_tags
+) ranked
+WHERE id <> keep_id;
+
+INSERT INTO blog_post_tags (post_id, tag_id)
+SELECT bpt.post_id, d.keep_id
+FROM blog_post_tags bpt
+INNER JOIN blog_tag_duplicates d ON d.duplicate_id = bpt.tag_id
+ON CONFLICT DO NOTHING;
+
+DELETE FROM blog_tags WHERE id IN (SELECT duplicate_id FROM blog_tag_duplicates);
+
+DROP TABLE blog_tag_duplicates;
+
+UPDATE blog_tags SET name = TRIM(name) WHERE name <> TRIM(name);
+
+ALTER TABLE blog_tags DROP CONSTRAINT IF EXISTS blog_tags_name_key;
+CREATE UNIQUE INDEX idx_blog_tags_name_lower ON blog_tags(LOWER(name));
+
+-- +migrate StatementEnd
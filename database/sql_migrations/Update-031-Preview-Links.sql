@@ -0,0 +1,21 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- DRAFT PREVIEW LINKS
+-- ============================
+
+CREATE TABLE preview_links (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type     VARCHAR(20) NOT NULL, -- blog_post, project
+    entity_id       UUID NOT NULL,
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at      TIMESTAMP WITH TIME ZONE,
+    created_by      UUID,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_preview_links_entity ON preview_links(entity_type, entity_id);
+CREATE INDEX idx_preview_links_active ON preview_links(expires_at) WHERE revoked_at IS NULL;
+
+-- +migrate StatementEnd
@@ -0,0 +1,25 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- PROJECT CASE STUDY
+-- ============================
+
+ALTER TABLE portfolio_projects ADD COLUMN case_study TEXT NOT NULL DEFAULT ''; -- markdown
+
+-- ============================
+-- PROJECT MEDIA (GALLERY)
+-- ============================
+
+CREATE TABLE project_media (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id      UUID NOT NULL REFERENCES portfolio_projects(id) ON DELETE CASCADE,
+    url             VARCHAR(500) NOT NULL,
+    caption         VARCHAR(300) NOT NULL DEFAULT '',
+    display_order   INTEGER NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_project_media_project ON project_media(project_id, display_order);
+
+-- +migrate StatementEnd
@@ -0,0 +1,21 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- PROJECT GITHUB METADATA
+-- ============================
+
+CREATE TABLE project_github_stats (
+    project_id      UUID PRIMARY KEY REFERENCES portfolio_projects(id) ON DELETE CASCADE,
+    repo_full_name  VARCHAR(200) NOT NULL, -- owner/repo
+    stars           INTEGER NOT NULL DEFAULT 0,
+    forks           INTEGER NOT NULL DEFAULT 0,
+    language        VARCHAR(100) NOT NULL DEFAULT '',
+    topics          TEXT[] NOT NULL DEFAULT '{}',
+    pushed_at       TIMESTAMP WITH TIME ZONE,
+    etag            VARCHAR(200) NOT NULL DEFAULT '', -- untuk conditional request (If-None-Match)
+    last_error      TEXT NOT NULL DEFAULT '',
+    synced_at       TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- +migrate StatementEnd
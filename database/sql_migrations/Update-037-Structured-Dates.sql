@@ -0,0 +1,98 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- STRUCTURED DATES (EXPERIENCES & EDUCATION)
+-- ============================
+
+-- Tanggal disimpan dengan presisi bulan (selalu tanggal 1). end_date NULL berarti masih berjalan
+-- hanya jika current_job (experiences) / is_current (education) bernilai TRUE; tanpa flag itu
+-- tanggal selesainya tidak diketahui dan periode tidak dihitung dalam durasi.
+-- Kolom start_year/end_year lama tetap ada sebagai teks tampilan untuk kompatibilitas.
+ALTER TABLE portfolio_experiences
+    ADD COLUMN start_date DATE,
+    ADD COLUMN end_date DATE;
+
+ALTER TABLE portfolio_education
+    ADD COLUMN start_date DATE,
+    ADD COLUMN end_date DATE,
+    ADD COLUMN is_current BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Parser sementara untuk teks bebas: "2021", "2021-03", "03/2021", "Mar 2021", "Maret 2021".
+-- Tahun tanpa bulan dianggap Januari untuk tanggal mulai dan Desember untuk tanggal selesai.
+CREATE FUNCTION pg_temp.parse_portfolio_month(raw TEXT, is_end BOOLEAN) RETURNS DATE AS $$
+DECLARE
+    value TEXT := LOWER(TRIM(COALESCE(raw, '')));
+    parts TEXT[];
+    month_number INTEGER;
+BEGIN
+    IF value = '' THEN
+        RETURN NULL;
+    END IF;
+
+    parts := regexp_match(value, '^(\d{4})[-/.](\d{1,2})');
+    IF parts IS NOT NULL AND parts[2]::INTEGER BETWEEN 1 AND 12 THEN
+        RETURN make_date(parts[1]::INTEGER, parts[2]::INTEGER, 1);
+    END IF;
+
+    parts := regexp_match(value, '^(\d{1,2})[-/.](\d{4})$');
+    IF parts IS NOT NULL AND parts[1]::INTEGER BETWEEN 1 AND 12 THEN
+        RETURN make_date(parts[2]::INTEGER, parts[1]::INTEGER, 1);
+    END IF;
+
+    parts := regexp_match(value, '^([a-z]+)\.?\s+(\d{4})$');
+    IF parts IS NOT NULL THEN
+        month_number := CASE LEFT(parts[1], 3)
+            WHEN 'jan' THEN 1 WHEN 'feb' THEN 2 WHEN 'mar' THEN 3 WHEN 'apr' THEN 4
+            WHEN 'may' THEN 5 WHEN 'mei' THEN 5 WHEN 'jun' THEN 6 WHEN 'jul' THEN 7
+            WHEN 'aug' THEN 8 WHEN 'agu' THEN 8 WHEN 'sep' THEN 9 WHEN 'oct' THEN 10
+            WHEN 'okt' THEN 10 WHEN 'nov' THEN 11 WHEN 'dec' THEN 12 WHEN 'des' THEN 12
+        END;
+        IF month_number IS NOT NULL THEN
+            RETURN make_date(parts[2]::INTEGER, month_number, 1);
+        END IF;
+    END IF;
+
+    parts := regexp_match(value, '^(\d{4})$');
+    IF parts IS NOT NULL THEN
+        RETURN make_date(parts[1]::INTEGER, CASE WHEN is_end THEN 12 ELSE 1 END, 1);
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+UPDATE portfolio_experiences
+SET start_date = pg_temp.parse_portfolio_month(start_year, FALSE),
+    end_date = CASE
+        WHEN current_job THEN NULL
+        ELSE pg_temp.parse_portfolio_month(end_year, TRUE)
+    END;
+
+-- Teks "Present"/"Sekarang" pada end_year berarti pekerjaan masih berjalan
+UPDATE portfolio_experiences
+SET current_job = TRUE, end_date = NULL
+WHERE LOWER(TRIM(COALESCE(end_year, ''))) IN ('present', 'now', 'current', 'sekarang', 'saat ini');
+
+UPDATE portfolio_education
+SET start_date = pg_temp.parse_portfolio_month(start_year, FALSE),
+    end_date = pg_temp.parse_portfolio_month(end_year, TRUE),
+    is_current = LOWER(TRIM(COALESCE(end_year, ''))) IN ('present', 'now', 'current', 'sekarang', 'saat ini');
+
+-- Data lama yang urutannya terbalik dianggap tidak valid: tanggal selesainya dikosongkan tanpa
+-- menyalakan flag masih berjalan, sehingga ditandai belum lengkap, bukan dianggap berjalan sampai sekarang.
+-- Teks end_year yang gagal diparse berakhir dengan kondisi yang sama.
+UPDATE portfolio_experiences SET end_date = NULL WHERE end_date < start_date AND NOT current_job;
+UPDATE portfolio_education SET end_date = NULL WHERE end_date < start_date AND NOT is_current;
+
+DROP FUNCTION pg_temp.parse_portfolio_month(TEXT, BOOLEAN);
+
+ALTER TABLE portfolio_experiences
+    ADD CONSTRAINT chk_experiences_date_range CHECK (end_date IS NULL OR end_date >= start_date);
+ALTER TABLE portfolio_education
+    ADD CONSTRAINT chk_education_date_range CHECK (end_date IS NULL OR end_date >= start_date);
+
+CREATE INDEX idx_experiences_dates ON portfolio_experiences(start_date DESC, end_date DESC);
+CREATE INDEX idx_education_dates ON portfolio_education(start_date DESC, end_date DESC);
+
+-- +migrate StatementEnd
@@ -0,0 +1,44 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- EXPERIENCE SKILLS -> PORTFOLIO SKILLS
+-- ============================
+
+-- Nama skill yang belum ada di katalog dibuat dulu (dicocokkan tanpa membedakan huruf besar/kecil)
+INSERT INTO portfolio_skills (name, value)
+SELECT DISTINCT ON (LOWER(TRIM(es.skill_name))) TRIM(es.skill_name), 0
+FROM experience_skills es
+WHERE TRIM(es.skill_name) <> ''
+  AND NOT EXISTS (
+      SELECT 1 FROM portfolio_skills ps WHERE LOWER(ps.name) = LOWER(TRIM(es.skill_name))
+  )
+ORDER BY LOWER(TRIM(es.skill_name)), es.skill_name;
+
+ALTER TABLE experience_skills ADD COLUMN skill_id UUID REFERENCES portfolio_skills(id) ON DELETE CASCADE;
+
+UPDATE experience_skills es
+SET skill_id = (
+    SELECT ps.id FROM portfolio_skills ps
+    WHERE LOWER(ps.name) = LOWER(TRIM(es.skill_name))
+    ORDER BY ps.created_at, ps.id
+    LIMIT 1
+);
+
+DELETE FROM experience_skills WHERE skill_id IS NULL;
+
+-- "React" dan "react" pada experience yang sama kini menunjuk skill yang sama, sisakan satu baris
+DELETE FROM experience_skills a
+USING experience_skills b
+WHERE a.experience_id = b.experience_id
+  AND a.skill_id = b.skill_id
+  AND a.ctid > b.ctid;
+
+ALTER TABLE experience_skills DROP CONSTRAINT experience_skills_pkey;
+ALTER TABLE experience_skills DROP COLUMN skill_name;
+ALTER TABLE experience_skills ALTER COLUMN skill_id SET NOT NULL;
+ALTER TABLE experience_skills ADD PRIMARY KEY (experience_id, skill_id);
+
+CREATE INDEX idx_experience_skills_skill ON experience_skills(skill_id);
+
+-- +migrate StatementEnd
@@ -0,0 +1,31 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- PROJECT <-> EXPERIENCE <-> SKILL
+-- ============================
+
+-- Pekerjaan (experience) tempat project dibuat
+ALTER TABLE portfolio_projects
+    ADD COLUMN experience_id UUID REFERENCES portfolio_experiences(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_projects_experience ON portfolio_projects(experience_id);
+
+-- Skill dari katalog portfolio_skills yang dipakai project
+CREATE TABLE project_skills (
+    project_id      UUID NOT NULL REFERENCES portfolio_projects(id) ON DELETE CASCADE,
+    skill_id        UUID NOT NULL REFERENCES portfolio_skills(id) ON DELETE CASCADE,
+    PRIMARY KEY (project_id, skill_id)
+);
+
+CREATE INDEX idx_project_skills_skill ON project_skills(skill_id);
+
+-- Isi awal dari tag project yang namanya sama dengan skill di katalog
+INSERT INTO project_skills (project_id, skill_id)
+SELECT DISTINCT ptr.project_id, ps.id
+FROM project_tag_relations ptr
+INNER JOIN project_tags pt ON pt.id = ptr.tag_id
+INNER JOIN portfolio_skills ps ON LOWER(ps.name) = LOWER(pt.name)
+ON CONFLICT DO NOTHING;
+
+-- +migrate StatementEnd
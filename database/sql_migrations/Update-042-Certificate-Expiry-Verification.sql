@@ -0,0 +1,20 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- CERTIFICATE EXPIRY & CREDENTIAL VERIFICATION
+-- ============================
+
+-- NULL berarti sertifikat tidak memiliki masa berlaku
+ALTER TABLE portfolio_certificates
+    ADD COLUMN expires_at DATE,
+    ADD COLUMN last_http_status INTEGER,                  -- status HTTP terakhir dari credential_url
+    ADD COLUMN last_checked_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN verification_error TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE portfolio_certificates
+    ADD CONSTRAINT chk_certificates_expiry CHECK (expires_at IS NULL OR issue_date IS NULL OR expires_at >= issue_date);
+
+CREATE INDEX idx_certificates_expires_at ON portfolio_certificates(expires_at);
+
+-- +migrate StatementEnd
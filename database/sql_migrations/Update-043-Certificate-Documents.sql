@@ -0,0 +1,18 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- CERTIFICATE PDF DOCUMENTS
+-- ============================
+
+-- File asli (PDF), sedangkan image_url berisi thumbnail halaman pertama
+ALTER TABLE portfolio_certificates
+    ADD COLUMN document_url VARCHAR(500) NOT NULL DEFAULT '';
+
+-- Sertifikat PDF lama: image_url menunjuk ke PDF, pindahkan ke document_url.
+-- image_url tetap diisi sampai thumbnail dibuat ulang lewat PUT /certificates/:id/with-image
+UPDATE portfolio_certificates
+SET document_url = image_url
+WHERE LOWER(image_url) LIKE '%.pdf';
+
+-- +migrate StatementEnd
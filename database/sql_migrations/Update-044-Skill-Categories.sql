@@ -0,0 +1,48 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- SKILL CATEGORIES
+-- ============================
+
+CREATE TABLE skill_categories (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    label           VARCHAR(100) NOT NULL,
+    slug            VARCHAR(120) NOT NULL UNIQUE,
+    icon            VARCHAR(500) NOT NULL DEFAULT '',
+    description     TEXT NOT NULL DEFAULT '',
+    display_order   INTEGER NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Kategori dari teks bebas lama, "Framework" dan "framework" menjadi satu slug. Label memakai
+-- penulisan asli yang paling sering dipakai agar field category di response tetap sama seperti dulu.
+INSERT INTO skill_categories (label, slug, display_order)
+SELECT label, slug, (ROW_NUMBER() OVER (ORDER BY slug)) - 1
+FROM (
+    SELECT DISTINCT ON (slug) label, slug
+    FROM (
+        SELECT TRIM(category) AS label,
+               TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(TRIM(category)), '[^a-z0-9]+', '-', 'g')) AS slug,
+               COUNT(*) AS uses
+        FROM portfolio_skills
+        WHERE category IS NOT NULL AND TRIM(category) <> ''
+        GROUP BY 1, 2
+    ) raw
+    WHERE slug <> ''
+    ORDER BY slug, uses DESC, label
+) categories;
+
+ALTER TABLE portfolio_skills ADD COLUMN category_id UUID REFERENCES skill_categories(id) ON DELETE SET NULL;
+
+UPDATE portfolio_skills ps
+SET category_id = sc.id
+FROM skill_categories sc
+WHERE sc.slug = TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(TRIM(ps.category)), '[^a-z0-9]+', '-', 'g'));
+
+ALTER TABLE portfolio_skills DROP COLUMN category;
+
+CREATE INDEX idx_skills_category ON portfolio_skills(category_id);
+
+-- +migrate StatementEnd
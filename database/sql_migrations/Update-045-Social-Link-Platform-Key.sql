@@ -0,0 +1,21 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- SOCIAL LINK PLATFORM KEY
+-- ============================
+
+-- Platform dicari tanpa membedakan huruf besar/kecil ("GitHub" = "github"), jadi keunikannya juga.
+-- Duplikat lama yang hanya beda huruf diberi akhiran agar index bisa dibuat tanpa menghapus data;
+-- baris yang paling dulu dibuat tetap memakai nama aslinya.
+UPDATE portfolio_social_links l
+SET platform = LEFT(l.platform, 45) || '-' || d.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY LOWER(platform) ORDER BY created_at, id) AS position
+    FROM portfolio_social_links
+) d
+WHERE d.id = l.id AND d.position > 1;
+
+CREATE UNIQUE INDEX idx_social_links_platform_lower ON portfolio_social_links(LOWER(platform));
+
+-- +migrate StatementEnd
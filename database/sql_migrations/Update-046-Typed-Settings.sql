@@ -0,0 +1,63 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- TYPED SETTINGS & VISIBILITY
+-- ============================
+
+-- schema berisi JSON Schema (teks JSON, kosong berarti tanpa schema).
+-- is_public menentukan apakah setting ikut tampil di endpoint publik.
+ALTER TABLE portfolio_settings
+    ADD COLUMN is_public BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN schema TEXT NOT NULL DEFAULT '';
+
+UPDATE portfolio_settings SET value = '' WHERE value IS NULL;
+ALTER TABLE portfolio_settings
+    ALTER COLUMN value SET DEFAULT '',
+    ALTER COLUMN value SET NOT NULL;
+
+-- data_type dinormalisasi, tipe yang tidak dikenal dianggap string
+UPDATE portfolio_settings SET data_type = LOWER(TRIM(COALESCE(data_type, '')));
+UPDATE portfolio_settings SET data_type = 'string'
+WHERE data_type NOT IN ('string', 'number', 'boolean', 'json');
+
+CREATE FUNCTION pg_temp.is_valid_json(raw TEXT) RETURNS BOOLEAN AS $$
+BEGIN
+    PERFORM raw::JSONB;
+    RETURN true;
+EXCEPTION WHEN others THEN
+    RETURN false;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Nilai lama yang tidak sesuai tipenya diturunkan menjadi string agar tetap terbaca apa adanya.
+-- Nilai yang valid disimpan dalam bentuk kanonik (angka/boolean/JSON tanpa spasi di tepi).
+UPDATE portfolio_settings SET data_type = 'string'
+WHERE data_type = 'number'
+  AND TRIM(value) !~ '^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$';
+
+UPDATE portfolio_settings SET data_type = 'string'
+WHERE data_type = 'boolean' AND LOWER(TRIM(value)) NOT IN ('true', 'false', '1', '0');
+
+UPDATE portfolio_settings SET data_type = 'string'
+WHERE data_type = 'json' AND NOT pg_temp.is_valid_json(value);
+
+UPDATE portfolio_settings SET value = TRIM(value) WHERE data_type IN ('number', 'json');
+
+UPDATE portfolio_settings
+SET value = CASE WHEN LOWER(TRIM(value)) IN ('true', '1') THEN 'true' ELSE 'false' END
+WHERE data_type = 'boolean';
+
+ALTER TABLE portfolio_settings
+    ALTER COLUMN data_type SET DEFAULT 'string',
+    ALTER COLUMN data_type SET NOT NULL,
+    ADD CONSTRAINT chk_settings_data_type CHECK (data_type IN ('string', 'number', 'boolean', 'json'));
+
+-- Setting yang sudah ada sebelumnya memang ditampilkan di website, tetap publik
+-- kecuali key yang terlihat seperti kredensial
+UPDATE portfolio_settings SET is_public = true
+WHERE key !~* '(secret|token|password|passwd|api_key|apikey|private|credential)';
+
+CREATE INDEX idx_settings_public ON portfolio_settings(is_public);
+
+-- +migrate StatementEnd
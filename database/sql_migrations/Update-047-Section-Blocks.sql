@@ -0,0 +1,26 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- SECTION CONTENT BLOCKS
+-- ============================
+
+-- Setiap section memiliki daftar block berurutan. payload berisi JSON yang
+-- divalidasi per type di service (lihat blockPayloadSchemas).
+CREATE TABLE section_blocks (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    section_id      UUID NOT NULL REFERENCES portfolio_sections(id) ON DELETE CASCADE,
+    type            VARCHAR(30) NOT NULL,
+    payload         TEXT NOT NULL DEFAULT '{}',
+    display_order   INTEGER NOT NULL DEFAULT 0,
+    is_active       BOOLEAN NOT NULL DEFAULT true,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_section_blocks_type CHECK (
+        type IN ('rich_text', 'image', 'cta', 'stats', 'project_list', 'skill_list')
+    )
+);
+
+CREATE INDEX idx_section_blocks_section ON section_blocks(section_id, display_order);
+
+-- +migrate StatementEnd
@@ -0,0 +1,47 @@
+-- +migrate Up
+-- +migrate StatementBegin
+
+-- ============================
+-- PROFILE (SINGLETON)
+-- ============================
+
+-- Hanya boleh ada satu baris profil, dijaga oleh kolom singleton yang unik.
+-- roles berisi array JSON teks, contoh: ["Web Developer", "Laravel Junior"].
+-- years_of_experience NULL berarti dihitung otomatis dari portfolio_experiences.
+CREATE TABLE portfolio_profile (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    singleton           BOOLEAN NOT NULL DEFAULT true UNIQUE CHECK (singleton),
+    name                VARCHAR(150) NOT NULL,
+    headline            VARCHAR(255) NOT NULL DEFAULT '',
+    roles               TEXT NOT NULL DEFAULT '[]',
+    bio                 TEXT NOT NULL DEFAULT '',
+    avatar_url          VARCHAR(500) NOT NULL DEFAULT '',
+    resume_url          VARCHAR(500) NOT NULL DEFAULT '',
+    location            VARCHAR(255) NOT NULL DEFAULT '',
+    availability        VARCHAR(30) NOT NULL DEFAULT 'open',
+    years_of_experience INTEGER,
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_profile_availability CHECK (availability IN ('open', 'freelance', 'busy', 'unavailable')),
+    CONSTRAINT chk_profile_years CHECK (years_of_experience IS NULL OR years_of_experience BETWEEN 0 AND 80)
+);
+
+-- Isi awal dari setting lama: site_title "Nama - Portfolio", site_description "... - Role | Role | Role"
+INSERT INTO portfolio_profile (name, headline, roles, location, resume_url)
+SELECT
+    COALESCE(NULLIF(TRIM(split_part(title.value, ' - ', 1)), ''), 'Portfolio Owner'),
+    COALESCE(TRIM(split_part(description.value, ' - ', 2)), ''),
+    COALESCE((
+        SELECT json_agg(TRIM(role))::TEXT
+        FROM unnest(string_to_array(split_part(description.value, ' - ', 2), '|')) AS role
+        WHERE TRIM(role) <> ''
+    ), '[]'),
+    COALESCE(location.value, ''),
+    COALESCE(cv.value, '')
+FROM (SELECT 1) AS seed
+LEFT JOIN portfolio_settings title ON title.key = 'site_title'
+LEFT JOIN portfolio_settings description ON description.key = 'site_description'
+LEFT JOIN portfolio_settings location ON location.key = 'location'
+LEFT JOIN portfolio_settings cv ON cv.key = 'cv_url';
+
+-- +migrate StatementEnd
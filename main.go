@@ -49,6 +49,11 @@ func main() {
 		}
 	}
 
+	// Subcommand CLI: gintugas portfolio export|import
+	if len(os.Args) > 1 && os.Args[1] == "portfolio" {
+		os.Exit(runPortfolioCommand(os.Args[2:]))
+	}
+
 	fmt.Println("\n" + strings.Repeat("=", 50))
 	fmt.Println("🚀 GINTUGAS API STARTING")
 	fmt.Println(strings.Repeat("=", 50))
@@ -1,10 +1,17 @@
 package serviceroute
 
 import (
+	"database/sql"
+	"errors"
+	projectrepo "gintugas/modules/components/Project/repository"
 	projectservice "gintugas/modules/components/Project/service"
+	"gintugas/modules/utils"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type ProjectHandler struct {
@@ -41,14 +48,19 @@ func (h *ProjectHandler) CreateProjectWithImage(c *gin.Context) {
 }
 
 func (h *ProjectHandler) GetAllProjects(c *gin.Context) {
-	projects, err := h.projectService.GetAllProjekService(c)
+	result, err := h.projectService.GetAllProjekService(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		status := http.StatusInternalServerError
+		if errors.Is(err, projectservice.ErrInvalidProjectFilter) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": projects,
+		"data": result.Projects,
+		"meta": result.Meta,
 	})
 }
 
@@ -76,6 +88,110 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 	})
 }
 
+func (h *ProjectHandler) GetProjectBySlug(c *gin.Context) {
+	project, err := h.projectService.GetProjekBySlugService(c)
+	if err != nil {
+		var moved *utils.SlugMovedError
+		if errors.As(err, &moved) {
+			redirectToSlug(c, moved.Slug)
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": project,
+	})
+}
+
+func (h *ProjectHandler) GetRelatedProjects(c *gin.Context) {
+	projects, err := h.projectService.GetRelatedProjekService(c)
+	if err != nil {
+		c.JSON(projectErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": projects,
+	})
+}
+
+func projectErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, projectservice.ErrInvalidProjectID):
+		return http.StatusBadRequest
+	case errors.Is(err, projectrepo.ErrProjectNotFound),
+		errors.Is(err, sql.ErrNoRows),
+		errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *ProjectHandler) GetProjectMedia(c *gin.Context) {
+	media, err := h.projectService.GetProjectMediaService(c)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": media,
+	})
+}
+
+func (h *ProjectHandler) ReorderProjectMedia(c *gin.Context) {
+	media, err := h.projectService.ReorderProjectMediaService(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Gallery order updated successfully",
+		"data":    media,
+	})
+}
+
+func (h *ProjectHandler) SetProjectExperience(c *gin.Context) {
+	project, err := h.projectService.SetProjectExperienceService(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Project experience updated successfully",
+		"data":    project,
+	})
+}
+
+func (h *ProjectHandler) SetProjectSkills(c *gin.Context) {
+	project, err := h.projectService.SetProjectSkillsService(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Project skills updated successfully",
+		"data":    project,
+	})
+}
+
+func (h *ProjectHandler) DeleteProjectMedia(c *gin.Context) {
+	if err := h.projectService.DeleteProjectMediaService(c); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Media deleted successfully",
+	})
+}
+
 func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	project, err := h.projectService.UpdateProjekService(c)
 	if err != nil {
@@ -104,7 +220,11 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 func (c *TagsHandler) CreateTags(ctx *gin.Context) {
 	Tags, err := c.tagsService.CreateTags(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
+		status := http.StatusBadRequest
+		if errors.Is(err, projectservice.ErrProjectTagNameTaken) {
+			status = http.StatusConflict
+		}
+		ctx.JSON(status, gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -115,3 +235,133 @@ func (c *TagsHandler) CreateTags(ctx *gin.Context) {
 		"Tags":    Tags,
 	})
 }
+
+func (c *TagsHandler) UpdateTags(ctx *gin.Context) {
+	tag, err := c.tagsService.UpdateTags(ctx)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, projectservice.ErrProjectTagNameTaken) {
+			status = http.StatusConflict
+		}
+		ctx.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Tags updated successfully",
+		"data":    tag,
+	})
+}
+
+func (c *TagsHandler) DeleteTags(ctx *gin.Context) {
+	if err := c.tagsService.DeleteTags(ctx); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, projectservice.ErrProjectTagInUse) {
+			status = http.StatusConflict
+		}
+		ctx.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Tags deleted successfully",
+	})
+}
+
+func (c *TagsHandler) GetTagUsage(ctx *gin.Context) {
+	tags, err := c.tagsService.GetTagUsage(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": tags,
+	})
+}
+
+type GitHubSyncHandler struct {
+	githubService projectservice.GitHubService
+}
+
+func NewGitHubSyncHandler(githubService projectservice.GitHubService) *GitHubSyncHandler {
+	return &GitHubSyncHandler{
+		githubService: githubService,
+	}
+}
+
+func (h *GitHubSyncHandler) SyncAll(c *gin.Context) {
+	if err := h.githubService.SyncAllService(c); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, projectservice.ErrGitHubSyncRunning) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "GitHub sync started",
+	})
+}
+
+func (h *GitHubSyncHandler) SyncProject(c *gin.Context) {
+	stats, err := h.githubService.SyncProjectService(c)
+	if err != nil {
+		status := http.StatusNotFound
+		var rateLimited *projectservice.GitHubRateLimitError
+		switch {
+		case errors.As(err, &rateLimited):
+			status = http.StatusTooManyRequests
+			c.Header("Retry-After", strconv.Itoa(int(time.Until(rateLimited.ResetAt).Seconds())+1))
+		case errors.Is(err, projectservice.ErrNotGitHubRepo):
+			status = http.StatusBadRequest
+		case errors.Is(err, projectservice.ErrGitHubNotFound):
+			status = http.StatusNotFound
+		case stats != nil:
+			// Request ke GitHub gagal, error sudah dicatat di last_error
+			status = http.StatusBadGateway
+		}
+		c.JSON(status, gin.H{"error": err.Error(), "data": stats})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "GitHub metadata synced successfully",
+		"data":    stats,
+	})
+}
+
+func (h *GitHubSyncHandler) GetSyncStatus(c *gin.Context) {
+	status, err := h.githubService.GetSyncStatusService(c)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, projectservice.ErrGitHubNotSynced) {
+			code = http.StatusNotFound
+		}
+		c.JSON(code, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": status,
+	})
+}
+
+func (h *GitHubSyncHandler) SuggestTags(c *gin.Context) {
+	suggestion, err := h.githubService.SuggestTagsService(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": suggestion,
+	})
+}
@@ -1,10 +1,15 @@
 package serviceroute
 
 import (
+	"errors"
 	"gintugas/modules/components/all/service"
+	"gintugas/modules/utils"
 	"net/http"
+	"net/url"
+	"path"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // ============================
@@ -45,6 +50,39 @@ func (h *SkillHandler) GetByID(c *gin.Context) {
 	})
 }
 
+func (h *SkillHandler) GetUsage(c *gin.Context) {
+	usage, err := h.service.GetUsage(c)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrInvalidSkillID):
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Skill usage retrieved successfully",
+		"data":    usage,
+	})
+}
+
+func (h *SkillHandler) GetProficiency(c *gin.Context) {
+	skills, err := h.service.GetProficiency(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Skill proficiency retrieved successfully",
+		"data":    skills,
+	})
+}
+
 func (h *SkillHandler) Update(c *gin.Context) {
 	skill, err := h.service.Update(c)
 	if err != nil {
@@ -134,6 +172,105 @@ func (h *SkillHandler) UpdateWithIcon(c *gin.Context) {
 	})
 }
 
+// ============================
+// SKILL CATEGORIES HANDLER
+// ============================
+
+type SkillCategoryHandler struct {
+	service service.SkillCategoryService
+}
+
+func NewSkillCategoryHandler(service service.SkillCategoryService) *SkillCategoryHandler {
+	return &SkillCategoryHandler{service: service}
+}
+
+func (h *SkillCategoryHandler) Create(c *gin.Context) {
+	category, err := h.service.Create(c)
+	if err != nil {
+		c.JSON(skillCategoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Skill category created successfully",
+		"data":    category,
+	})
+}
+
+func (h *SkillCategoryHandler) GetByID(c *gin.Context) {
+	category, err := h.service.GetByID(c)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Skill category retrieved successfully",
+		"data":    category,
+	})
+}
+
+func (h *SkillCategoryHandler) Update(c *gin.Context) {
+	category, err := h.service.Update(c)
+	if err != nil {
+		c.JSON(skillCategoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Skill category updated successfully",
+		"data":    category,
+	})
+}
+
+func (h *SkillCategoryHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c); err != nil {
+		c.JSON(skillCategoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Skill category deleted successfully",
+	})
+}
+
+func (h *SkillCategoryHandler) GetAll(c *gin.Context) {
+	categories, err := h.service.GetAll(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Skill categories retrieved successfully",
+		"data":    categories,
+	})
+}
+
+func (h *SkillCategoryHandler) GetGrouped(c *gin.Context) {
+	groups, err := h.service.GetGrouped(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Skills grouped by category retrieved successfully",
+		"data":    groups,
+	})
+}
+
+func skillCategoryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrSkillCategorySlugTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
 // ============================
 // CERTIFICATES HANDLER
 // ============================
@@ -222,6 +359,59 @@ func (h *CertificateHandler) CreateWithImage(c *gin.Context) {
 	})
 }
 
+func (h *CertificateHandler) UpdateWithImage(c *gin.Context) {
+	response, err := h.service.UpdateWithImage(c)
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    response,
+		"message": "Certificate updated successfully with image upload",
+	})
+}
+
+func (h *CertificateHandler) VerifyAll(c *gin.Context) {
+	if err := h.service.VerifyAll(c); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrCertificateVerifyRunning) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Certificate verification started",
+	})
+}
+
+func (h *CertificateHandler) Verify(c *gin.Context) {
+	cert, err := h.service.Verify(c)
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certificate verified successfully",
+		"data":    cert,
+	})
+}
+
+func certificateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCertificateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidCertificate),
+		errors.Is(err, service.ErrNoCredentialURL):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // ============================
 // EDUCATION HANDLER
 // ============================
@@ -439,6 +629,11 @@ func (h *BlogHandler) GetByIDWithTags(c *gin.Context) {
 func (h *BlogHandler) GetBySlugWithTags(c *gin.Context) {
 	post, err := h.service.GetBySlugWithTags(c)
 	if err != nil {
+		var moved *utils.SlugMovedError
+		if errors.As(err, &moved) {
+			redirectToSlug(c, moved.Slug)
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
@@ -512,149 +707,949 @@ func (h *BlogHandler) GetAllTags(c *gin.Context) {
 	})
 }
 
+func (h *BlogHandler) GetTagUsage(c *gin.Context) {
+	tags, err := h.service.GetTagUsage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tags retrieved successfully",
+		"data":    tags,
+	})
+}
+
+func (h *BlogHandler) RenameTag(c *gin.Context) {
+	tag, err := h.service.RenameTag(c)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrTagNameTaken) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag renamed successfully",
+		"data":    tag,
+	})
+}
+
+func (h *BlogHandler) MergeTags(c *gin.Context) {
+	tag, err := h.service.MergeTags(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tags merged successfully",
+		"data":    tag,
+	})
+}
+
+func (h *BlogHandler) DeleteTag(c *gin.Context) {
+	if err := h.service.DeleteTag(c); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrTagInUse) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag deleted successfully",
+	})
+}
+
+func (h *BlogHandler) DeleteUnusedTags(c *gin.Context) {
+	deleted, err := h.service.DeleteUnusedTags(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Unused tags deleted successfully",
+		"data":    gin.H{"deleted": deleted},
+	})
+}
+
+func (h *BlogHandler) GetRelated(c *gin.Context) {
+	posts, err := h.service.GetRelated(c)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Related blog posts retrieved successfully",
+		"data":    posts,
+	})
+}
+
 // ============================
-// SECTIONS HANDLER
+// BLOG COMMENTS HANDLER
 // ============================
 
-type SectionHandler struct {
-	service service.SectionService
+type CommentHandler struct {
+	service service.CommentService
 }
 
-func NewSectionHandler(service service.SectionService) *SectionHandler {
-	return &SectionHandler{service: service}
+func NewCommentHandler(service service.CommentService) *CommentHandler {
+	return &CommentHandler{service: service}
 }
 
-func (h *SectionHandler) Create(c *gin.Context) {
-	section, err := h.service.Create(c)
+func (h *CommentHandler) Create(c *gin.Context) {
+	comment, err := h.service.Create(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, service.ErrCommentsClosed):
+			status = http.StatusForbidden
+		case errors.Is(err, service.ErrCommentPostNotFound):
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Section created successfully",
-		"data":    section,
+		"message": "Comment submitted and awaiting moderation",
+		"data":    comment,
 	})
 }
 
-func (h *SectionHandler) Delete(c *gin.Context) {
-	if err := h.service.Delete(c); err != nil {
+func (h *CommentHandler) GetByPost(c *gin.Context) {
+	comments, err := h.service.GetByPost(c)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrCommentPostNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comments retrieved successfully",
+		"data":    comments,
+	})
+}
+
+func (h *CommentHandler) GetAll(c *gin.Context) {
+	comments, err := h.service.GetAll(c)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Section deleted successfully",
+		"message": "Comments retrieved successfully",
+		"data":    comments,
 	})
 }
 
-func (h *SectionHandler) GetAll(c *gin.Context) {
-	sections, err := h.service.GetAll(c)
+func (h *CommentHandler) UpdateStatus(c *gin.Context) {
+	comment, err := h.service.UpdateStatus(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Sections retrieved successfully",
-		"data":    sections,
+		"message": "Comment status updated successfully",
+		"data":    comment,
+	})
+}
+
+func (h *CommentHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment deleted successfully",
 	})
 }
 
 // ============================
-// SOCIAL LINKS HANDLER
+// BLOG ANALYTICS HANDLER
 // ============================
 
-type SocialLinkHandler struct {
-	service service.SocialLinkService
+type BlogAnalyticsHandler struct {
+	service service.BlogAnalyticsService
 }
 
-func NewSocialLinkHandler(service service.SocialLinkService) *SocialLinkHandler {
-	return &SocialLinkHandler{service: service}
+func NewBlogAnalyticsHandler(service service.BlogAnalyticsService) *BlogAnalyticsHandler {
+	return &BlogAnalyticsHandler{service: service}
 }
 
-func (h *SocialLinkHandler) Create(c *gin.Context) {
-	link, err := h.service.Create(c)
+func (h *BlogAnalyticsHandler) GetViewsOverTime(c *gin.Context) {
+	points, err := h.service.GetViewsOverTime(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "Social link created successfully",
-		"data":    link,
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Blog views retrieved successfully",
+		"data":    points,
 	})
 }
 
-func (h *SocialLinkHandler) Delete(c *gin.Context) {
-	if err := h.service.Delete(c); err != nil {
+func (h *BlogAnalyticsHandler) GetTopPosts(c *gin.Context) {
+	posts, err := h.service.GetTopPosts(c)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Social link deleted successfully",
+		"message": "Top posts retrieved successfully",
+		"data":    posts,
 	})
 }
 
-func (h *SocialLinkHandler) GetAll(c *gin.Context) {
-	links, err := h.service.GetAll(c)
+func (h *BlogAnalyticsHandler) GetTopReferrers(c *gin.Context) {
+	referrers, err := h.service.GetTopReferrers(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Social links retrieved successfully",
-		"data":    links,
+		"message": "Top referrers retrieved successfully",
+		"data":    referrers,
 	})
 }
 
 // ============================
-// SETTINGS HANDLER
+// PREVIEW LINKS HANDLER
 // ============================
 
-type SettingHandler struct {
-	service service.SettingService
+type PreviewLinkHandler struct {
+	service service.PreviewLinkService
 }
 
-func NewSettingHandler(service service.SettingService) *SettingHandler {
-	return &SettingHandler{service: service}
+func NewPreviewLinkHandler(service service.PreviewLinkService) *PreviewLinkHandler {
+	return &PreviewLinkHandler{service: service}
 }
 
-func (h *SettingHandler) Create(c *gin.Context) {
-	setting, err := h.service.Create(c)
+// PreviewAccess membaca ?preview_token= dan memberi akses draft ke entity yang ditautkan.
+// Token tidak valid diabaikan sehingga request diperlakukan seperti pengunjung biasa.
+func (h *PreviewLinkHandler) PreviewAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := c.Query("preview_token"); token != "" {
+			if access, err := h.service.Validate(token); err == nil {
+				c.Set(utils.PreviewContextKey, access)
+			}
+		}
+		c.Next()
+	}
+}
+
+func (h *PreviewLinkHandler) Create(c *gin.Context) {
+	link, err := h.service.Create(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Setting created successfully",
-		"data":    setting,
+		"message": "Preview link created successfully",
+		"data":    link,
 	})
 }
 
-func (h *SettingHandler) Delete(c *gin.Context) {
-	if err := h.service.Delete(c); err != nil {
+func (h *PreviewLinkHandler) GetActive(c *gin.Context) {
+	links, err := h.service.GetActive(c)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Setting deleted successfully",
+		"message": "Preview links retrieved successfully",
+		"data":    links,
 	})
 }
 
-func (h *SettingHandler) GetAll(c *gin.Context) {
-	settings, err := h.service.GetAll(c)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+func (h *PreviewLinkHandler) Revoke(c *gin.Context) {
+	if err := h.service.Revoke(c); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Settings retrieved successfully",
-		"data":    settings,
+		"message": "Preview link revoked successfully",
 	})
 }
+
+// ============================
+// DISPLAY ORDER HANDLER
+// ============================
+
+type DisplayOrderHandler struct {
+	service service.DisplayOrderService
+}
+
+func NewDisplayOrderHandler(service service.DisplayOrderService) *DisplayOrderHandler {
+	return &DisplayOrderHandler{service: service}
+}
+
+// Reorder membuat handler PUT /{entity}/order untuk entity yang diberikan
+func (h *DisplayOrderHandler) Reorder(entity string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		items, err := h.service.Reorder(c, entity)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, service.ErrInvalidOrderList) || errors.Is(err, service.ErrUnknownOrderID) {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Display order updated successfully",
+			"data":    items,
+		})
+	}
+}
+
+// ============================
+// TIMELINE HANDLER
+// ============================
+
+type TimelineHandler struct {
+	service service.TimelineService
+}
+
+func NewTimelineHandler(service service.TimelineService) *TimelineHandler {
+	return &TimelineHandler{service: service}
+}
+
+func (h *TimelineHandler) GetTimeline(c *gin.Context) {
+	entries, err := h.service.GetTimeline(c)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidTimelineFilter) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Timeline retrieved successfully",
+		"data":    entries,
+	})
+}
+
+// ============================
+// SECTIONS HANDLER
+// ============================
+
+type SectionHandler struct {
+	service service.SectionService
+}
+
+func NewSectionHandler(service service.SectionService) *SectionHandler {
+	return &SectionHandler{service: service}
+}
+
+// Create melakukan upsert berdasarkan section_id: 201 jika dibuat, 200 jika diperbarui
+func (h *SectionHandler) Create(c *gin.Context) {
+	section, created, err := h.service.Create(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if created {
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Section created successfully",
+			"data":    section,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Section updated successfully",
+		"data":    section,
+	})
+}
+
+func (h *SectionHandler) GetByID(c *gin.Context) {
+	section, err := h.service.GetByID(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Section retrieved successfully",
+		"data":    section,
+	})
+}
+
+func (h *SectionHandler) Update(c *gin.Context) {
+	section, created, err := h.service.Update(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if created {
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Section created successfully",
+			"data":    section,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Section updated successfully",
+		"data":    section,
+	})
+}
+
+func (h *SectionHandler) Patch(c *gin.Context) {
+	section, err := h.service.Patch(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Section updated successfully",
+		"data":    section,
+	})
+}
+
+func (h *SectionHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c); err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Section deleted successfully",
+	})
+}
+
+func (h *SectionHandler) GetAll(c *gin.Context) {
+	sections, err := h.service.GetAll(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sections retrieved successfully",
+		"data":    sections,
+	})
+}
+
+func (h *SectionHandler) GetActive(c *gin.Context) {
+	sections, err := h.service.GetActive(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Active sections retrieved successfully",
+		"data":    sections,
+	})
+}
+
+// ============================
+// SECTION BLOCKS HANDLER
+// ============================
+
+type SectionBlockHandler struct {
+	service service.SectionBlockService
+}
+
+func NewSectionBlockHandler(service service.SectionBlockService) *SectionBlockHandler {
+	return &SectionBlockHandler{service: service}
+}
+
+func (h *SectionBlockHandler) Create(c *gin.Context) {
+	block, err := h.service.Create(c)
+	if err != nil {
+		c.JSON(sectionBlockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Section block created successfully",
+		"data":    block,
+	})
+}
+
+func (h *SectionBlockHandler) GetBySection(c *gin.Context) {
+	blocks, err := h.service.GetBySection(c)
+	if err != nil {
+		c.JSON(sectionBlockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Section blocks retrieved successfully",
+		"data":    blocks,
+	})
+}
+
+func (h *SectionBlockHandler) GetAllBySection(c *gin.Context) {
+	blocks, err := h.service.GetAllBySection(c)
+	if err != nil {
+		c.JSON(sectionBlockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Section blocks retrieved successfully",
+		"data":    blocks,
+	})
+}
+
+func (h *SectionBlockHandler) Update(c *gin.Context) {
+	block, err := h.service.Update(c)
+	if err != nil {
+		c.JSON(sectionBlockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Section block updated successfully",
+		"data":    block,
+	})
+}
+
+func (h *SectionBlockHandler) Patch(c *gin.Context) {
+	block, err := h.service.Patch(c)
+	if err != nil {
+		c.JSON(sectionBlockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Section block updated successfully",
+		"data":    block,
+	})
+}
+
+func (h *SectionBlockHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c); err != nil {
+		c.JSON(sectionBlockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Section block deleted successfully",
+	})
+}
+
+func (h *SectionBlockHandler) Reorder(c *gin.Context) {
+	items, err := h.service.Reorder(c)
+	if err != nil {
+		c.JSON(sectionBlockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Display order updated successfully",
+		"data":    items,
+	})
+}
+
+// GetPage mengembalikan halaman lengkap: section aktif beserta block yang sudah di-resolve
+func (h *SectionBlockHandler) GetPage(c *gin.Context) {
+	page, err := h.service.GetPage(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Page retrieved successfully",
+		"data":    page,
+	})
+}
+
+func sectionBlockErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidBlockPayload),
+		errors.Is(err, service.ErrUnknownBlockType),
+		errors.Is(err, service.ErrInvalidOrderList),
+		errors.Is(err, service.ErrUnknownOrderID):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ============================
+// SOCIAL LINKS HANDLER
+// ============================
+
+type SocialLinkHandler struct {
+	service service.SocialLinkService
+}
+
+func NewSocialLinkHandler(service service.SocialLinkService) *SocialLinkHandler {
+	return &SocialLinkHandler{service: service}
+}
+
+// Create melakukan upsert berdasarkan platform: 201 jika dibuat, 200 jika diperbarui
+func (h *SocialLinkHandler) Create(c *gin.Context) {
+	link, created, err := h.service.Create(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if created {
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Social link created successfully",
+			"data":    link,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Social link updated successfully",
+		"data":    link,
+	})
+}
+
+func (h *SocialLinkHandler) GetByID(c *gin.Context) {
+	link, err := h.service.GetByID(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Social link retrieved successfully",
+		"data":    link,
+	})
+}
+
+func (h *SocialLinkHandler) Update(c *gin.Context) {
+	link, created, err := h.service.Update(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if created {
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Social link created successfully",
+			"data":    link,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Social link updated successfully",
+		"data":    link,
+	})
+}
+
+func (h *SocialLinkHandler) Patch(c *gin.Context) {
+	link, err := h.service.Patch(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Social link updated successfully",
+		"data":    link,
+	})
+}
+
+func (h *SocialLinkHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c); err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Social link deleted successfully",
+	})
+}
+
+func (h *SocialLinkHandler) GetAll(c *gin.Context) {
+	links, err := h.service.GetAll(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Social links retrieved successfully",
+		"data":    links,
+	})
+}
+
+func (h *SocialLinkHandler) GetActive(c *gin.Context) {
+	links, err := h.service.GetActive(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Active social links retrieved successfully",
+		"data":    links,
+	})
+}
+
+// ============================
+// PROFILE HANDLER
+// ============================
+
+type ProfileHandler struct {
+	service service.ProfileService
+}
+
+func NewProfileHandler(service service.ProfileService) *ProfileHandler {
+	return &ProfileHandler{service: service}
+}
+
+func (h *ProfileHandler) Get(c *gin.Context) {
+	profile, err := h.service.Get(c)
+	if err != nil {
+		c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Profile retrieved successfully",
+		"data":    profile,
+	})
+}
+
+func (h *ProfileHandler) Update(c *gin.Context) {
+	profile, err := h.service.Update(c)
+	if err != nil {
+		c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Profile updated successfully",
+		"data":    profile,
+	})
+}
+
+func (h *ProfileHandler) Patch(c *gin.Context) {
+	profile, err := h.service.Patch(c)
+	if err != nil {
+		c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Profile updated successfully",
+		"data":    profile,
+	})
+}
+
+func (h *ProfileHandler) UploadAvatar(c *gin.Context) {
+	profile, err := h.service.UploadAvatar(c)
+	if err != nil {
+		c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Avatar updated successfully",
+		"data":    profile,
+	})
+}
+
+func (h *ProfileHandler) DeleteAvatar(c *gin.Context) {
+	profile, err := h.service.DeleteAvatar(c)
+	if err != nil {
+		c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Avatar deleted successfully",
+		"data":    profile,
+	})
+}
+
+func profileErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidProfile):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ============================
+// SETTINGS HANDLER
+// ============================
+
+type SettingHandler struct {
+	service service.SettingService
+}
+
+func NewSettingHandler(service service.SettingService) *SettingHandler {
+	return &SettingHandler{service: service}
+}
+
+// Create melakukan upsert berdasarkan key: 201 jika dibuat, 200 jika diperbarui
+func (h *SettingHandler) Create(c *gin.Context) {
+	setting, created, err := h.service.Create(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if created {
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Setting created successfully",
+			"data":    setting,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Setting updated successfully",
+		"data":    setting,
+	})
+}
+
+func (h *SettingHandler) GetByKey(c *gin.Context) {
+	setting, err := h.service.GetByKey(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Setting retrieved successfully",
+		"data":    setting,
+	})
+}
+
+func (h *SettingHandler) Update(c *gin.Context) {
+	setting, created, err := h.service.Update(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if created {
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Setting created successfully",
+			"data":    setting,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Setting updated successfully",
+		"data":    setting,
+	})
+}
+
+func (h *SettingHandler) Patch(c *gin.Context) {
+	setting, err := h.service.Patch(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Setting updated successfully",
+		"data":    setting,
+	})
+}
+
+func (h *SettingHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c); err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Setting deleted successfully",
+	})
+}
+
+func (h *SettingHandler) GetAll(c *gin.Context) {
+	settings, err := h.service.GetAll(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Settings retrieved successfully",
+		"data":    settings,
+	})
+}
+
+// GetAllAdmin mengembalikan semua setting termasuk yang privat
+func (h *SettingHandler) GetAllAdmin(c *gin.Context) {
+	settings, err := h.service.GetAllAdmin(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Settings retrieved successfully",
+		"data":    settings,
+	})
+}
+
+func (h *SettingHandler) GetByKeyAdmin(c *gin.Context) {
+	setting, err := h.service.GetByKeyAdmin(c)
+	if err != nil {
+		c.JSON(naturalKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Setting retrieved successfully",
+		"data":    setting,
+	})
+}
+
+func naturalKeyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrNaturalKeyTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// redirectToSlug mengirim 301 ke URL yang sama dengan segmen slug terakhir diganti slug terbaru
+func redirectToSlug(c *gin.Context, slug string) {
+	location := path.Join(path.Dir(c.Request.URL.Path), url.PathEscape(slug))
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+	c.Redirect(http.StatusMovedPermanently, location)
+}
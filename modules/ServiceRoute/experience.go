@@ -1,10 +1,12 @@
 package serviceroute
 
 import (
+	"errors"
 	"gintugas/modules/components/experiences/service"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type GormExpeHandler struct {
@@ -93,3 +95,121 @@ func (c *GormExpeHandler) GetAllExperiencesWithRelations(ctx *gin.Context) {
 		"experiences": experiences,
 	})
 }
+
+func (c *GormExpeHandler) GetExperienceSummary(ctx *gin.Context) {
+	summary, err := c.expeService.GetExperienceSummary(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Experience summary retrieved successfully",
+		"summary": summary,
+	})
+}
+
+// ============================
+// RESPONSIBILITIES & SKILLS SUB-RESOURCE HANDLERS
+// ============================
+
+// subResourceStatus: experience/responsibility/skill yang tidak ada -> 404, input tidak valid -> 400, selain itu 500
+func subResourceStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidInput):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (c *GormExpeHandler) GetResponsibilities(ctx *gin.Context) {
+	responsibilities, err := c.expeService.GetResponsibilities(ctx)
+	if err != nil {
+		ctx.JSON(subResourceStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":          "Responsibilities retrieved successfully",
+		"responsibilities": responsibilities,
+	})
+}
+
+func (c *GormExpeHandler) CreateResponsibility(ctx *gin.Context) {
+	responsibility, err := c.expeService.CreateResponsibility(ctx)
+	if err != nil {
+		ctx.JSON(subResourceStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message":        "Responsibility created successfully",
+		"responsibility": responsibility,
+	})
+}
+
+func (c *GormExpeHandler) UpdateResponsibility(ctx *gin.Context) {
+	responsibility, err := c.expeService.UpdateResponsibility(ctx)
+	if err != nil {
+		ctx.JSON(subResourceStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":        "Responsibility updated successfully",
+		"responsibility": responsibility,
+	})
+}
+
+func (c *GormExpeHandler) DeleteResponsibility(ctx *gin.Context) {
+	if err := c.expeService.DeleteResponsibility(ctx); err != nil {
+		ctx.JSON(subResourceStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Responsibility deleted successfully",
+	})
+}
+
+func (c *GormExpeHandler) ReorderResponsibilities(ctx *gin.Context) {
+	responsibilities, err := c.expeService.ReorderResponsibilities(ctx)
+	if err != nil {
+		ctx.JSON(subResourceStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":          "Responsibilities reordered successfully",
+		"responsibilities": responsibilities,
+	})
+}
+
+func (c *GormExpeHandler) AddSkill(ctx *gin.Context) {
+	skill, err := c.expeService.AddSkill(ctx)
+	if err != nil {
+		ctx.JSON(subResourceStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Skill added to experience successfully",
+		"skill":   skill,
+	})
+}
+
+func (c *GormExpeHandler) RemoveSkill(ctx *gin.Context) {
+	if err := c.expeService.RemoveSkill(ctx); err != nil {
+		ctx.JSON(subResourceStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Skill removed from experience successfully",
+	})
+}
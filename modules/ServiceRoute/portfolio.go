@@ -0,0 +1,275 @@
+package serviceroute
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gintugas/modules/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ============================
+// PORTFOLIO SNAPSHOT HANDLER
+// ============================
+
+const defaultSnapshotTTL = 5 * time.Minute
+
+var ErrUnknownSnapshotPart = errors.New("unknown portfolio part")
+
+// snapshotPart menunjuk endpoint publik sebuah bagian snapshot. key adalah field response yang
+// berisi datanya, optional berarti 404 (mis. profil belum dibuat) dianggap kosong.
+type snapshotPart struct {
+	path     string
+	key      string
+	optional bool
+}
+
+// Setiap bagian snapshot diambil dari endpoint publiknya sendiri, sehingga isi dan
+// aturan visibilitasnya (draft, setting privat, testimonial pending) selalu sama.
+// Project diambil tanpa ?limit agar semua project published ikut, bukan hanya halaman pertama.
+var snapshotParts = map[string]snapshotPart{
+	"profile":      {path: "/api/v1/profile", key: "data", optional: true},
+	"page":         {path: "/api/v1/page", key: "data"},
+	"sections":     {path: "/api/v1/sections/active", key: "data"},
+	"projects":     {path: "/api/v1/projects?with_tags=true", key: "data"},
+	"experiences":  {path: "/api/v1/experiences/with-relations", key: "experiences"},
+	"skills":       {path: "/api/v1/skills", key: "data"},
+	"certificates": {path: "/api/v1/certificates", key: "data"},
+	"education":    {path: "/api/v1/education", key: "data"},
+	"testimonials": {path: "/api/v1/testimonials/status/approved", key: "data"},
+	"blog":         {path: "/api/v1/blog/published", key: "data"},
+	"social_links": {path: "/api/v1/social-links/active", key: "data"},
+	"settings":     {path: "/api/v1/settings", key: "data"},
+}
+
+type portfolioSnapshot struct {
+	body []byte
+	etag string
+}
+
+type PortfolioSnapshotHandler struct {
+	router   http.Handler
+	cache    *utils.MemoryCache
+	version  atomic.Uint64
+	building sync.Mutex
+}
+
+// NewPortfolioSnapshotHandler membuat handler snapshot. Cache dihapus setiap ada write lewat
+// InvalidateOnWrite dan kedaluwarsa sesuai PORTFOLIO_SNAPSHOT_TTL (durasi Go, default 5m)
+// untuk menangkap perubahan dari job background seperti GitHub sync.
+func NewPortfolioSnapshotHandler(router http.Handler) *PortfolioSnapshotHandler {
+	ttl := defaultSnapshotTTL
+	if value := os.Getenv("PORTFOLIO_SNAPSHOT_TTL"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			fmt.Printf("⚠️ PORTFOLIO_SNAPSHOT_TTL tidak valid (%q), memakai default %s\n", value, ttl)
+		} else {
+			ttl = parsed
+		}
+	}
+
+	return &PortfolioSnapshotHandler{
+		router: router,
+		cache:  utils.NewMemoryCache(ttl),
+	}
+}
+
+// InvalidateOnWrite menghapus cache snapshot setelah request non-GET yang berhasil
+func (h *PortfolioSnapshotHandler) InvalidateOnWrite() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+		if c.Writer.Status() < http.StatusBadRequest {
+			h.Invalidate()
+		}
+	}
+}
+
+// Invalidate menaikkan versi cache sehingga snapshot yang sedang dibangun dengan data lama tidak dipakai
+func (h *PortfolioSnapshotHandler) Invalidate() {
+	h.version.Add(1)
+	h.cache.Flush()
+}
+
+// GetSnapshot mengembalikan seluruh konten publik dalam satu response.
+// ?include=projects,skills memilih bagian tertentu, If-None-Match dijawab 304 jika ETag sama.
+func (h *PortfolioSnapshotHandler) GetSnapshot(c *gin.Context) {
+	parts, err := parseSnapshotInclude(c.QueryArray("include"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	snapshot, err := h.get(c.Request.Context(), parts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", snapshot.etag)
+	c.Header("Cache-Control", "public, no-cache")
+	if etagMatches(c.GetHeader("If-None-Match"), snapshot.etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", snapshot.body)
+}
+
+func (h *PortfolioSnapshotHandler) get(ctx context.Context, parts []string) (*portfolioSnapshot, error) {
+	version := h.version.Load()
+	key := fmt.Sprintf("%d:%s", version, strings.Join(parts, ","))
+	if cached, ok := h.cache.Get(key); ok {
+		return cached.(*portfolioSnapshot), nil
+	}
+
+	// Satu build dalam satu waktu agar cache miss bersamaan tidak membangun snapshot berkali-kali
+	h.building.Lock()
+	defer h.building.Unlock()
+	if cached, ok := h.cache.Get(key); ok {
+		return cached.(*portfolioSnapshot), nil
+	}
+
+	snapshot, complete, err := h.build(ctx, parts)
+	if err != nil {
+		return nil, err
+	}
+	// Snapshot dengan bagian yang gagal atau yang dibangun saat ada write tidak di-cache
+	if complete && h.version.Load() == version {
+		h.cache.Set(key, snapshot)
+	}
+	return snapshot, nil
+}
+
+// build memanggil endpoint setiap bagian secara paralel
+func (h *PortfolioSnapshotHandler) build(ctx context.Context, parts []string) (*portfolioSnapshot, bool, error) {
+	data := make(map[string]json.RawMessage, len(parts))
+	failures := make(map[string]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, part := range parts {
+		wg.Add(1)
+		go func(part string) {
+			defer wg.Done()
+			value, err := h.fetchPart(ctx, snapshotParts[part])
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures[part] = err.Error()
+				data[part] = json.RawMessage("null")
+				return
+			}
+			data[part] = value
+		}(part)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	response := gin.H{
+		"message": "Portfolio retrieved successfully",
+		"data":    data,
+	}
+	if len(failures) > 0 {
+		response["errors"] = failures
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return nil, false, err
+	}
+	sum := sha256.Sum256(body)
+	return &portfolioSnapshot{
+		body: body,
+		etag: `"` + hex.EncodeToString(sum[:16]) + `"`,
+	}, len(failures) == 0, nil
+}
+
+// fetchPart menjalankan GET internal tanpa header auth dan mengambil field data bagian tersebut.
+// 404 hanya dianggap kosong untuk bagian optional; field data yang tidak ada dianggap error.
+func (h *PortfolioSnapshotHandler) fetchPart(ctx context.Context, part snapshotPart) (json.RawMessage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, part.path, nil)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	h.router.ServeHTTP(recorder, req)
+
+	if recorder.Code == http.StatusNotFound && part.optional {
+		return json.RawMessage("null"), nil
+	}
+	if recorder.Code != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d", part.path, recorder.Code)
+	}
+
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(recorder.Body.Bytes(), &envelope); err != nil {
+		return nil, fmt.Errorf("%s returned invalid JSON: %v", part.path, err)
+	}
+	value, ok := envelope[part.key]
+	if !ok {
+		return nil, fmt.Errorf("%s response has no %q field", part.path, part.key)
+	}
+	if len(bytes.TrimSpace(value)) == 0 {
+		return json.RawMessage("null"), nil
+	}
+	return value, nil
+}
+
+// parseSnapshotInclude menerima ?include=a,b maupun ?include=a&include=b, kosong berarti semua bagian
+func parseSnapshotInclude(values []string) ([]string, error) {
+	selected := make(map[string]bool)
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.ToLower(strings.TrimSpace(part))
+			if part == "" {
+				continue
+			}
+			if _, ok := snapshotParts[part]; !ok {
+				return nil, fmt.Errorf("%w: %q", ErrUnknownSnapshotPart, part)
+			}
+			selected[part] = true
+		}
+	}
+
+	parts := make([]string, 0, len(snapshotParts))
+	for part := range snapshotParts {
+		if len(selected) == 0 || selected[part] {
+			parts = append(parts, part)
+		}
+	}
+	sort.Strings(parts)
+	return parts, nil
+}
+
+// etagMatches membandingkan If-None-Match dengan ETag (perbandingan lemah sesuai RFC 9110)
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
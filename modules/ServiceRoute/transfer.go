@@ -0,0 +1,113 @@
+package serviceroute
+
+import (
+	"errors"
+	"fmt"
+	"gintugas/modules/components/transfer/model"
+	"gintugas/modules/components/transfer/service"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ============================
+// PORTFOLIO EXPORT / IMPORT HANDLER
+// ============================
+
+const maxImportSize = 200 << 20
+
+type TransferHandler struct {
+	service service.TransferService
+}
+
+func NewTransferHandler(service service.TransferService) *TransferHandler {
+	return &TransferHandler{service: service}
+}
+
+// Export mengunduh seluruh konten portfolio. ?format=json|yaml, ?media=true membungkusnya dalam zip bersama file media.
+func (h *TransferHandler) Export(c *gin.Context) {
+	file, err := h.service.Export(c.Request.Context(), model.ExportOptions{
+		Format: c.Query("format"),
+		Media:  c.Query("media") == "true",
+	})
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file.Name))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
+
+// Import menerima body JSON/YAML/zip langsung atau sebagai field multipart "file".
+// ?mode=merge|replace, ?dry_run=true hanya memvalidasi, ?fresh_ids=true memberi UUID baru ke baris yang tidak cocok.
+func (h *TransferHandler) Import(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	data, err := readImportBody(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.service.Import(c.Request.Context(), data, model.ImportOptions{
+		Mode:     c.Query("mode"),
+		DryRun:   c.Query("dry_run") == "true",
+		FreshIDs: c.Query("fresh_ids") == "true",
+	})
+	if err != nil {
+		response := gin.H{"error": err.Error()}
+		if report != nil {
+			response["data"] = report
+		}
+		c.JSON(transferErrorStatus(err), response)
+		return
+	}
+
+	message := "Portfolio imported successfully"
+	if report.DryRun {
+		message = "Dry run completed, nothing was changed"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    report,
+	})
+}
+
+func readImportBody(c *gin.Context) ([]byte, error) {
+	if c.ContentType() == "multipart/form-data" {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, errors.New("file is required")
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("request body is empty")
+	}
+	return data, nil
+}
+
+func transferErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrImportRejected):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrInvalidDocument),
+		errors.Is(err, service.ErrUnsupportedFormat),
+		errors.Is(err, service.ErrInvalidImportMode):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -69,3 +69,37 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalAuthMiddleware mengisi info user ke context jika request membawa token valid,
+// tanpa menolak request anonim (dipakai di endpoint publik yang bisa menampilkan draft untuk admin)
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.Next()
+			return
+		}
+
+		tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
+		if blacklist.GetInstance().IsBlacklisted(tokenString) {
+			c.Next()
+			return
+		}
+
+		claims, err := utils.ValidateToken(tokenString)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		userID, _ := claims["user_id"].(string)
+		role, _ := claims["role"].(string)
+		if userID != "" && role != "" {
+			c.Set("user_id", userID)
+			c.Set("username", claims["username"])
+			c.Set("user_role", role)
+		}
+
+		c.Next()
+	}
+}
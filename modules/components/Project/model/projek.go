@@ -9,7 +9,9 @@ import (
 type Project struct {
 	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	Title        string    `json:"title" gorm:"column:title;type:varchar(200);not null"`
+	Slug         string    `json:"slug" gorm:"column:slug;type:varchar(200);unique;not null"`
 	Description  string    `json:"description" gorm:"column:description;type:text;not null"`
+	CaseStudy    string    `json:"case_study" gorm:"column:case_study;type:text"` // markdown: problem, solution, results
 	ImageURL     string    `json:"image_url" gorm:"column:image_url;type:varchar(500)"`
 	DemoURL      string    `json:"demo_url" gorm:"column:demo_url;type:varchar(500)"`
 	CodeURL      string    `json:"code_url" gorm:"column:code_url;type:varchar(500);not null"`
@@ -20,27 +22,159 @@ type Project struct {
 	UpdatedAt    time.Time `json:"updated_at" gorm:"column:updated_at"`
 
 	// Relations
-	Tags []ProjectTag `json:"tags,omitempty" gorm:"many2many:project_tag_relations;"`
+	Tags   []ProjectTag        `json:"tags,omitempty" gorm:"many2many:project_tag_relations;joinForeignKey:ProjectID;joinReferences:TagID"`
+	Media  []ProjectMedia      `json:"media,omitempty" gorm:"-"`
+	GitHub *ProjectGitHubStats `json:"github,omitempty" gorm:"-"`
+
+	// Pekerjaan tempat project dibuat dan skill yang dipakai, diisi oleh AttachRelationsRepository
+	ExperienceID *uuid.UUID         `json:"experience_id" gorm:"-"`
+	Experience   *ProjectExperience `json:"experience,omitempty" gorm:"-"`
+	Skills       []ProjectSkill     `json:"skills,omitempty" gorm:"-"`
+}
+
+// ProjectExperience ringkasan experience (pekerjaan) yang terkait dengan project
+type ProjectExperience struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	Company    string    `json:"company"`
+	StartYear  string    `json:"start_year"`
+	EndYear    string    `json:"end_year"`
+	CurrentJob bool      `json:"current_job"`
+}
+
+// ProjectSkill skill dari katalog portfolio_skills yang dipakai project
+type ProjectSkill struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Category string    `json:"category"`
+	IconURL  string    `json:"icon_url"`
+}
+
+// ProjectExperienceRequest experience_id null melepas project dari experience
+type ProjectExperienceRequest struct {
+	ExperienceID *uuid.UUID `json:"experience_id"`
+}
+
+// ProjectSkillsRequest mengganti seluruh skill project, list kosong menghapus semuanya
+type ProjectSkillsRequest struct {
+	SkillIDs []uuid.UUID `json:"skill_ids" binding:"required"`
+}
+
+// ProjectGitHubStats metadata repository GitHub dari CodeURL, diperbarui oleh sync berkala
+type ProjectGitHubStats struct {
+	ProjectID    uuid.UUID  `json:"-"`
+	RepoFullName string     `json:"repo"`
+	Stars        int        `json:"stars"`
+	Forks        int        `json:"forks"`
+	Language     string     `json:"language"`
+	Topics       []string   `json:"topics"`
+	PushedAt     *time.Time `json:"pushed_at"`
+	ETag         string     `json:"-"`
+	LastError    string     `json:"-"` // bisa berisi detail internal, hanya ditampilkan lewat ProjectGitHubSyncStatus
+	SyncedAt     time.Time  `json:"synced_at"`
+}
+
+// ProjectGitHubSyncStatus metadata GitHub beserta error sync terakhir, hanya untuk endpoint admin
+type ProjectGitHubSyncStatus struct {
+	ProjectGitHubStats
+	LastError string `json:"last_error,omitempty"`
+}
+
+// NewProjectGitHubSyncStatus membungkus stats untuk response admin, nil tetap nil
+func NewProjectGitHubSyncStatus(stats *ProjectGitHubStats) *ProjectGitHubSyncStatus {
+	if stats == nil {
+		return nil
+	}
+	return &ProjectGitHubSyncStatus{ProjectGitHubStats: *stats, LastError: stats.LastError}
+}
+
+// ProjectTagSuggestion hasil pencocokan topic GitHub dengan project_tags
+type ProjectTagSuggestion struct {
+	Matched   []ProjectTag `json:"matched"`   // tag yang sudah ada tapi belum terpasang di project
+	Unmatched []string     `json:"unmatched"` // topic yang belum punya tag
+}
+
+// ProjectMedia satu gambar gallery pada case study project
+type ProjectMedia struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProjectID    uuid.UUID `json:"project_id" gorm:"column:project_id;type:uuid;not null"`
+	URL          string    `json:"url" gorm:"column:url;type:varchar(500);not null"`
+	Caption      string    `json:"caption" gorm:"column:caption;type:varchar(300)"`
+	DisplayOrder int       `json:"display_order" gorm:"column:display_order;type:integer;default:0"`
+	CreatedAt    time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+// ProjectMediaOrderRequest berisi seluruh ID media project dalam urutan yang diinginkan
+type ProjectMediaOrderRequest struct {
+	MediaIDs []uuid.UUID `json:"media_ids" binding:"required,min=1"`
+}
+
+// ProjectRelatedScore hasil perhitungan kedekatan tag antar project (bobot berdasarkan kelangkaan tag)
+type ProjectRelatedScore struct {
+	ProjectID  uuid.UUID
+	SharedTags int
+	Score      float64
+}
+
+type RelatedProject struct {
+	Project
+	SharedTags int     `json:"shared_tags"`
+	Score      float64 `json:"score"`
+	Similarity float64 `json:"similarity"`
+}
+
+// ProjectFilter parameter query untuk GET /api/v1/projects
+type ProjectFilter struct {
+	Statuses   []string // kosong = semua status
+	IsFeatured *bool
+	Tags       []string // ID atau nama tag (case-insensitive), cocok jika project punya salah satunya
+	Skills     []string // ID atau nama skill (case-insensitive), cocok jika project punya salah satunya
+	Experience *uuid.UUID
+	Sort       string // display_order, created_at, title
+	Desc       bool
+	Limit      int // 0 = tanpa batas
+	Offset     int
+}
+
+type ProjectListMeta struct {
+	Total  int `json:"total"`
+	Limit  int `json:"limit"` // 0 = semua project (tanpa ?limit)
+	Offset int `json:"offset"`
+}
+
+type ProjectListResult struct {
+	Projects []Project
+	Meta     ProjectListMeta
 }
 
 type ProjectForm struct {
 	Title        string `form:"title" binding:"required"`
+	Slug         string `form:"slug"` // Opsional, dibuat otomatis dari title jika kosong
 	Description  string `form:"description" binding:"required"`
+	CaseStudy    string `form:"case_study"`
 	CodeURL      string `form:"code_url" binding:"required"`
 	DemoURL      string `form:"demo_url"`
 	Status       string `form:"status"`
 	DisplayOrder int    `form:"display_order"`
 	IsFeatured   bool   `form:"is_featured"`
+
+	// Caption untuk file "gallery" sesuai urutan upload
+	Captions []string `form:"captions"`
 }
 
 type ProjectUpdateForm struct {
-	Title        string `form:"title"`
-	Description  string `form:"description"`
-	DemoURL      string `form:"demo_url"`
-	CodeURL      string `form:"code_url"`
-	DisplayOrder int    `form:"display_order"`
-	IsFeatured   bool   `form:"is_featured"`
-	Status       string `form:"status"`
+	Title        string  `form:"title"`
+	Slug         string  `form:"slug"`
+	Description  string  `form:"description"`
+	CaseStudy    *string `form:"case_study"` // Pointer agar case study bisa dikosongkan
+	DemoURL      string  `form:"demo_url"`
+	CodeURL      string  `form:"code_url"`
+	DisplayOrder *int    `form:"display_order"` // Pointer agar nilai 0 bisa di-set
+	IsFeatured   bool    `form:"is_featured"`
+	Status       string  `form:"status"`
+
+	// Caption untuk file "gallery" baru, ditambahkan di akhir gallery
+	Captions []string `form:"captions"`
 }
 
 type ProjectTag struct {
@@ -57,10 +191,33 @@ type TagResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type ProjectTagRequest struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color"` // HEX: #RGB atau #RRGGBB
+}
+
+type ProjectTagUpdateRequest struct {
+	Name  *string `json:"name" binding:"omitempty,max=50"`
+	Color *string `json:"color"`
+}
+
+// ProjectTagUsage tag beserta jumlah project yang memakainya
+type ProjectTagUsage struct {
+	ID           uuid.UUID `json:"id" gorm:"column:id"`
+	Name         string    `json:"name" gorm:"column:name"`
+	Color        string    `json:"color" gorm:"column:color"`
+	ProjectCount int       `json:"project_count" gorm:"column:project_count"`
+	CreatedAt    time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
 func (Project) TableName() string {
 	return "portfolio_projects"
 }
 
+func (ProjectMedia) TableName() string {
+	return "project_media"
+}
+
 func (ProjectTag) TableName() string {
 	return "project_tags"
 }
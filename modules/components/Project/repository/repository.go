@@ -8,22 +8,56 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
+// ErrProjectNotFound dikembalikan jika project dengan ID/slug tersebut tidak ada
+var ErrProjectNotFound = errors.New("project not found")
+
 type Repository interface {
 	CreateProjekRepository(projek Project) (Project, error)
 	GetAllProjekRepository() ([]Project, error)
+	ListProjekRepository(filter ProjectFilter) ([]Project, int, error)
+	AttachTagsRepository(projects []Project) error
 	GetProjekRepository(id uuid.UUID) (Project, error)
 	UpdateProjekRepository(projek Project) (Project, error)
 	DeleteProjekRepository(id uuid.UUID) error
 	GetProjekWithTagsRepository(id uuid.UUID) (Project, error)
 	GetAllProjekWithTagsRepository() ([]Project, error)
 	GetAllTagsRepository() (result []ProjectTag, err error)
+	GetRelatedScoresRepository(id uuid.UUID) ([]ProjectRelatedScore, error)
+	SlugExistsRepository(slug string, excludeID uuid.UUID) (bool, error)
+	GetProjekBySlugRepository(slug string) (Project, error)
+	GetCurrentSlugRepository(oldSlug string) (string, error)
+
+	// Gallery media
+	AddProjectMediaRepository(projectID uuid.UUID, media []ProjectMedia) ([]ProjectMedia, error)
+	GetProjectMediaRepository(projectID uuid.UUID) ([]ProjectMedia, error)
+	GetProjectMediaByIDRepository(id uuid.UUID) (ProjectMedia, error)
+	DeleteProjectMediaRepository(id uuid.UUID) error
+	ReorderProjectMediaRepository(projectID uuid.UUID, mediaIDs []uuid.UUID) error
+
+	// GitHub metadata
+	GetGitHubStatsRepository(projectID uuid.UUID) (*ProjectGitHubStats, error)
+	AttachGitHubStatsRepository(projects []Project) error
+	UpsertGitHubStatsRepository(stats ProjectGitHubStats) error
+	GetProjectTagsRepository(projectID uuid.UUID) ([]ProjectTag, error)
+
+	// Relasi ke experience dan katalog skill
+	AttachRelationsRepository(projects []Project) error
+	SetProjectExperienceRepository(projectID uuid.UUID, experienceID *uuid.UUID) error
+	SetProjectSkillsRepository(projectID uuid.UUID, skillIDs []uuid.UUID) error
 }
 
 type TagsRepository interface {
 	CreateTags(Tags *ProjectTag) error
+	GetTagByID(id uuid.UUID) (*ProjectTag, error)
+	TagNameExists(name string, excludeID uuid.UUID) (bool, error)
+	UpdateTags(tag *ProjectTag) error
+	DeleteTags(id uuid.UUID) error
+	CountTagUsage(id uuid.UUID) (int64, error)
+	GetTagsWithUsage() ([]ProjectTagUsage, error)
 }
 
 type repository struct {
@@ -68,15 +102,17 @@ func (r *repository) GetAllTagsRepository() (result []ProjectTag, err error) {
 func (r *repository) CreateProjekRepository(projek Project) (Project, error) {
 	query := `
 		INSERT INTO portfolio_projects 
-		(title, description, image_url, demo_url, code_url, display_order, is_featured, status) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
+		(title, slug, description, case_study, image_url, demo_url, code_url, display_order, is_featured, status) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) 
 		RETURNING id, created_at, updated_at
 	`
 
 	err := r.db.QueryRow(
 		query,
 		projek.Title,
+		projek.Slug,
 		projek.Description,
+		projek.CaseStudy,
 		projek.ImageURL,
 		projek.DemoURL,
 		projek.CodeURL,
@@ -94,7 +130,7 @@ func (r *repository) CreateProjekRepository(projek Project) (Project, error) {
 
 func (r *repository) GetAllProjekRepository() ([]Project, error) {
 	query := `
-		SELECT id, title, description, image_url, demo_url, code_url, 
+		SELECT id, title, slug, description, case_study, image_url, demo_url, code_url, 
 		       display_order, is_featured, status, created_at, updated_at
 		FROM portfolio_projects 
 		ORDER BY display_order ASC
@@ -112,7 +148,9 @@ func (r *repository) GetAllProjekRepository() ([]Project, error) {
 		err := rows.Scan(
 			&project.ID,
 			&project.Title,
+			&project.Slug,
 			&project.Description,
+			&project.CaseStudy,
 			&project.ImageURL,
 			&project.DemoURL,
 			&project.CodeURL,
@@ -131,9 +169,164 @@ func (r *repository) GetAllProjekRepository() ([]Project, error) {
 	return projects, nil
 }
 
+// Kolom yang boleh dipakai untuk sorting, dipetakan agar input user tidak masuk ke query
+var projectSortColumns = map[string]string{
+	"display_order": "p.display_order",
+	"created_at":    "p.created_at",
+	"title":         "LOWER(p.title)",
+}
+
+// ListProjekRepository mengembalikan project sesuai filter beserta total sebelum pagination
+func (r *repository) ListProjekRepository(filter ProjectFilter) ([]Project, int, error) {
+	var conditions []string
+	var params []interface{}
+
+	addParam := func(value interface{}) string {
+		params = append(params, value)
+		return fmt.Sprintf("$%d", len(params))
+	}
+
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "p.status = ANY("+addParam(pq.Array(filter.Statuses))+")")
+	}
+	if filter.IsFeatured != nil {
+		conditions = append(conditions, "p.is_featured = "+addParam(*filter.IsFeatured))
+	}
+	if filter.Experience != nil {
+		conditions = append(conditions, "p.experience_id = "+addParam(*filter.Experience))
+	}
+	if len(filter.Skills) > 0 {
+		lowered := make([]string, len(filter.Skills))
+		for i, skill := range filter.Skills {
+			lowered[i] = strings.ToLower(skill)
+		}
+		skillsParam := addParam(pq.Array(lowered))
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM project_skills pks
+			INNER JOIN portfolio_skills s ON s.id = pks.skill_id
+			WHERE pks.project_id = p.id AND (s.id::text = ANY(%s) OR LOWER(s.name) = ANY(%s))
+		)`, skillsParam, skillsParam))
+	}
+	if len(filter.Tags) > 0 {
+		lowered := make([]string, len(filter.Tags))
+		for i, tag := range filter.Tags {
+			lowered[i] = strings.ToLower(tag)
+		}
+		tagsParam := addParam(pq.Array(lowered))
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM project_tag_relations ptr
+			INNER JOIN project_tags pt ON pt.id = ptr.tag_id
+			WHERE ptr.project_id = p.id AND (pt.id::text = ANY(%s) OR LOWER(pt.name) = ANY(%s))
+		)`, tagsParam, tagsParam))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	sortColumn, ok := projectSortColumns[filter.Sort]
+	if !ok {
+		sortColumn = projectSortColumns["display_order"]
+	}
+	direction := "ASC"
+	if filter.Desc {
+		direction = "DESC"
+	}
+
+	// Parameter filter saja, dipakai ulang oleh query COUNT di bawah
+	filterParams := params
+
+	limit := "ALL"
+	if filter.Limit > 0 {
+		limit = addParam(filter.Limit)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT p.id, p.title, p.slug, p.description, p.case_study, p.image_url, p.demo_url, p.code_url, 
+		       p.display_order, p.is_featured, p.status, p.created_at, p.updated_at,
+		       COUNT(*) OVER() AS total
+		FROM portfolio_projects p
+		%s
+		ORDER BY %s %s, p.id ASC
+		LIMIT %s OFFSET %s
+	`, where, sortColumn, direction, limit, addParam(filter.Offset))
+
+	rows, err := r.db.Query(query, params...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	projects := []Project{}
+	total := 0
+	for rows.Next() {
+		var project Project
+		err := rows.Scan(
+			&project.ID,
+			&project.Title,
+			&project.Slug,
+			&project.Description,
+			&project.CaseStudy,
+			&project.ImageURL,
+			&project.DemoURL,
+			&project.CodeURL,
+			&project.DisplayOrder,
+			&project.IsFeatured,
+			&project.Status,
+			&project.CreatedAt,
+			&project.UpdatedAt,
+			&total,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		projects = append(projects, project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	// Offset melewati data terakhir: total tetap dihitung untuk pagination
+	if len(projects) == 0 && filter.Offset > 0 {
+		countQuery := "SELECT COUNT(*) FROM portfolio_projects p " + where
+		if err := r.db.QueryRow(countQuery, filterParams...).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return projects, total, nil
+}
+
+// AttachTagsRepository mengisi Tags untuk setiap project dalam satu query
+func (r *repository) AttachTagsRepository(projects []Project) error {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	projectIDs := make([]uuid.UUID, len(projects))
+	for i := range projects {
+		projectIDs[i] = projects[i].ID
+		projects[i].Tags = []ProjectTag{}
+	}
+
+	tags, err := r.getTagsForMultipleProjects(projectIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range projects {
+		if projectTags, ok := tags[projects[i].ID]; ok {
+			projects[i].Tags = projectTags
+		}
+	}
+
+	return nil
+}
+
 func (r *repository) GetProjekRepository(id uuid.UUID) (Project, error) {
 	query := `
-		SELECT id, title, description, image_url, demo_url, code_url, 
+		SELECT id, title, slug, description, case_study, image_url, demo_url, code_url, 
 		       display_order, is_featured, status, created_at, updated_at
 		FROM portfolio_projects 
 		WHERE id = $1
@@ -143,7 +336,9 @@ func (r *repository) GetProjekRepository(id uuid.UUID) (Project, error) {
 	err := r.db.QueryRow(query, id).Scan(
 		&project.ID,
 		&project.Title,
+		&project.Slug,
 		&project.Description,
+		&project.CaseStudy,
 		&project.ImageURL,
 		&project.DemoURL,
 		&project.CodeURL,
@@ -156,7 +351,7 @@ func (r *repository) GetProjekRepository(id uuid.UUID) (Project, error) {
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return Project{}, errors.New("project not found")
+			return Project{}, ErrProjectNotFound
 		}
 		return Project{}, err
 	}
@@ -165,19 +360,37 @@ func (r *repository) GetProjekRepository(id uuid.UUID) (Project, error) {
 }
 
 func (r *repository) UpdateProjekRepository(projek Project) (Project, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return Project{}, err
+	}
+	defer tx.Rollback()
+
+	// Ambil slug lama untuk dicatat ke history jika berubah
+	var oldSlug string
+	err = tx.QueryRow(`SELECT slug FROM portfolio_projects WHERE id = $1 FOR UPDATE`, projek.ID).Scan(&oldSlug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Project{}, ErrProjectNotFound
+		}
+		return Project{}, err
+	}
+
 	query := `
 		UPDATE portfolio_projects 
-		SET title = $1, description = $2, image_url = $3, demo_url = $4, 
-		    code_url = $5, display_order = $6, is_featured = $7, status = $8,
+		SET title = $1, slug = $2, description = $3, case_study = $4, image_url = $5, demo_url = $6, 
+		    code_url = $7, display_order = $8, is_featured = $9, status = $10,
 			updated_at = NOW()
-		WHERE id = $9
+		WHERE id = $11
 		RETURNING updated_at
 	`
 
-	err := r.db.QueryRow(
+	err = tx.QueryRow(
 		query,
 		projek.Title,
+		projek.Slug,
 		projek.Description,
+		projek.CaseStudy,
 		projek.ImageURL,
 		projek.DemoURL,
 		projek.CodeURL,
@@ -191,9 +404,306 @@ func (r *repository) UpdateProjekRepository(projek Project) (Project, error) {
 		return Project{}, err
 	}
 
+	if oldSlug != projek.Slug {
+		_, err = tx.Exec(`
+			INSERT INTO project_slug_history (slug, project_id) VALUES ($1, $2)
+			ON CONFLICT (slug) DO UPDATE SET project_id = EXCLUDED.project_id, created_at = NOW()
+		`, oldSlug, projek.ID)
+		if err != nil {
+			return Project{}, err
+		}
+
+		// Slug baru tidak boleh tetap tercatat sebagai slug lama
+		if _, err = tx.Exec(`DELETE FROM project_slug_history WHERE slug = $1`, projek.Slug); err != nil {
+			return Project{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Project{}, err
+	}
+
 	return projek, nil
 }
 
+// SlugExistsRepository mengecek apakah slug sudah dipakai project lain, baik sebagai slug aktif maupun slug lama
+func (r *repository) SlugExistsRepository(slug string, excludeID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM portfolio_projects WHERE slug = $1 AND id <> $2
+			UNION ALL
+			SELECT 1 FROM project_slug_history WHERE slug = $1 AND project_id <> $2
+		)
+	`
+
+	var exists bool
+	err := r.db.QueryRow(query, slug, excludeID).Scan(&exists)
+	return exists, err
+}
+
+func (r *repository) GetProjekBySlugRepository(slug string) (Project, error) {
+	var id uuid.UUID
+	err := r.db.QueryRow(`SELECT id FROM portfolio_projects WHERE slug = $1`, slug).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Project{}, ErrProjectNotFound
+		}
+		return Project{}, err
+	}
+
+	return r.GetProjekWithTagsRepository(id)
+}
+
+// GetCurrentSlugRepository mencari slug aktif dari sebuah slug lama
+func (r *repository) GetCurrentSlugRepository(oldSlug string) (string, error) {
+	query := `
+		SELECT p.slug
+		FROM project_slug_history h
+		INNER JOIN portfolio_projects p ON p.id = h.project_id
+		WHERE h.slug = $1
+	`
+
+	var slug string
+	err := r.db.QueryRow(query, oldSlug).Scan(&slug)
+	return slug, err
+}
+
+// AddProjectMediaRepository menambahkan media di akhir gallery project sesuai urutan slice
+func (r *repository) AddProjectMediaRepository(projectID uuid.UUID, media []ProjectMedia) ([]ProjectMedia, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var nextOrder int
+	err = tx.QueryRow(`SELECT COALESCE(MAX(display_order) + 1, 0) FROM project_media WHERE project_id = $1`, projectID).Scan(&nextOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO project_media (project_id, url, caption, display_order)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	created := make([]ProjectMedia, 0, len(media))
+	for i, item := range media {
+		item.ProjectID = projectID
+		item.DisplayOrder = nextOrder + i
+		if err := tx.QueryRow(query, projectID, item.URL, item.Caption, item.DisplayOrder).Scan(&item.ID, &item.CreatedAt); err != nil {
+			return nil, err
+		}
+		created = append(created, item)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r *repository) GetProjectMediaRepository(projectID uuid.UUID) ([]ProjectMedia, error) {
+	query := `
+		SELECT id, project_id, url, caption, display_order, created_at
+		FROM project_media
+		WHERE project_id = $1
+		ORDER BY display_order ASC, created_at ASC
+	`
+
+	rows, err := r.db.Query(query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	media := []ProjectMedia{}
+	for rows.Next() {
+		var item ProjectMedia
+		if err := rows.Scan(&item.ID, &item.ProjectID, &item.URL, &item.Caption, &item.DisplayOrder, &item.CreatedAt); err != nil {
+			return nil, err
+		}
+		media = append(media, item)
+	}
+
+	return media, rows.Err()
+}
+
+func (r *repository) GetProjectMediaByIDRepository(id uuid.UUID) (ProjectMedia, error) {
+	query := `
+		SELECT id, project_id, url, caption, display_order, created_at
+		FROM project_media
+		WHERE id = $1
+	`
+
+	var item ProjectMedia
+	err := r.db.QueryRow(query, id).Scan(&item.ID, &item.ProjectID, &item.URL, &item.Caption, &item.DisplayOrder, &item.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ProjectMedia{}, errors.New("media not found")
+		}
+		return ProjectMedia{}, err
+	}
+
+	return item, nil
+}
+
+func (r *repository) DeleteProjectMediaRepository(id uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM project_media WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("media not found")
+	}
+
+	return nil
+}
+
+// ReorderProjectMediaRepository mengisi display_order sesuai posisi ID di slice
+func (r *repository) ReorderProjectMediaRepository(projectID uuid.UUID, mediaIDs []uuid.UUID) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i, id := range mediaIDs {
+		result, err := tx.Exec(`UPDATE project_media SET display_order = $1 WHERE id = $2 AND project_id = $3`, i, id, projectID)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("media %s not found in this project", id)
+		}
+	}
+
+	return tx.Commit()
+}
+
+const githubStatsColumns = `project_id, repo_full_name, stars, forks, language, topics, pushed_at, etag, last_error, synced_at`
+
+func scanGitHubStats(scanner interface{ Scan(...interface{}) error }) (ProjectGitHubStats, error) {
+	var stats ProjectGitHubStats
+	err := scanner.Scan(
+		&stats.ProjectID,
+		&stats.RepoFullName,
+		&stats.Stars,
+		&stats.Forks,
+		&stats.Language,
+		pq.Array(&stats.Topics),
+		&stats.PushedAt,
+		&stats.ETag,
+		&stats.LastError,
+		&stats.SyncedAt,
+	)
+	return stats, err
+}
+
+// GetGitHubStatsRepository mengembalikan nil tanpa error jika project belum pernah di-sync
+func (r *repository) GetGitHubStatsRepository(projectID uuid.UUID) (*ProjectGitHubStats, error) {
+	row := r.db.QueryRow(`SELECT `+githubStatsColumns+` FROM project_github_stats WHERE project_id = $1`, projectID)
+	stats, err := scanGitHubStats(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// AttachGitHubStatsRepository mengisi field GitHub untuk banyak project dalam satu query
+func (r *repository) AttachGitHubStatsRepository(projects []Project) error {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(projects))
+	for i := range projects {
+		ids[i] = projects[i].ID.String()
+	}
+
+	rows, err := r.db.Query(`SELECT `+githubStatsColumns+` FROM project_github_stats WHERE project_id::text = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	statsMap := make(map[uuid.UUID]*ProjectGitHubStats)
+	for rows.Next() {
+		stats, err := scanGitHubStats(rows)
+		if err != nil {
+			return err
+		}
+		statsMap[stats.ProjectID] = &stats
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range projects {
+		projects[i].GitHub = statsMap[projects[i].ID]
+	}
+	return nil
+}
+
+func (r *repository) UpsertGitHubStatsRepository(stats ProjectGitHubStats) error {
+	query := `
+		INSERT INTO project_github_stats (` + githubStatsColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+		ON CONFLICT (project_id) DO UPDATE SET
+			repo_full_name = EXCLUDED.repo_full_name,
+			stars = EXCLUDED.stars,
+			forks = EXCLUDED.forks,
+			language = EXCLUDED.language,
+			topics = EXCLUDED.topics,
+			pushed_at = EXCLUDED.pushed_at,
+			etag = EXCLUDED.etag,
+			last_error = EXCLUDED.last_error,
+			synced_at = NOW()
+	`
+
+	topics := stats.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+
+	_, err := r.db.Exec(query,
+		stats.ProjectID,
+		stats.RepoFullName,
+		stats.Stars,
+		stats.Forks,
+		stats.Language,
+		pq.Array(topics),
+		stats.PushedAt,
+		stats.ETag,
+		stats.LastError,
+	)
+	return err
+}
+
+func (r *repository) GetProjectTagsRepository(projectID uuid.UUID) ([]ProjectTag, error) {
+	tags, err := r.getTagsForMultipleProjects([]uuid.UUID{projectID})
+	if err != nil {
+		return nil, err
+	}
+	return tags[projectID], nil
+}
+
 func (r *repository) DeleteProjekRepository(id uuid.UUID) error {
 	query := `DELETE FROM portfolio_projects WHERE id = $1`
 
@@ -208,7 +718,7 @@ func (r *repository) DeleteProjekRepository(id uuid.UUID) error {
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("project not found")
+		return ErrProjectNotFound
 	}
 
 	return nil
@@ -252,7 +762,7 @@ func (r *repository) GetProjekWithTagsRepository(id uuid.UUID) (Project, error)
 func (r *repository) GetAllProjekWithTagsRepository() ([]Project, error) {
 	// Query untuk mendapatkan semua projects
 	projectQuery := `
-		SELECT id, title, description, image_url, demo_url, code_url, 
+		SELECT id, title, slug, description, case_study, image_url, demo_url, code_url, 
 		       display_order, is_featured, status, created_at, updated_at
 		FROM portfolio_projects 
 		ORDER BY display_order ASC
@@ -273,7 +783,9 @@ func (r *repository) GetAllProjekWithTagsRepository() ([]Project, error) {
 		err := projectRows.Scan(
 			&project.ID,
 			&project.Title,
+			&project.Slug,
 			&project.Description,
+			&project.CaseStudy,
 			&project.ImageURL,
 			&project.DemoURL,
 			&project.CodeURL,
@@ -380,6 +892,231 @@ func (r *repository) getTagsForMultipleProjects(projectIDs []uuid.UUID) (map[uui
 	return tagsMap, nil
 }
 
+// GetRelatedScoresRepository menghitung skor kedekatan project published lain berdasarkan tag yang sama.
+// Setiap tag diberi bobot ln((N+1)/df) sehingga tag yang jarang dipakai bernilai lebih tinggi.
+func (r *repository) GetRelatedScoresRepository(id uuid.UUID) ([]ProjectRelatedScore, error) {
+	query := `
+		WITH published AS (
+			SELECT id FROM portfolio_projects WHERE status = 'published'
+		),
+		tag_df AS (
+			SELECT ptr.tag_id, COUNT(*) AS df
+			FROM project_tag_relations ptr
+			JOIN published p ON p.id = ptr.project_id
+			GROUP BY ptr.tag_id
+		),
+		total AS (
+			SELECT COUNT(*) AS n FROM published
+		)
+		SELECT other.project_id,
+		       COUNT(*) AS shared_tags,
+		       SUM(LN((total.n + 1.0) / tag_df.df))::float8 AS score
+		FROM project_tag_relations src
+		JOIN project_tag_relations other ON other.tag_id = src.tag_id AND other.project_id <> src.project_id
+		JOIN published p ON p.id = other.project_id
+		JOIN tag_df ON tag_df.tag_id = src.tag_id
+		CROSS JOIN total
+		WHERE src.project_id = $1
+		GROUP BY other.project_id
+	`
+
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []ProjectRelatedScore
+	for rows.Next() {
+		var score ProjectRelatedScore
+		if err := rows.Scan(&score.ProjectID, &score.SharedTags, &score.Score); err != nil {
+			return nil, err
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, nil
+}
+
 func (r *tagsRepository) CreateTags(Tags *ProjectTag) error {
 	return r.db.Create(Tags).Error
 }
+
+func (r *tagsRepository) GetTagByID(id uuid.UUID) (*ProjectTag, error) {
+	var tag ProjectTag
+	err := r.db.Where("id = ?", id).First(&tag).Error
+	return &tag, err
+}
+
+func (r *tagsRepository) TagNameExists(name string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&ProjectTag{}).
+		Where("LOWER(name) = LOWER(?) AND id <> ?", name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *tagsRepository) UpdateTags(tag *ProjectTag) error {
+	return r.db.Model(&ProjectTag{}).
+		Where("id = ?", tag.ID).
+		Updates(map[string]interface{}{
+			"name":  tag.Name,
+			"color": tag.Color,
+		}).Error
+}
+
+// DeleteTags menghapus tag, relasi project ikut terhapus karena ON DELETE CASCADE
+func (r *tagsRepository) DeleteTags(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&ProjectTag{}).Error
+}
+
+func (r *tagsRepository) CountTagUsage(id uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&ProjectTagRelation{}).Where("tag_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *tagsRepository) GetTagsWithUsage() ([]ProjectTagUsage, error) {
+	var tags []ProjectTagUsage
+	err := r.db.Table("project_tags t").
+		Select("t.id, t.name, COALESCE(t.color, '') AS color, t.created_at, COUNT(ptr.project_id) AS project_count").
+		Joins("LEFT JOIN project_tag_relations ptr ON ptr.tag_id = t.id").
+		Group("t.id, t.name, t.color, t.created_at").
+		Order("project_count DESC, t.name ASC").
+		Scan(&tags).Error
+	return tags, err
+}
+
+// AttachRelationsRepository mengisi ExperienceID, Experience, dan Skills untuk setiap project
+func (r *repository) AttachRelationsRepository(projects []Project) error {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(projects))
+	index := make(map[uuid.UUID]int, len(projects))
+	for i := range projects {
+		ids[i] = projects[i].ID.String()
+		index[projects[i].ID] = i
+		projects[i].Skills = []ProjectSkill{}
+	}
+
+	rows, err := r.db.Query(`
+		SELECT p.id, e.id, e.title, e.company, COALESCE(e.start_year, ''), COALESCE(e.end_year, ''), COALESCE(e.current_job, false)
+		FROM portfolio_projects p
+		INNER JOIN portfolio_experiences e ON e.id = p.experience_id
+		WHERE p.id::text = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var projectID uuid.UUID
+		var experience ProjectExperience
+		if err := rows.Scan(&projectID, &experience.ID, &experience.Title, &experience.Company,
+			&experience.StartYear, &experience.EndYear, &experience.CurrentJob); err != nil {
+			return err
+		}
+		if i, ok := index[projectID]; ok {
+			experienceID := experience.ID
+			projects[i].ExperienceID = &experienceID
+			projects[i].Experience = &experience
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	skillRows, err := r.db.Query(`
+		SELECT pks.project_id, s.id, s.name, COALESCE(sc.label, ''), COALESCE(s.icon_url, '')
+		FROM project_skills pks
+		INNER JOIN portfolio_skills s ON s.id = pks.skill_id
+		LEFT JOIN skill_categories sc ON sc.id = s.category_id
+		WHERE pks.project_id::text = ANY($1)
+		ORDER BY s.display_order ASC, s.name ASC
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer skillRows.Close()
+
+	for skillRows.Next() {
+		var projectID uuid.UUID
+		var skill ProjectSkill
+		if err := skillRows.Scan(&projectID, &skill.ID, &skill.Name, &skill.Category, &skill.IconURL); err != nil {
+			return err
+		}
+		if i, ok := index[projectID]; ok {
+			projects[i].Skills = append(projects[i].Skills, skill)
+		}
+	}
+	return skillRows.Err()
+}
+
+// SetProjectExperienceRepository menautkan project ke experience, nil melepas tautannya
+func (r *repository) SetProjectExperienceRepository(projectID uuid.UUID, experienceID *uuid.UUID) error {
+	if experienceID != nil {
+		var exists bool
+		if err := r.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM portfolio_experiences WHERE id = $1)`, *experienceID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("experience %s tidak ditemukan", *experienceID)
+		}
+	}
+
+	result, err := r.db.Exec(`UPDATE portfolio_projects SET experience_id = $1, updated_at = NOW() WHERE id = $2`, experienceID, projectID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("projek tidak ditemukan")
+	}
+	return nil
+}
+
+// SetProjectSkillsRepository mengganti seluruh skill project dalam satu transaksi
+func (r *repository) SetProjectSkillsRepository(projectID uuid.UUID, skillIDs []uuid.UUID) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`UPDATE portfolio_projects SET updated_at = NOW() WHERE id = $1`, projectID)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if rowsAffected == 0 {
+		return errors.New("projek tidak ditemukan")
+	}
+
+	if _, err := tx.Exec(`DELETE FROM project_skills WHERE project_id = $1`, projectID); err != nil {
+		return err
+	}
+
+	for _, skillID := range skillIDs {
+		var exists bool
+		if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM portfolio_skills WHERE id = $1)`, skillID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("skill %s tidak ditemukan", skillID)
+		}
+
+		if _, err := tx.Exec(`INSERT INTO project_skills (project_id, skill_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, projectID, skillID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
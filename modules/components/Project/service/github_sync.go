@@ -0,0 +1,441 @@
+package projectservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	. "gintugas/modules/components/Project/model"
+	. "gintugas/modules/components/Project/repository"
+	"gintugas/modules/utils"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultGitHubAPIURL       = "https://api.github.com"
+	defaultGitHubSyncInterval = 6 * time.Hour
+	// Jeda antar request agar sync semua project tidak memicu secondary rate limit
+	githubRequestSpacing = 500 * time.Millisecond
+)
+
+var (
+	ErrNotGitHubRepo     = errors.New("code_url bukan repository GitHub")
+	ErrGitHubNotModified = errors.New("github: repository tidak berubah")
+	ErrGitHubSyncRunning = errors.New("sync GitHub sedang berjalan")
+	ErrGitHubNotFound    = errors.New("github: repository tidak ditemukan")
+	ErrGitHubNotSynced   = errors.New("projek belum di-sync dengan GitHub")
+	errGitHubRateLimited = errors.New("github: rate limit tercapai")
+)
+
+// GitHubRateLimitError dikembalikan saat kuota API habis, ResetAt adalah waktu request boleh dicoba lagi
+type GitHubRateLimitError struct {
+	ResetAt time.Time
+}
+
+func (e *GitHubRateLimitError) Error() string {
+	return fmt.Sprintf("%v, coba lagi setelah %s", errGitHubRateLimited, e.ResetAt.Format(time.RFC3339))
+}
+
+func (e *GitHubRateLimitError) Unwrap() error {
+	return errGitHubRateLimited
+}
+
+// GitHubRepoInfo field yang dipakai dari GET /repos/{owner}/{repo}
+type GitHubRepoInfo struct {
+	FullName string     `json:"full_name"`
+	Stars    int        `json:"stargazers_count"`
+	Forks    int        `json:"forks_count"`
+	Language string     `json:"language"`
+	Topics   []string   `json:"topics"`
+	PushedAt *time.Time `json:"pushed_at"`
+}
+
+// GitHubClient client REST API GitHub dengan dukungan ETag dan rate limit
+type GitHubClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+
+	mu             sync.Mutex
+	rateLimitReset time.Time
+}
+
+func NewGitHubClient(baseURL, token string) *GitHubClient {
+	if baseURL == "" {
+		baseURL = defaultGitHubAPIURL
+	}
+	return &GitHubClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// NewGitHubClientFromEnv membaca GITHUB_API_URL (misal server lokal untuk testing) dan GITHUB_TOKEN
+func NewGitHubClientFromEnv() *GitHubClient {
+	return NewGitHubClient(os.Getenv("GITHUB_API_URL"), os.Getenv("GITHUB_TOKEN"))
+}
+
+// GetRepo mengambil metadata repository. Jika etag masih sama, mengembalikan ErrGitHubNotModified
+// (response 304 tidak mengurangi kuota rate limit).
+func (c *GitHubClient) GetRepo(fullName, etag string) (*GitHubRepoInfo, string, error) {
+	c.mu.Lock()
+	resetAt := c.rateLimitReset
+	c.mu.Unlock()
+	if time.Now().Before(resetAt) {
+		return nil, "", &GitHubRateLimitError{ResetAt: resetAt}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+"/repos/"+fullName, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "gintugas-portfolio")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	c.trackRateLimit(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var info GitHubRepoInfo
+		if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+			return nil, "", fmt.Errorf("github: response tidak valid: %v", err)
+		}
+		return &info, resp.Header.Get("ETag"), nil
+	case http.StatusNotModified:
+		return nil, etag, ErrGitHubNotModified
+	case http.StatusNotFound:
+		return nil, "", ErrGitHubNotFound
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		c.mu.Lock()
+		resetAt := c.rateLimitReset
+		c.mu.Unlock()
+		if time.Now().Before(resetAt) {
+			return nil, "", &GitHubRateLimitError{ResetAt: resetAt}
+		}
+		return nil, "", fmt.Errorf("github: akses ditolak (%d)", resp.StatusCode)
+	default:
+		return nil, "", fmt.Errorf("github: status %d", resp.StatusCode)
+	}
+}
+
+// trackRateLimit menyimpan kapan request boleh dilakukan lagi berdasarkan Retry-After atau X-RateLimit-*
+func (c *GitHubClient) trackRateLimit(resp *http.Response) {
+	var resetAt time.Time
+
+	if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && retryAfter > 0 {
+		resetAt = time.Now().Add(time.Duration(retryAfter) * time.Second)
+	} else if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			resetAt = time.Unix(reset, 0)
+		}
+	}
+
+	if !resetAt.IsZero() {
+		c.mu.Lock()
+		c.rateLimitReset = resetAt
+		c.mu.Unlock()
+	}
+}
+
+// ParseGitHubRepo mengambil "owner/repo" dari URL seperti https://github.com/owner/repo(.git)(/tree/...)
+func ParseGitHubRepo(codeURL string) (string, bool) {
+	parsed, err := url.Parse(strings.TrimSpace(codeURL))
+	if err != nil {
+		return "", false
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if host != "github.com" {
+		return "", false
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+
+	repo := strings.TrimSuffix(parts[1], ".git")
+	return parts[0] + "/" + repo, true
+}
+
+// GitHubSyncSummary ringkasan satu kali sync semua project
+type GitHubSyncSummary struct {
+	Updated          int        `json:"updated"`
+	NotModified      int        `json:"not_modified"`
+	Skipped          int        `json:"skipped"`
+	Failed           int        `json:"failed"`
+	RateLimitedUntil *time.Time `json:"rate_limited_until,omitempty"`
+}
+
+type GitHubService interface {
+	SyncAllService(ctx *gin.Context) error
+	SyncProjectService(ctx *gin.Context) (*ProjectGitHubSyncStatus, error)
+	GetSyncStatusService(ctx *gin.Context) (*ProjectGitHubSyncStatus, error)
+	SuggestTagsService(ctx *gin.Context) (ProjectTagSuggestion, error)
+}
+
+type githubService struct {
+	repository Repository
+	client     *GitHubClient
+	running    sync.Mutex
+}
+
+// NewGitHubService membuat service sync dan menjalankan sync berkala sesuai GITHUB_SYNC_INTERVAL
+// (durasi Go, default 6h; "0" untuk mematikan sync otomatis). autoSync false (database tidak
+// terhubung) mematikan sync berkala.
+func NewGitHubService(repository Repository, client *GitHubClient, autoSync bool) GitHubService {
+	s := &githubService{
+		repository: repository,
+		client:     client,
+	}
+
+	interval := defaultGitHubSyncInterval
+	if value := os.Getenv("GITHUB_SYNC_INTERVAL"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			fmt.Printf("⚠️ GITHUB_SYNC_INTERVAL tidak valid (%v), memakai default %s\n", err, interval)
+		} else {
+			interval = parsed
+		}
+	}
+
+	if autoSync && interval > 0 {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for range ticker.C {
+				if !s.running.TryLock() {
+					continue
+				}
+				s.runSyncAll()
+			}
+		}()
+	}
+
+	return s
+}
+
+// SyncAllService menjalankan sync semua project di background karena ada jeda antar request
+// GitHub; hasilnya dicatat di log seperti sync berkala
+func (s *githubService) SyncAllService(ctx *gin.Context) error {
+	if !s.running.TryLock() {
+		return ErrGitHubSyncRunning
+	}
+	go s.runSyncAll()
+	return nil
+}
+
+// runSyncAll dipanggil setelah lock running didapat dan melepaskannya setelah selesai
+func (s *githubService) runSyncAll() {
+	defer s.running.Unlock()
+
+	utils.RunSafely("GitHub sync", func() {
+		summary, err := s.syncAll()
+		if err != nil {
+			fmt.Printf("⚠️ GitHub sync gagal: %v\n", err)
+			return
+		}
+		fmt.Printf("🔄 GitHub sync: %d updated, %d not modified, %d skipped, %d failed\n",
+			summary.Updated, summary.NotModified, summary.Skipped, summary.Failed)
+	})
+}
+
+func (s *githubService) syncAll() (GitHubSyncSummary, error) {
+	var summary GitHubSyncSummary
+
+	projects, err := s.repository.GetAllProjekRepository()
+	if err != nil {
+		return summary, err
+	}
+
+	for i, project := range projects {
+		if i > 0 {
+			time.Sleep(githubRequestSpacing)
+		}
+
+		_, err := s.syncProject(project)
+		var rateLimited *GitHubRateLimitError
+		switch {
+		case err == nil:
+			summary.Updated++
+		case errors.Is(err, ErrGitHubNotModified):
+			summary.NotModified++
+		case errors.Is(err, ErrNotGitHubRepo):
+			summary.Skipped++
+		case errors.As(err, &rateLimited):
+			// Sisa project dilanjutkan pada sync berikutnya
+			summary.RateLimitedUntil = &rateLimited.ResetAt
+			return summary, nil
+		default:
+			summary.Failed++
+		}
+	}
+
+	return summary, nil
+}
+
+func (s *githubService) SyncProjectService(ctx *gin.Context) (*ProjectGitHubSyncStatus, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, errors.New("ID projek tidak valid")
+	}
+
+	project, err := s.repository.GetProjekRepository(id)
+	if err != nil {
+		return nil, errors.New("projek tidak ditemukan")
+	}
+
+	stats, err := s.syncProject(project)
+	if errors.Is(err, ErrGitHubNotModified) {
+		return NewProjectGitHubSyncStatus(stats), nil
+	}
+	return NewProjectGitHubSyncStatus(stats), err
+}
+
+// GetSyncStatusService mengembalikan metadata GitHub tersimpan beserta last_error untuk admin
+func (s *githubService) GetSyncStatusService(ctx *gin.Context) (*ProjectGitHubSyncStatus, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, errors.New("ID projek tidak valid")
+	}
+
+	stats, err := s.repository.GetGitHubStatsRepository(id)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		return nil, ErrGitHubNotSynced
+	}
+	return NewProjectGitHubSyncStatus(stats), nil
+}
+
+// syncProject memperbarui metadata satu project. Error selain rate limit dicatat di last_error
+// tanpa menghapus data yang sudah tersimpan.
+func (s *githubService) syncProject(project Project) (*ProjectGitHubStats, error) {
+	fullName, ok := ParseGitHubRepo(project.CodeURL)
+	if !ok {
+		return nil, ErrNotGitHubRepo
+	}
+
+	existing, err := s.repository.GetGitHubStatsRepository(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := ProjectGitHubStats{ProjectID: project.ID, RepoFullName: fullName}
+	etag := ""
+	if existing != nil && strings.EqualFold(existing.RepoFullName, fullName) {
+		stats = *existing
+		etag = existing.ETag
+	}
+
+	info, newETag, err := s.client.GetRepo(fullName, etag)
+	var rateLimited *GitHubRateLimitError
+	switch {
+	case errors.As(err, &rateLimited):
+		return nil, err
+	case errors.Is(err, ErrGitHubNotModified):
+		stats.LastError = ""
+	case err != nil:
+		stats.LastError = err.Error()
+	default:
+		stats.RepoFullName = info.FullName
+		stats.Stars = info.Stars
+		stats.Forks = info.Forks
+		stats.Language = info.Language
+		stats.Topics = info.Topics
+		stats.PushedAt = info.PushedAt
+		stats.ETag = newETag
+		stats.LastError = ""
+	}
+
+	if upsertErr := s.repository.UpsertGitHubStatsRepository(stats); upsertErr != nil {
+		return nil, upsertErr
+	}
+	stats.SyncedAt = time.Now()
+
+	return &stats, err
+}
+
+// normalizeTopic menyamakan "node-js", "Node.js", dan "nodejs"
+func normalizeTopic(value string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '+' || r == '#' {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// SuggestTagsService mencocokkan topic GitHub project dengan project_tags yang ada
+func (s *githubService) SuggestTagsService(ctx *gin.Context) (ProjectTagSuggestion, error) {
+	suggestion := ProjectTagSuggestion{Matched: []ProjectTag{}, Unmatched: []string{}}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return suggestion, errors.New("ID projek tidak valid")
+	}
+
+	stats, err := s.repository.GetGitHubStatsRepository(id)
+	if err != nil {
+		return suggestion, err
+	}
+	if stats == nil {
+		return suggestion, ErrGitHubNotSynced
+	}
+
+	allTags, err := s.repository.GetAllTagsRepository()
+	if err != nil {
+		return suggestion, err
+	}
+	attached, err := s.repository.GetProjectTagsRepository(id)
+	if err != nil {
+		return suggestion, err
+	}
+
+	tagsByName := make(map[string]ProjectTag, len(allTags))
+	for _, tag := range allTags {
+		tagsByName[normalizeTopic(tag.Name)] = tag
+	}
+	attachedIDs := make(map[uuid.UUID]bool, len(attached))
+	for _, tag := range attached {
+		attachedIDs[tag.ID] = true
+	}
+
+	for _, topic := range stats.Topics {
+		tag, ok := tagsByName[normalizeTopic(topic)]
+		if !ok {
+			suggestion.Unmatched = append(suggestion.Unmatched, topic)
+			continue
+		}
+		if !attachedIDs[tag.ID] {
+			suggestion.Matched = append(suggestion.Matched, tag)
+			attachedIDs[tag.ID] = true
+		}
+	}
+
+	return suggestion, nil
+}
@@ -1,6 +1,7 @@
 package projectservice
 
 import (
+	model "gintugas/modules/components/Project/model"
 	repository "gintugas/modules/components/Project/repository"
 	"net/http"
 
@@ -63,6 +64,7 @@ func (s *ProjectMemberService) AddTag(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	relatedProjectsCache.Flush()
 
 	// Get project dengan tags untuk response
 	pID, _ := uuid.Parse(projectID)
@@ -98,6 +100,7 @@ func (s *ProjectMemberService) RemoveTag(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	relatedProjectsCache.Flush()
 
 	// Get project dengan tags untuk response
 	pID, _ := uuid.Parse(projectID)
@@ -133,3 +136,32 @@ func (s *ProjectMemberService) GetProjectTags(ctx *gin.Context) {
 		"data": tags,
 	})
 }
+
+// GetProjectsByTag mendapatkan semua project yang memakai tag. Selain admin hanya melihat project published.
+func (s *ProjectMemberService) GetProjectsByTag(ctx *gin.Context) {
+	tagID := ctx.Param("id")
+
+	// Validasi UUID
+	if _, err := uuid.Parse(tagID); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID format"})
+		return
+	}
+
+	projects, err := s.MemberRepo.GetProjectsByTag(tagID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	isAdmin := ctx.GetString("user_role") == "admin"
+	visible := []model.Project{}
+	for _, project := range projects {
+		if isAdmin || project.Status == "published" {
+			visible = append(visible, project)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": visible,
+	})
+}
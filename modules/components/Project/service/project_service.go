@@ -11,7 +11,11 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -19,17 +23,58 @@ import (
 
 type Service interface {
 	GetAllTagsService(ctx *gin.Context) (result []ProjectTag, err error)
-	GetAllProjekService(ctx *gin.Context) ([]Project, error)
+	GetAllProjekService(ctx *gin.Context) (ProjectListResult, error)
 	GetProjekService(ctx *gin.Context) (Project, error)
+	GetProjekBySlugService(ctx *gin.Context) (Project, error)
 	UpdateProjekService(ctx *gin.Context) (Project, error)
 	DeleteProjekService(ctx *gin.Context) error
 	CreateProjekWithImageService(ctx *gin.Context) (Project, error)
+	GetRelatedProjekService(ctx *gin.Context) ([]RelatedProject, error)
+
+	// Gallery media
+	GetProjectMediaService(ctx *gin.Context) ([]ProjectMedia, error)
+	ReorderProjectMediaService(ctx *gin.Context) ([]ProjectMedia, error)
+	DeleteProjectMediaService(ctx *gin.Context) error
+
+	// Relasi ke experience dan katalog skill
+	SetProjectExperienceService(ctx *gin.Context) (Project, error)
+	SetProjectSkillsService(ctx *gin.Context) (Project, error)
 }
 
 type TagsService interface {
 	CreateTags(ctx *gin.Context) (*TagResponse, error)
+	UpdateTags(ctx *gin.Context) (*TagResponse, error)
+	DeleteTags(ctx *gin.Context) error
+	GetTagUsage(ctx *gin.Context) ([]ProjectTagUsage, error)
+}
+
+var (
+	ErrProjectTagInUse     = errors.New("tag masih dipakai project, gunakan ?force=true untuk menghapus beserta relasinya")
+	ErrProjectTagNameTaken = errors.New("nama tag sudah dipakai")
+	ErrInvalidProjectID    = errors.New("ID projek tidak valid")
+)
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// normalizeHexColor memvalidasi warna HEX dan menyeragamkannya ke #RRGGBB huruf besar
+func normalizeHexColor(color string) (string, error) {
+	color = strings.TrimSpace(color)
+	if color == "" {
+		return "", nil
+	}
+	if !hexColorPattern.MatchString(color) {
+		return "", fmt.Errorf("warna %q bukan kode HEX yang valid (contoh: #1E90FF)", color)
+	}
+	if len(color) == 4 {
+		color = "#" + strings.Repeat(color[1:2], 2) + strings.Repeat(color[2:3], 2) + strings.Repeat(color[3:4], 2)
+	}
+	return strings.ToUpper(color), nil
 }
 
+// relatedProjectsCache dipakai bersama oleh projectService dan ProjectMemberService,
+// di-flush setiap kali project atau relasi tag berubah
+var relatedProjectsCache = utils.NewMemoryCache(30 * time.Minute)
+
 type projectService struct {
 	repository    Repository
 	uploadPath    string
@@ -172,6 +217,18 @@ func (s *projectService) CreateProjekWithImageService(ctx *gin.Context) (Project
 		return Project{}, errors.New("judul projek harus diisi")
 	}
 
+	// Slug opsional, dibuat dari judul jika kosong
+	slugSource := form.Slug
+	if slugSource == "" {
+		slugSource = form.Title
+	}
+	slug, err := utils.UniqueSlug(slugSource, "project", func(candidate string) (bool, error) {
+		return s.repository.SlugExistsRepository(candidate, uuid.Nil)
+	})
+	if err != nil {
+		return Project{}, fmt.Errorf("gagal membuat slug: %v", err)
+	}
+
 	// Handle file upload
 	file, err := ctx.FormFile("image")
 	imageURL := ""
@@ -207,6 +264,15 @@ func (s *projectService) CreateProjekWithImageService(ctx *gin.Context) (Project
 		}
 	}
 
+	// Handle gallery upload (multi-file, field "gallery")
+	gallery, err := s.uploadGalleryFiles(ctx, form.Captions)
+	if err != nil {
+		if imageURL != "" {
+			s.uploadService.DeleteFile(imageURL)
+		}
+		return Project{}, err
+	}
+
 	// Set default values
 	if form.Status == "" {
 		form.Status = "published"
@@ -221,7 +287,9 @@ func (s *projectService) CreateProjekWithImageService(ctx *gin.Context) (Project
 	// Convert form to Project entity
 	project := Project{
 		Title:        form.Title,
+		Slug:         slug,
 		Description:  form.Description,
+		CaseStudy:    form.CaseStudy,
 		ImageURL:     imageURL, // URL dari Supabase atau local
 		DemoURL:      form.DemoURL,
 		CodeURL:      form.CodeURL,
@@ -239,9 +307,25 @@ func (s *projectService) CreateProjekWithImageService(ctx *gin.Context) (Project
 			fmt.Printf("🧹 Cleaning up uploaded file: %s\n", imageURL)
 			s.uploadService.DeleteFile(imageURL)
 		}
+		s.deleteMediaFiles(gallery)
 		return Project{}, fmt.Errorf("gagal menyimpan data projek: %v", err)
 	}
 
+	if len(gallery) > 0 {
+		result.Media, err = s.repository.AddProjectMediaRepository(result.ID, gallery)
+		if err != nil {
+			// Batalkan project agar tidak tersimpan tanpa gallery yang diminta
+			s.repository.DeleteProjekRepository(result.ID)
+			if imageURL != "" {
+				s.uploadService.DeleteFile(imageURL)
+			}
+			s.deleteMediaFiles(gallery)
+			return Project{}, fmt.Errorf("gagal menyimpan gallery projek: %v", err)
+		}
+	}
+
+	relatedProjectsCache.Flush()
+
 	fmt.Printf("✅ Project created successfully with ID: %s\n", result.ID)
 	fmt.Printf("   Image URL: %s\n", result.ImageURL)
 
@@ -257,15 +341,121 @@ func (s *projectService) GetAllTagsService(ctx *gin.Context) (result []ProjectTa
 	return Tags, nil
 }
 
-func (s *projectService) GetAllProjekService(ctx *gin.Context) ([]Project, error) {
+// ErrInvalidProjectFilter membungkus kesalahan parameter query pada list project
+var ErrInvalidProjectFilter = errors.New("filter projek tidak valid")
+
+// splitQueryValues mendukung ?tag=a&tag=b maupun ?tag=a,b
+func splitQueryValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+// parseProjectFilter membaca status, is_featured, tag, sort, order, limit, dan offset.
+// Selain admin hanya bisa melihat project published. Tanpa ?limit semua project dikembalikan
+// seperti sebelum pagination ada; ?limit dibatasi maksimal 100.
+func parseProjectFilter(ctx *gin.Context) (ProjectFilter, error) {
+	filter := ProjectFilter{
+		Tags:   splitQueryValues(ctx.QueryArray("tag")),
+		Skills: splitQueryValues(ctx.QueryArray("skill")),
+		Sort:   "display_order",
+	}
+	if ctx.Query("limit") != "" {
+		filter.Limit = utils.ParseLimit(ctx.Query("limit"), 50, 100)
+	}
+
+	if experience := ctx.Query("experience"); experience != "" {
+		experienceID, err := uuid.Parse(experience)
+		if err != nil {
+			return filter, fmt.Errorf("%w: experience harus berupa ID experience", ErrInvalidProjectFilter)
+		}
+		filter.Experience = &experienceID
+	}
+
+	statuses := splitQueryValues(ctx.QueryArray("status"))
+	if ctx.GetString("user_role") != "admin" {
+		filter.Statuses = []string{"published"}
+	} else if len(statuses) > 0 && !(len(statuses) == 1 && statuses[0] == "all") {
+		filter.Statuses = statuses
+	}
+
+	if featured := ctx.Query("is_featured"); featured != "" {
+		value, err := strconv.ParseBool(featured)
+		if err != nil {
+			return filter, fmt.Errorf("%w: is_featured harus true atau false", ErrInvalidProjectFilter)
+		}
+		filter.IsFeatured = &value
+	}
+
+	// ?sort=-created_at sama dengan ?sort=created_at&order=desc
+	if sortParam := ctx.Query("sort"); sortParam != "" {
+		filter.Desc = strings.HasPrefix(sortParam, "-")
+		filter.Sort = strings.TrimPrefix(sortParam, "-")
+		if filter.Sort != "display_order" && filter.Sort != "created_at" && filter.Sort != "title" {
+			return filter, fmt.Errorf("%w: sort harus display_order, created_at, atau title", ErrInvalidProjectFilter)
+		}
+	}
+	switch strings.ToLower(ctx.Query("order")) {
+	case "":
+	case "asc":
+		filter.Desc = false
+	case "desc":
+		filter.Desc = true
+	default:
+		return filter, fmt.Errorf("%w: order harus asc atau desc", ErrInvalidProjectFilter)
+	}
+
+	if offset := ctx.Query("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return filter, fmt.Errorf("%w: offset harus bilangan bulat >= 0", ErrInvalidProjectFilter)
+		}
+		filter.Offset = value
+	}
+
+	return filter, nil
+}
+
+func (s *projectService) GetAllProjekService(ctx *gin.Context) (ProjectListResult, error) {
+	filter, err := parseProjectFilter(ctx)
+	if err != nil {
+		return ProjectListResult{}, err
+	}
+
+	projects, total, err := s.repository.ListProjekRepository(filter)
+	if err != nil {
+		return ProjectListResult{}, err
+	}
+
 	// Check query parameter for with_tags
-	withTags := ctx.Query("with_tags")
+	if ctx.Query("with_tags") == "true" {
+		if err := s.repository.AttachTagsRepository(projects); err != nil {
+			return ProjectListResult{}, err
+		}
+	}
 
-	if withTags == "true" {
-		return s.repository.GetAllProjekWithTagsRepository()
+	if err := s.repository.AttachGitHubStatsRepository(projects); err != nil {
+		return ProjectListResult{}, err
+	}
+
+	if err := s.repository.AttachRelationsRepository(projects); err != nil {
+		return ProjectListResult{}, err
 	}
 
-	return s.repository.GetAllProjekRepository()
+	return ProjectListResult{
+		Projects: projects,
+		Meta: ProjectListMeta{
+			Total:  total,
+			Limit:  filter.Limit,
+			Offset: filter.Offset,
+		},
+	}, nil
 }
 
 func (s *projectService) GetProjekService(ctx *gin.Context) (Project, error) {
@@ -275,14 +465,73 @@ func (s *projectService) GetProjekService(ctx *gin.Context) (Project, error) {
 		return Project{}, errors.New("ID projek tidak valid")
 	}
 
+	var project Project
+
 	// Check query parameter for with_tags
 	withTags := ctx.Query("with_tags")
 
 	if withTags == "true" {
-		return s.repository.GetProjekWithTagsRepository(id)
+		project, err = s.repository.GetProjekWithTagsRepository(id)
+	} else {
+		project, err = s.repository.GetProjekRepository(id)
+	}
+	if err != nil {
+		return Project{}, err
+	}
+
+	// Draft hanya terlihat oleh admin atau pemegang preview token
+	if project.Status != "published" && !utils.CanViewDraft(ctx, "project", project.ID) {
+		return Project{}, ErrProjectNotFound
+	}
+
+	project.Media, err = s.repository.GetProjectMediaRepository(project.ID)
+	if err != nil {
+		return Project{}, err
 	}
 
-	return s.repository.GetProjekRepository(id)
+	project.GitHub, err = s.repository.GetGitHubStatsRepository(project.ID)
+	if err != nil {
+		return Project{}, err
+	}
+
+	if err := s.attachRelations(&project); err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
+}
+
+// GetProjekBySlugService mengembalikan *utils.SlugMovedError jika slug adalah slug lama project
+func (s *projectService) GetProjekBySlugService(ctx *gin.Context) (Project, error) {
+	slug := ctx.Param("slug")
+
+	project, err := s.repository.GetProjekBySlugRepository(slug)
+	if err != nil {
+		if currentSlug, historyErr := s.repository.GetCurrentSlugRepository(slug); historyErr == nil {
+			return Project{}, &utils.SlugMovedError{Slug: currentSlug}
+		}
+		return Project{}, err
+	}
+
+	if project.Status != "published" && !utils.CanViewDraft(ctx, "project", project.ID) {
+		return Project{}, ErrProjectNotFound
+	}
+
+	project.Media, err = s.repository.GetProjectMediaRepository(project.ID)
+	if err != nil {
+		return Project{}, err
+	}
+
+	project.GitHub, err = s.repository.GetGitHubStatsRepository(project.ID)
+	if err != nil {
+		return Project{}, err
+	}
+
+	if err := s.attachRelations(&project); err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
 }
 
 // Service dengan struct binding
@@ -336,21 +585,45 @@ func (s *projectService) UpdateProjekService(ctx *gin.Context) (Project, error)
 		return Project{}, fmt.Errorf("gagal binding data: %v", err)
 	}
 
+	gallery, err := s.uploadGalleryFiles(ctx, form.Captions)
+	if err != nil {
+		if file != nil && imageURL != existingProject.ImageURL {
+			s.uploadService.DeleteFile(imageURL)
+		}
+		return Project{}, err
+	}
+
 	// Update fields yang ada nilainya
 	if form.Title != "" {
 		existingProject.Title = form.Title
 	}
+	// Slug lama tetap disimpan di history oleh repository agar URL lama di-redirect
+	if form.Slug != "" && utils.Slugify(form.Slug) != existingProject.Slug {
+		slug, err := utils.UniqueSlug(form.Slug, "project", func(candidate string) (bool, error) {
+			return s.repository.SlugExistsRepository(candidate, existingProject.ID)
+		})
+		if err != nil {
+			if file != nil && imageURL != existingProject.ImageURL {
+				s.uploadService.DeleteFile(imageURL)
+			}
+			return Project{}, fmt.Errorf("gagal membuat slug: %v", err)
+		}
+		existingProject.Slug = slug
+	}
 	if form.Description != "" {
 		existingProject.Description = form.Description
 	}
+	if form.CaseStudy != nil {
+		existingProject.CaseStudy = *form.CaseStudy
+	}
 	if form.DemoURL != "" {
 		existingProject.DemoURL = form.DemoURL
 	}
 	if form.CodeURL != "" {
 		existingProject.CodeURL = form.CodeURL
 	}
-	if form.DisplayOrder != 0 {
-		existingProject.DisplayOrder = form.DisplayOrder
+	if form.DisplayOrder != nil {
+		existingProject.DisplayOrder = *form.DisplayOrder
 	}
 	existingProject.IsFeatured = form.IsFeatured
 	if form.Status != "" {
@@ -367,9 +640,22 @@ func (s *projectService) UpdateProjekService(ctx *gin.Context) (Project, error)
 		if file != nil && imageURL != existingProject.ImageURL {
 			s.uploadService.DeleteFile(imageURL)
 		}
+		s.deleteMediaFiles(gallery)
 		return Project{}, fmt.Errorf("gagal mengupdate projek: %v", err)
 	}
 
+	if len(gallery) > 0 {
+		if _, err := s.repository.AddProjectMediaRepository(result.ID, gallery); err != nil {
+			s.deleteMediaFiles(gallery)
+			return Project{}, fmt.Errorf("gagal menyimpan gallery projek: %v", err)
+		}
+	}
+	result.Media, err = s.repository.GetProjectMediaRepository(result.ID)
+	if err != nil {
+		return Project{}, err
+	}
+	relatedProjectsCache.Flush()
+
 	return result, nil
 }
 
@@ -386,24 +672,243 @@ func (s *projectService) DeleteProjekService(ctx *gin.Context) error {
 		return errors.New("projek tidak ditemukan")
 	}
 
-	// Hapus file image jika ada
+	media, err := s.repository.GetProjectMediaRepository(id)
+	if err != nil {
+		return err
+	}
+
+	// Delete dari database (media ikut terhapus karena ON DELETE CASCADE)
+	if err := s.repository.DeleteProjekRepository(id); err != nil {
+		return err
+	}
+
+	// Hapus file image dan gallery jika ada
 	if existingProject.ImageURL != "" {
 		s.uploadService.DeleteFile(existingProject.ImageURL)
 	}
+	s.deleteMediaFiles(media)
+	relatedProjectsCache.Flush()
+
+	return nil
+}
+
+// uploadGalleryFiles mengupload semua file pada field "gallery". Semua file divalidasi dulu,
+// dan file yang sudah terupload dihapus lagi jika salah satu upload gagal.
+func (s *projectService) uploadGalleryFiles(ctx *gin.Context, captions []string) ([]ProjectMedia, error) {
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		// Request tanpa multipart (atau tanpa gallery) tidak menambah media
+		return nil, nil
+	}
+
+	files := form.File["gallery"]
+	for _, file := range files {
+		if err := s.validateFile(file); err != nil {
+			return nil, fmt.Errorf("%s: %v", file.Filename, err)
+		}
+	}
+
+	var media []ProjectMedia
+	for i, file := range files {
+		url, err := s.uploadService.UploadFile(file, "projects/gallery")
+		if err != nil {
+			s.deleteMediaFiles(media)
+			return nil, fmt.Errorf("gagal mengupload file gallery %s: %v", file.Filename, err)
+		}
+
+		item := ProjectMedia{URL: url}
+		if i < len(captions) {
+			item.Caption = strings.TrimSpace(captions[i])
+		}
+		media = append(media, item)
+	}
+
+	return media, nil
+}
+
+func (s *projectService) deleteMediaFiles(media []ProjectMedia) {
+	for _, item := range media {
+		s.uploadService.DeleteFile(item.URL)
+	}
+}
+
+func (s *projectService) GetProjectMediaService(ctx *gin.Context) ([]ProjectMedia, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, errors.New("ID projek tidak valid")
+	}
+
+	project, err := s.repository.GetProjekRepository(id)
+	if err != nil {
+		return nil, errors.New("projek tidak ditemukan")
+	}
+
+	// Galeri project draft mengikuti aturan preview yang sama dengan detail project
+	if project.Status != "published" && !utils.CanViewDraft(ctx, "project", project.ID) {
+		return nil, errors.New("projek tidak ditemukan")
+	}
+
+	return s.repository.GetProjectMediaRepository(id)
+}
+
+// ReorderProjectMediaService membutuhkan semua ID media project agar urutan tidak ambigu
+func (s *projectService) ReorderProjectMediaService(ctx *gin.Context) ([]ProjectMedia, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, errors.New("ID projek tidak valid")
+	}
+
+	var req ProjectMediaOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
 
-	// Delete dari database
-	return s.repository.DeleteProjekRepository(id)
+	current, err := s.repository.GetProjectMediaRepository(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.MediaIDs) != len(current) {
+		return nil, fmt.Errorf("media_ids harus berisi semua %d media projek", len(current))
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.MediaIDs))
+	for _, mediaID := range req.MediaIDs {
+		if seen[mediaID] {
+			return nil, fmt.Errorf("media %s duplikat", mediaID)
+		}
+		seen[mediaID] = true
+	}
+
+	if err := s.repository.ReorderProjectMediaRepository(id, req.MediaIDs); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetProjectMediaRepository(id)
+}
+
+func (s *projectService) DeleteProjectMediaService(ctx *gin.Context) error {
+	projectID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return errors.New("ID projek tidak valid")
+	}
+	mediaID, err := uuid.Parse(ctx.Param("mediaId"))
+	if err != nil {
+		return errors.New("ID media tidak valid")
+	}
+
+	media, err := s.repository.GetProjectMediaByIDRepository(mediaID)
+	if err != nil || media.ProjectID != projectID {
+		return errors.New("media tidak ditemukan")
+	}
+
+	if err := s.repository.DeleteProjectMediaRepository(mediaID); err != nil {
+		return err
+	}
+	s.uploadService.DeleteFile(media.URL)
+
+	return nil
+}
+
+// GetRelatedProjekService mengembalikan project published lain yang paling mirip: skor tag (bobot kelangkaan) lalu kemiripan teks
+func (s *projectService) GetRelatedProjekService(ctx *gin.Context) ([]RelatedProject, error) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, ErrInvalidProjectID
+	}
+
+	limit := utils.ParseLimit(ctx.Query("limit"), 4, 20)
+
+	if cached, ok := relatedProjectsCache.Get(id.String()); ok {
+		related := cached.([]RelatedProject)
+		if len(related) > limit {
+			related = related[:limit]
+		}
+		return related, nil
+	}
+
+	source, err := s.repository.GetProjekRepository(id)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := s.repository.GetRelatedScoresRepository(id)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghitung project terkait: %v", err)
+	}
+
+	scoreByProject := make(map[uuid.UUID]ProjectRelatedScore)
+	for _, score := range scores {
+		scoreByProject[score.ProjectID] = score
+	}
+
+	candidates, err := s.repository.GetAllProjekWithTagsRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	sourceText := source.Title + " " + source.Description
+	related := []RelatedProject{}
+	for _, project := range candidates {
+		if project.ID == source.ID || project.Status != "published" {
+			continue
+		}
+
+		score := scoreByProject[project.ID]
+		similarity := utils.TextSimilarity(sourceText, project.Title+" "+project.Description)
+		if score.Score == 0 && similarity == 0 {
+			continue
+		}
+
+		related = append(related, RelatedProject{
+			Project:    project,
+			SharedTags: score.SharedTags,
+			Score:      score.Score,
+			Similarity: similarity,
+		})
+	}
+
+	sort.SliceStable(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		return related[i].Similarity > related[j].Similarity
+	})
+
+	relatedProjectsCache.Set(id.String(), related)
+
+	if len(related) > limit {
+		related = related[:limit]
+	}
+	return related, nil
 }
 
 func (s *tagsService) CreateTags(ctx *gin.Context) (*TagResponse, error) {
-	var reqcomments TagResponse
+	var reqcomments ProjectTagRequest
 	if err := ctx.ShouldBindJSON(&reqcomments); err != nil {
 		return nil, err
 	}
 
+	name := strings.TrimSpace(reqcomments.Name)
+	if name == "" {
+		return nil, errors.New("nama tag harus diisi")
+	}
+	color, err := normalizeHexColor(reqcomments.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.tagsRepo.TagNameExists(name, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrProjectTagNameTaken
+	}
+
 	Tags := &ProjectTag{
-		Name:  reqcomments.Name,
-		Color: reqcomments.Color,
+		Name:  name,
+		Color: color,
 	}
 
 	if err := s.tagsRepo.CreateTags(Tags); err != nil {
@@ -413,6 +918,85 @@ func (s *tagsService) CreateTags(ctx *gin.Context) (*TagResponse, error) {
 	return s.convertToResponse(Tags), nil
 }
 
+func (s *tagsService) UpdateTags(ctx *gin.Context) (*TagResponse, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, errors.New("ID tag tidak valid")
+	}
+
+	var req ProjectTagUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
+
+	tag, err := s.tagsRepo.GetTagByID(id)
+	if err != nil {
+		return nil, errors.New("tag tidak ditemukan")
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("nama tag tidak boleh kosong")
+		}
+		exists, err := s.tagsRepo.TagNameExists(name, id)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrProjectTagNameTaken
+		}
+		tag.Name = name
+	}
+	if req.Color != nil {
+		color, err := normalizeHexColor(*req.Color)
+		if err != nil {
+			return nil, err
+		}
+		tag.Color = color
+	}
+
+	if err := s.tagsRepo.UpdateTags(tag); err != nil {
+		return nil, err
+	}
+	relatedProjectsCache.Flush()
+
+	return s.convertToResponse(tag), nil
+}
+
+// DeleteTags menolak menghapus tag yang masih dipakai kecuali dengan ?force=true
+func (s *tagsService) DeleteTags(ctx *gin.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return errors.New("ID tag tidak valid")
+	}
+
+	if _, err := s.tagsRepo.GetTagByID(id); err != nil {
+		return errors.New("tag tidak ditemukan")
+	}
+
+	if ctx.Query("force") != "true" {
+		usage, err := s.tagsRepo.CountTagUsage(id)
+		if err != nil {
+			return err
+		}
+		if usage > 0 {
+			return ErrProjectTagInUse
+		}
+	}
+
+	if err := s.tagsRepo.DeleteTags(id); err != nil {
+		return err
+	}
+	relatedProjectsCache.Flush()
+
+	return nil
+}
+
+func (s *tagsService) GetTagUsage(ctx *gin.Context) ([]ProjectTagUsage, error) {
+	return s.tagsRepo.GetTagsWithUsage()
+}
+
 func (s *tagsService) convertToResponse(Tags *ProjectTag) *TagResponse {
 	return &TagResponse{
 		ID:        Tags.ID,
@@ -421,3 +1005,61 @@ func (s *tagsService) convertToResponse(Tags *ProjectTag) *TagResponse {
 		CreatedAt: Tags.CreatedAt,
 	}
 }
+
+// attachRelations mengisi experience dan skill untuk satu project
+func (s *projectService) attachRelations(project *Project) error {
+	projects := []Project{*project}
+	if err := s.repository.AttachRelationsRepository(projects); err != nil {
+		return err
+	}
+	*project = projects[0]
+	return nil
+}
+
+func (s *projectService) SetProjectExperienceService(ctx *gin.Context) (Project, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return Project{}, errors.New("ID projek tidak valid")
+	}
+
+	var req ProjectExperienceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return Project{}, err
+	}
+
+	if err := s.repository.SetProjectExperienceRepository(id, req.ExperienceID); err != nil {
+		return Project{}, err
+	}
+
+	return s.getProjectWithRelations(id)
+}
+
+func (s *projectService) SetProjectSkillsService(ctx *gin.Context) (Project, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return Project{}, errors.New("ID projek tidak valid")
+	}
+
+	var req ProjectSkillsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return Project{}, err
+	}
+
+	if err := s.repository.SetProjectSkillsRepository(id, req.SkillIDs); err != nil {
+		return Project{}, err
+	}
+
+	return s.getProjectWithRelations(id)
+}
+
+func (s *projectService) getProjectWithRelations(id uuid.UUID) (Project, error) {
+	project, err := s.repository.GetProjekRepository(id)
+	if err != nil {
+		return Project{}, err
+	}
+
+	if err := s.attachRelations(&project); err != nil {
+		return Project{}, err
+	}
+	return project, nil
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,72 +12,221 @@ import (
 // ============================
 
 type Skill struct {
-	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Name         string    `json:"name" gorm:"type:varchar(100);unique;not null"`
-	Value        int       `json:"value" gorm:"type:integer;check:value >= 0 AND value <= 100"`
-	IconURL      string    `json:"icon_url" gorm:"type:varchar(500)"`
-	Category     string    `json:"category" gorm:"type:varchar(50)"` // programming, framework, tool
-	DisplayOrder int       `json:"display_order" gorm:"type:integer;default:0"`
-	IsFeatured   bool      `json:"is_featured" gorm:"type:boolean;default:false"`
-	CreatedAt    time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
-	UpdatedAt    time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name         string         `json:"name" gorm:"type:varchar(100);unique;not null"`
+	Value        int            `json:"value" gorm:"type:integer;check:value >= 0 AND value <= 100"`
+	IconURL      string         `json:"icon_url" gorm:"type:varchar(500)"`
+	CategoryID   *uuid.UUID     `json:"category_id" gorm:"type:uuid"`
+	Category     *SkillCategory `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	DisplayOrder int            `json:"display_order" gorm:"type:integer;default:0"`
+	IsFeatured   bool           `json:"is_featured" gorm:"type:boolean;default:false"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
 
 func (Skill) TableName() string {
 	return "portfolio_skills"
 }
 
+// Kategori bisa dikirim lewat category_id atau category (slug/label kategori yang sudah ada)
 type SkillForm struct {
 	Name         string `form:"name" binding:"required"`
 	Value        int    `form:"value" binding:"required,min=0,max=100"`
+	CategoryID   string `form:"category_id"`
 	Category     string `form:"category"`
 	DisplayOrder int    `form:"display_order"`
 	IsFeatured   bool   `form:"is_featured"`
 }
 
 type SkillRequest struct {
-	Name         string `json:"name" binding:"required"`
-	Value        int    `json:"value" binding:"required,min=0,max=100"`
-	IconURL      string `json:"icon_url"`
-	Category     string `json:"category"`
-	DisplayOrder int    `json:"display_order"`
-	IsFeatured   bool   `json:"is_featured"`
+	Name         string     `json:"name" binding:"required"`
+	Value        int        `json:"value" binding:"required,min=0,max=100"`
+	IconURL      string     `json:"icon_url"`
+	CategoryID   *uuid.UUID `json:"category_id"`
+	Category     string     `json:"category"` // slug atau label, dipakai jika category_id kosong
+	DisplayOrder int        `json:"display_order"`
+	IsFeatured   bool       `json:"is_featured"`
 }
 
 type SkillUpdateRequest struct {
-	Name         string `json:"name"`
-	Value        int    `json:"value" binding:"omitempty,min=0,max=100"`
-	IconURL      string `json:"icon_url"`
-	Category     string `json:"category"`
-	DisplayOrder int    `json:"display_order"`
-	IsFeatured   bool   `json:"is_featured"`
+	Name         string     `json:"name"`
+	Value        int        `json:"value" binding:"omitempty,min=0,max=100"`
+	IconURL      string     `json:"icon_url"`
+	CategoryID   *uuid.UUID `json:"category_id"`
+	Category     string     `json:"category"`
+	DisplayOrder int        `json:"display_order"`
+	IsFeatured   bool       `json:"is_featured"`
 }
 
 type SkillResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Value        int        `json:"value"`
+	IconURL      string     `json:"icon_url"`
+	CategoryID   *uuid.UUID `json:"category_id"`
+	Category     string     `json:"category"` // label kategori, sama dengan teks category lama; kosong jika belum dikategorikan
+	CategorySlug string     `json:"category_slug"`
+	DisplayOrder int        `json:"display_order"`
+	IsFeatured   bool       `json:"is_featured"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// SkillUsageExperience experience yang memakai skill (lewat experience_skills)
+type SkillUsageExperience struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	Company    string    `json:"company"`
+	StartYear  string    `json:"start_year"`
+	EndYear    string    `json:"end_year"`
+	CurrentJob bool      `json:"current_job"`
+}
+
+// SkillUsageProject projek yang memakai skill (lewat project_skills)
+type SkillUsageProject struct {
+	ID     uuid.UUID `json:"id"`
+	Title  string    `json:"title"`
+	Slug   string    `json:"slug"`
+	Status string    `json:"status"`
+}
+
+type SkillUsageResponse struct {
+	Skill       SkillResponse          `json:"skill"`
+	Experiences []SkillUsageExperience `json:"experiences"`
+	Projects    []SkillUsageProject    `json:"projects"`
+}
+
+// SkillUsagePeriod periode experience yang memakai skill, untuk menghitung lama pemakaian
+type SkillUsagePeriod struct {
+	SkillID    uuid.UUID
+	StartDate  *time.Time
+	EndDate    *time.Time
+	CurrentJob bool
+}
+
+// SkillUsageCount jumlah experience dan projek published yang memakai skill
+type SkillUsageCount struct {
+	SkillID         uuid.UUID
+	ExperienceCount int
+	ProjectCount    int
+}
+
+// Level proficiency yang diturunkan dari jumlah pemakaian dan lama pemakaian skill
+const (
+	SkillLevelNone         = "none"
+	SkillLevelBeginner     = "beginner"
+	SkillLevelIntermediate = "intermediate"
+	SkillLevelAdvanced     = "advanced"
+	SkillLevelExpert       = "expert"
+)
+
+type SkillProficiencyResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	Category        string    `json:"category"`
+	IconURL         string    `json:"icon_url"`
+	Value           int       `json:"value"` // nilai manual dari admin
+	ExperienceCount int       `json:"experience_count"`
+	ProjectCount    int       `json:"project_count"`
+	UsageCount      int       `json:"usage_count"`
+	MonthsUsed      int       `json:"months_used"`
+	Duration        string    `json:"duration"`
+	LastUsed        *string   `json:"last_used"` // YYYY-MM, null jika masih dipakai atau belum ada data
+	CurrentlyUsed   bool      `json:"currently_used"`
+	Level           string    `json:"level"`
+}
+
+// ============================
+// SKILL CATEGORIES MODEL
+// ============================
+
+type SkillCategory struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Label        string    `json:"label" gorm:"type:varchar(100);not null"`
+	Slug         string    `json:"slug" gorm:"type:varchar(120);unique;not null"`
+	Icon         string    `json:"icon" gorm:"type:varchar(500);not null;default:''"`
+	Description  string    `json:"description" gorm:"type:text;not null;default:''"`
+	DisplayOrder int       `json:"display_order" gorm:"type:integer;default:0"`
+	CreatedAt    time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (SkillCategory) TableName() string {
+	return "skill_categories"
+}
+
+type SkillCategoryRequest struct {
+	Label        string `json:"label" binding:"required,max=100"`
+	Slug         string `json:"slug"` // dibuat dari label jika kosong
+	Icon         string `json:"icon"`
+	Description  string `json:"description"`
+	DisplayOrder int    `json:"display_order"`
+}
+
+type SkillCategoryUpdateRequest struct {
+	Label        string  `json:"label" binding:"omitempty,max=100"`
+	Slug         string  `json:"slug"`
+	Icon         *string `json:"icon"`
+	Description  *string `json:"description"`
+	DisplayOrder *int    `json:"display_order"`
+}
+
+type SkillCategoryResponse struct {
 	ID           uuid.UUID `json:"id"`
-	Name         string    `json:"name"`
-	Value        int       `json:"value"`
-	IconURL      string    `json:"icon_url"`
-	Category     string    `json:"category"`
+	Label        string    `json:"label"`
+	Slug         string    `json:"slug"`
+	Icon         string    `json:"icon"`
+	Description  string    `json:"description"`
 	DisplayOrder int       `json:"display_order"`
-	IsFeatured   bool      `json:"is_featured"`
+	SkillCount   int       `json:"skill_count"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// SkillCategoryCount jumlah skill per kategori
+type SkillCategoryCount struct {
+	CategoryID uuid.UUID
+	Total      int
+}
+
+// SkillCategoryGroup kategori beserta skill-nya untuk section skills, Category null untuk skill tanpa kategori
+type SkillCategoryGroup struct {
+	Category *SkillCategoryResponse `json:"category"`
+	Skills   []SkillResponse        `json:"skills"`
+}
+
 // ============================
 // CERTIFICATES MODEL
 // ============================
 
+// Status masa berlaku sertifikat, dihitung dari expires_at
+const (
+	CertificateStatusValid    = "valid"
+	CertificateStatusExpiring = "expiring"
+	CertificateStatusExpired  = "expired"
+)
+
+// Status verifikasi credential_url berdasarkan HTTP status terakhir
+const (
+	CredentialUnchecked   = "unchecked"
+	CredentialReachable   = "reachable"
+	CredentialUnreachable = "unreachable"
+)
+
 type Certificate struct {
-	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Name          string    `json:"name" gorm:"type:varchar(200);not null"`
-	ImageURL      string    `json:"image_url" gorm:"type:varchar(500);not null"`
-	IssueDate     time.Time `json:"issue_date" gorm:"type:date"`
-	Issuer        string    `json:"issuer" gorm:"type:varchar(150)"`
-	CredentialURL string    `json:"credential_url" gorm:"type:varchar(500)"`
-	DisplayOrder  int       `json:"display_order" gorm:"type:integer;default:0"`
-	CreatedAt     time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	ID                uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name              string     `json:"name" gorm:"type:varchar(200);not null"`
+	ImageURL          string     `json:"image_url" gorm:"type:varchar(500);not null"`               // gambar atau thumbnail halaman pertama PDF
+	DocumentURL       string     `json:"document_url" gorm:"type:varchar(500);not null;default:''"` // file PDF asli, kosong jika sertifikat berupa gambar
+	IssueDate         time.Time  `json:"issue_date" gorm:"type:date"`
+	ExpiresAt         *time.Time `json:"expires_at" gorm:"type:date"`
+	Issuer            string     `json:"issuer" gorm:"type:varchar(150)"`
+	CredentialURL     string     `json:"credential_url" gorm:"type:varchar(500)"`
+	DisplayOrder      int        `json:"display_order" gorm:"type:integer;default:0"`
+	LastHTTPStatus    *int       `json:"last_http_status" gorm:"column:last_http_status"`
+	LastCheckedAt     *time.Time `json:"last_checked_at"`
+	VerificationError string     `json:"verification_error" gorm:"type:text;not null;default:''"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
 
 func (Certificate) TableName() string {
@@ -86,38 +236,72 @@ func (Certificate) TableName() string {
 type CertificateForm struct {
 	Name          string `form:"name" binding:"required"`
 	IssueDate     string `form:"issue_date"` // Pakai string untuk form-data
+	ExpiresAt     string `form:"expires_at"` // YYYY-MM-DD, kosong jika tidak kedaluwarsa
 	Issuer        string `form:"issuer"`
 	CredentialURL string `form:"credential_url"`
 	DisplayOrder  int    `form:"display_order"`
 }
 
+// CertificateUpdateForm untuk update multipart, field kosong tidak mengubah data lama
+type CertificateUpdateForm struct {
+	Name          string `form:"name"`
+	IssueDate     string `form:"issue_date"`
+	ExpiresAt     string `form:"expires_at"`
+	NoExpiry      bool   `form:"no_expiry"` // true untuk menghapus expires_at
+	Issuer        string `form:"issuer"`
+	CredentialURL string `form:"credential_url"`
+	DisplayOrder  *int   `form:"display_order"`
+}
+
 type CertificateRequest struct {
-	Name          string    `json:"name" binding:"required"`
-	ImageURL      string    `json:"image_url" binding:"required"`
-	IssueDate     time.Time `json:"issue_date"`
-	Issuer        string    `json:"issuer"`
-	CredentialURL string    `json:"credential_url"`
-	DisplayOrder  int       `json:"display_order"`
+	Name          string     `json:"name" binding:"required"`
+	ImageURL      string     `json:"image_url" binding:"required"`
+	DocumentURL   string     `json:"document_url"`
+	IssueDate     time.Time  `json:"issue_date"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	Issuer        string     `json:"issuer"`
+	CredentialURL string     `json:"credential_url"`
+	DisplayOrder  int        `json:"display_order"`
 }
 
 type CertificateUpdateRequest struct {
-	Name          string    `json:"name"`
-	ImageURL      string    `json:"image_url"`
-	IssueDate     time.Time `json:"issue_date"`
-	Issuer        string    `json:"issuer"`
-	CredentialURL string    `json:"credential_url"`
-	DisplayOrder  int       `json:"display_order"`
+	Name          string     `json:"name"`
+	ImageURL      string     `json:"image_url"`
+	DocumentURL   string     `json:"document_url"`
+	IssueDate     time.Time  `json:"issue_date"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	NoExpiry      bool       `json:"no_expiry"` // true untuk menghapus expires_at
+	Issuer        string     `json:"issuer"`
+	CredentialURL string     `json:"credential_url"`
+	DisplayOrder  int        `json:"display_order"`
 }
 
 type CertificateResponse struct {
-	ID            uuid.UUID `json:"id"`
-	Name          string    `json:"name"`
-	ImageURL      string    `json:"image_url"`
-	IssueDate     time.Time `json:"issue_date"`
-	Issuer        string    `json:"issuer"`
-	CredentialURL string    `json:"credential_url"`
-	DisplayOrder  int       `json:"display_order"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID                 uuid.UUID  `json:"id"`
+	Name               string     `json:"name"`
+	ImageURL           string     `json:"image_url"`
+	DocumentURL        string     `json:"document_url"`
+	IsPDF              bool       `json:"is_pdf"`
+	IssueDate          time.Time  `json:"issue_date"`
+	ExpiresAt          *time.Time `json:"expires_at"`
+	Status             string     `json:"status"`
+	DaysUntilExpiry    *int       `json:"days_until_expiry"` // null jika tidak kedaluwarsa
+	Issuer             string     `json:"issuer"`
+	CredentialURL      string     `json:"credential_url"`
+	VerificationStatus string     `json:"verification_status,omitempty"` // field verifikasi hanya dikirim ke admin
+	LastHTTPStatus     *int       `json:"last_http_status,omitempty"`
+	LastCheckedAt      *time.Time `json:"last_checked_at,omitempty"`
+	VerificationError  string     `json:"verification_error,omitempty"`
+	DisplayOrder       int        `json:"display_order"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// CertificateVerificationSummary hasil satu kali pengecekan credential_url
+type CertificateVerificationSummary struct {
+	Checked     int `json:"checked"`
+	Reachable   int `json:"reachable"`
+	Unreachable int `json:"unreachable"`
+	Skipped     int `json:"skipped"` // tanpa credential_url
 }
 
 // ============================
@@ -136,6 +320,12 @@ type Education struct {
 	Achievements []EducationAchievement `json:"achievements" gorm:"foreignKey:EducationID;references:ID"`
 	CreatedAt    time.Time              `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt    time.Time              `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Tanggal presisi bulan, EndDate nil berarti masih berjalan hanya jika IsCurrent.
+	// StartYear/EndYear jadi teks tampilan.
+	StartDate *time.Time `json:"start_date" gorm:"type:date"`
+	EndDate   *time.Time `json:"end_date" gorm:"type:date"`
+	IsCurrent bool       `json:"is_current" gorm:"column:is_current;type:boolean;default:false"`
 }
 
 func (Education) TableName() string {
@@ -162,7 +352,9 @@ type AchievementRequest struct {
 type EducationRequest struct {
 	School       string               `json:"school" binding:"required"`
 	Major        string               `json:"major" binding:"required"`
-	StartYear    string               `json:"start_year"`
+	StartDate    string               `json:"start_date"` // YYYY-MM
+	EndDate      string               `json:"end_date"`   // YYYY-MM, kosong jika masih berjalan
+	StartYear    string               `json:"start_year"` // Format lama, dipakai jika start_date kosong
 	EndYear      string               `json:"end_year"`
 	Description  string               `json:"description"`
 	Degree       string               `json:"degree"`
@@ -173,6 +365,8 @@ type EducationRequest struct {
 type EducationUpdateRequest struct {
 	School       string               `json:"school"`
 	Major        string               `json:"major"`
+	StartDate    string               `json:"start_date"`
+	EndDate      string               `json:"end_date"`
 	StartYear    string               `json:"start_year"`
 	EndYear      string               `json:"end_year"`
 	Description  string               `json:"description"`
@@ -195,6 +389,10 @@ type EducationResponse struct {
 	Major        string                `json:"major"`
 	StartYear    string                `json:"start_year"`
 	EndYear      string                `json:"end_year"`
+	StartDate    *string               `json:"start_date"`
+	EndDate      *string               `json:"end_date"`
+	Ongoing      bool                  `json:"ongoing"`
+	Duration     string                `json:"duration"`
 	Description  string                `json:"description"`
 	Degree       string                `json:"degree"`
 	DisplayOrder int                   `json:"display_order"`
@@ -276,6 +474,27 @@ type BlogPost struct {
 	Tags          []BlogTag `json:"tags" gorm:"many2many:blog_post_tags;joinForeignKey:PostID;joinReferences:TagID"`
 	CreatedAt     time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt     time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Pengaturan komentar; 0 pada CommentsCloseAfterDays berarti tidak pernah ditutup otomatis
+	CommentsEnabled        bool `json:"comments_enabled" gorm:"type:boolean"`
+	CommentsCloseAfterDays int  `json:"comments_close_after_days" gorm:"type:integer;default:0"`
+	CommentCount           int  `json:"comment_count" gorm:"-"`
+}
+
+// CommentsOpen menentukan apakah post masih menerima komentar baru
+func (p *BlogPost) CommentsOpen(now time.Time) bool {
+	if !p.CommentsEnabled || p.Status != "published" {
+		return false
+	}
+	if p.CommentsCloseAfterDays <= 0 {
+		return true
+	}
+
+	openedAt := p.PublishDate
+	if openedAt.IsZero() {
+		openedAt = p.CreatedAt
+	}
+	return now.Before(openedAt.AddDate(0, 0, p.CommentsCloseAfterDays))
 }
 
 func (BlogPost) TableName() string {
@@ -284,7 +503,7 @@ func (BlogPost) TableName() string {
 
 type BlogTag struct {
 	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Name      string     `json:"name" gorm:"type:varchar(50);unique;not null"`
+	Name      string     `json:"name" gorm:"type:varchar(50);not null"` // unik tanpa membedakan huruf besar/kecil (index LOWER(name))
 	Posts     []BlogPost `json:"-" gorm:"many2many:blog_post_tags;joinForeignKey:TagID;joinReferences:PostID"`
 	CreatedAt time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
@@ -293,6 +512,17 @@ func (BlogTag) TableName() string {
 	return "blog_tags"
 }
 
+// BlogPostSlugHistory menyimpan slug lama agar link lama bisa di-redirect ke slug terbaru
+type BlogPostSlugHistory struct {
+	Slug      string    `json:"slug" gorm:"type:varchar(200);primaryKey"`
+	PostID    uuid.UUID `json:"post_id" gorm:"type:uuid;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (BlogPostSlugHistory) TableName() string {
+	return "blog_post_slug_history"
+}
+
 type BlogPostTag struct {
 	PostID uuid.UUID `json:"post_id" gorm:"type:uuid;primaryKey;column:post_id"`
 	TagID  uuid.UUID `json:"tag_id" gorm:"type:uuid;primaryKey;column:tag_id"`
@@ -310,11 +540,14 @@ type BlogPostRequest struct {
 	Title         string       `json:"title" binding:"required"`
 	Content       string       `json:"content"`
 	Excerpt       string       `json:"excerpt"`
-	Slug          string       `json:"slug" binding:"required"`
+	Slug          string       `json:"slug"` // Opsional, dibuat otomatis dari title jika kosong
 	FeaturedImage string       `json:"featured_image"`
 	PublishDate   time.Time    `json:"publish_date"`
 	Status        string       `json:"status"`
 	Tags          []TagRequest `json:"tags"`
+
+	CommentsEnabled        *bool `json:"comments_enabled"`
+	CommentsCloseAfterDays *int  `json:"comments_close_after_days" binding:"omitempty,min=0"`
 }
 
 type BlogPostUpdateRequest struct {
@@ -326,6 +559,9 @@ type BlogPostUpdateRequest struct {
 	PublishDate   time.Time    `json:"publish_date"`
 	Status        string       `json:"status"`
 	Tags          []TagRequest `json:"tags"`
+
+	CommentsEnabled        *bool `json:"comments_enabled"`
+	CommentsCloseAfterDays *int  `json:"comments_close_after_days" binding:"omitempty,min=0"`
 }
 
 type TagResponse struct {
@@ -334,6 +570,22 @@ type TagResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// BlogTagUsage tag beserta jumlah post yang memakainya
+type BlogTagUsage struct {
+	ID        uuid.UUID `json:"id" gorm:"column:id"`
+	Name      string    `json:"name" gorm:"column:name"`
+	PostCount int       `json:"post_count" gorm:"column:post_count"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+type TagRenameRequest struct {
+	Name string `json:"name" binding:"required,max=50"`
+}
+
+type TagMergeRequest struct {
+	SourceIDs []uuid.UUID `json:"source_ids" binding:"required,min=1"`
+}
+
 type BlogPostResponse struct {
 	ID            uuid.UUID     `json:"id"`
 	Title         string        `json:"title"`
@@ -347,6 +599,266 @@ type BlogPostResponse struct {
 	Tags          []TagResponse `json:"tags"`
 	CreatedAt     time.Time     `json:"created_at"`
 	UpdatedAt     time.Time     `json:"updated_at"`
+
+	CommentCount           int  `json:"comment_count"`
+	CommentsEnabled        bool `json:"comments_enabled"`
+	CommentsCloseAfterDays int  `json:"comments_close_after_days"`
+	CommentsOpen           bool `json:"comments_open"`
+}
+
+// BlogRelatedScore hasil perhitungan kedekatan tag antar post (bobot berdasarkan kelangkaan tag)
+type BlogRelatedScore struct {
+	PostID     uuid.UUID `gorm:"column:post_id"`
+	SharedTags int       `gorm:"column:shared_tags"`
+	Score      float64   `gorm:"column:score"`
+}
+
+type RelatedPostResponse struct {
+	ID            uuid.UUID     `json:"id"`
+	Title         string        `json:"title"`
+	Excerpt       string        `json:"excerpt"`
+	Slug          string        `json:"slug"`
+	FeaturedImage string        `json:"featured_image"`
+	PublishDate   time.Time     `json:"publish_date"`
+	Tags          []TagResponse `json:"tags"`
+	SharedTags    int           `json:"shared_tags"`
+	Score         float64       `json:"score"`
+	Similarity    float64       `json:"similarity"`
+}
+
+// ============================
+// BLOG COMMENTS MODEL
+// ============================
+
+type BlogComment struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PostID      uuid.UUID  `json:"post_id" gorm:"type:uuid;not null"`
+	ParentID    *uuid.UUID `json:"parent_id" gorm:"type:uuid"`
+	AuthorName  string     `json:"author_name" gorm:"type:varchar(100);not null"`
+	AuthorEmail string     `json:"author_email" gorm:"type:varchar(150);not null"`
+	Content     string     `json:"content" gorm:"type:text;not null"`
+	Status      string     `json:"status" gorm:"type:varchar(20);default:'pending'"` // pending, approved, spam
+	IPAddress   string     `json:"ip_address" gorm:"type:varchar(45)"`
+	UserAgent   string     `json:"user_agent" gorm:"type:text"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (BlogComment) TableName() string {
+	return "blog_comments"
+}
+
+type CommentRequest struct {
+	ParentID    *uuid.UUID `json:"parent_id"`
+	AuthorName  string     `json:"author_name" binding:"required,max=100"`
+	AuthorEmail string     `json:"author_email" binding:"required,email,max=150"`
+	Content     string     `json:"content" binding:"required,max=5000"`
+}
+
+type CommentStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending approved spam"`
+}
+
+type CommentFilter struct {
+	PostID uuid.UUID
+	Status string
+}
+
+type CommentResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	PostID      uuid.UUID         `json:"post_id"`
+	ParentID    *uuid.UUID        `json:"parent_id"`
+	AuthorName  string            `json:"author_name"`
+	AuthorEmail string            `json:"author_email,omitempty"`
+	Content     string            `json:"content"`
+	Status      string            `json:"status,omitempty"`
+	IPAddress   string            `json:"ip_address,omitempty"`
+	Replies     []CommentResponse `json:"replies,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// ============================
+// BLOG ANALYTICS MODEL
+// ============================
+
+// BlogView satu kunjungan yang sudah lolos filter bot, dicatat secara async
+type BlogView struct {
+	PostID      uuid.UUID
+	VisitorHash string
+	Referrer    string
+	ViewedAt    time.Time
+}
+
+type BlogPostViewVisitor struct {
+	PostID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ViewDate    time.Time `gorm:"type:date;primaryKey"`
+	VisitorHash string    `gorm:"type:char(64);primaryKey"`
+}
+
+func (BlogPostViewVisitor) TableName() string {
+	return "blog_post_view_visitors"
+}
+
+type BlogPostViewDaily struct {
+	PostID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ViewDate time.Time `gorm:"type:date;primaryKey"`
+	Views    int       `gorm:"type:integer;default:0"`
+}
+
+func (BlogPostViewDaily) TableName() string {
+	return "blog_post_views_daily"
+}
+
+type BlogPostReferrerDaily struct {
+	PostID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ViewDate time.Time `gorm:"type:date;primaryKey"`
+	Referrer string    `gorm:"type:varchar(255);primaryKey"`
+	Views    int       `gorm:"type:integer;default:0"`
+}
+
+func (BlogPostReferrerDaily) TableName() string {
+	return "blog_post_referrers_daily"
+}
+
+type AnalyticsFilter struct {
+	PostID *uuid.UUID
+	From   time.Time
+	To     time.Time
+	Limit  int
+}
+
+type ViewsPointResponse struct {
+	Date  string `json:"date" gorm:"column:date"`
+	Views int    `json:"views" gorm:"column:views"`
+}
+
+type TopPostResponse struct {
+	PostID uuid.UUID `json:"post_id" gorm:"column:post_id"`
+	Title  string    `json:"title" gorm:"column:title"`
+	Slug   string    `json:"slug" gorm:"column:slug"`
+	Views  int       `json:"views" gorm:"column:views"`
+}
+
+type ReferrerResponse struct {
+	Referrer string `json:"referrer" gorm:"column:referrer"`
+	Views    int    `json:"views" gorm:"column:views"`
+}
+
+// ============================
+// PREVIEW LINKS MODEL
+// ============================
+
+const (
+	PreviewEntityBlogPost = "blog_post"
+	PreviewEntityProject  = "project"
+)
+
+type PreviewLink struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	EntityType string     `json:"entity_type" gorm:"type:varchar(20);not null"` // blog_post, project
+	EntityID   uuid.UUID  `json:"entity_id" gorm:"type:uuid;not null"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedBy  *uuid.UUID `json:"created_by" gorm:"type:uuid"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (PreviewLink) TableName() string {
+	return "preview_links"
+}
+
+type PreviewLinkRequest struct {
+	EntityType     string    `json:"entity_type" binding:"required,oneof=blog_post project"`
+	EntityID       uuid.UUID `json:"entity_id" binding:"required"`
+	ExpiresInHours int       `json:"expires_in_hours" binding:"omitempty,min=1,max=720"` // default 72 jam
+}
+
+type PreviewLinkFilter struct {
+	EntityType string
+	EntityID   uuid.UUID
+}
+
+type PreviewLinkResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	EntityType string     `json:"entity_type"`
+	EntityID   uuid.UUID  `json:"entity_id"`
+	Token      string     `json:"token"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedBy  *uuid.UUID `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ============================
+// DISPLAY ORDER MODEL
+// ============================
+
+// Entity yang urutannya bisa diatur lewat PUT /api/v1/{entity}/order
+const (
+	OrderEntityProjects        = "projects"
+	OrderEntityExperiences     = "experiences"
+	OrderEntitySkills          = "skills"
+	OrderEntitySkillCategories = "skill-categories"
+	OrderEntityCertificates    = "certificates"
+	OrderEntityEducation       = "education"
+	OrderEntityTestimonials    = "testimonials"
+	OrderEntitySections        = "sections"
+	OrderEntitySocialLinks     = "social-links"
+)
+
+// ReorderRequest daftar ID sesuai urutan tampil. ID yang tidak disebut ditaruh di belakang
+// dengan urutan relatif yang sama seperti sebelumnya.
+type ReorderRequest struct {
+	IDs []uuid.UUID `json:"ids" binding:"required,min=1"`
+}
+
+type DisplayOrderItem struct {
+	ID           uuid.UUID `json:"id"`
+	DisplayOrder int       `json:"display_order"`
+}
+
+// ============================
+// TIMELINE MODEL
+// ============================
+
+// Jenis entry pada GET /api/v1/timeline
+const (
+	TimelineExperience  = "experience"
+	TimelineEducation   = "education"
+	TimelineCertificate = "certificate"
+	TimelineProject     = "project"
+)
+
+// TimelineRow satu baris mentah dari tabel sumber, tanggal sudah dalam bentuk DATE
+type TimelineRow struct {
+	Type      string
+	ID        uuid.UUID
+	Title     string
+	Subtitle  string
+	Slug      string
+	StartDate time.Time
+	EndDate   *time.Time
+	Current   bool // current_job / is_current, EndDate nil tanpa Current berarti tidak diketahui
+}
+
+// TimelineFilter: Types kosong = experience, education, certificate. Tahun 0 = tanpa batas.
+type TimelineFilter struct {
+	Types    []string
+	FromYear int
+	ToYear   int
+	Asc      bool
+}
+
+type TimelineEntry struct {
+	Type      string    `json:"type"`
+	ID        uuid.UUID `json:"id"`
+	Title     string    `json:"title"`
+	Subtitle  string    `json:"subtitle"`
+	Slug      string    `json:"slug,omitempty"`
+	StartDate string    `json:"start_date"` // YYYY-MM
+	EndDate   *string   `json:"end_date"`   // null jika masih berjalan atau tidak diketahui
+	Ongoing   bool      `json:"ongoing"`
+	DateLabel string    `json:"date_label"` // contoh: "Mar 2021 – Present"
+	Duration  string    `json:"duration,omitempty"`
 }
 
 // ============================
@@ -374,6 +886,22 @@ type SectionRequest struct {
 	IsActive     bool   `json:"is_active"`
 }
 
+// SectionUpdateRequest untuk PUT, section_id kosong berarti memakai key dari URL / data lama
+type SectionUpdateRequest struct {
+	SectionID    string `json:"section_id"`
+	Label        string `json:"label" binding:"required"`
+	DisplayOrder int    `json:"display_order"`
+	IsActive     bool   `json:"is_active"`
+}
+
+// SectionPatchRequest untuk PATCH, hanya field yang dikirim yang diubah
+type SectionPatchRequest struct {
+	SectionID    *string `json:"section_id"`
+	Label        *string `json:"label"`
+	DisplayOrder *int    `json:"display_order"`
+	IsActive     *bool   `json:"is_active"`
+}
+
 type SectionResponse struct {
 	ID           uuid.UUID `json:"id"`
 	SectionID    string    `json:"section_id"`
@@ -384,6 +912,94 @@ type SectionResponse struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// ============================
+// SECTION BLOCKS MODEL
+// ============================
+
+// Jenis content block, payload masing-masing divalidasi dengan JSON Schema di service
+const (
+	BlockTypeRichText    = "rich_text"
+	BlockTypeImage       = "image"
+	BlockTypeCTA         = "cta"
+	BlockTypeStats       = "stats"
+	BlockTypeProjectList = "project_list"
+	BlockTypeSkillList   = "skill_list"
+)
+
+// SectionBlock content block milik section, Payload berisi JSON ringkas sesuai Type
+type SectionBlock struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SectionID    uuid.UUID `json:"section_id" gorm:"type:uuid;not null"`
+	Type         string    `json:"type" gorm:"type:varchar(30);not null"`
+	Payload      string    `json:"payload" gorm:"type:text;not null;default:'{}'"`
+	DisplayOrder int       `json:"display_order" gorm:"type:integer;default:0"`
+	IsActive     bool      `json:"is_active" gorm:"type:boolean;default:true"`
+	CreatedAt    time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (SectionBlock) TableName() string {
+	return "section_blocks"
+}
+
+// SectionBlockRequest untuk membuat block, display_order kosong berarti ditaruh paling akhir
+type SectionBlockRequest struct {
+	Type         string          `json:"type" binding:"required"`
+	Payload      json.RawMessage `json:"payload" binding:"required"`
+	DisplayOrder *int            `json:"display_order"`
+	IsActive     *bool           `json:"is_active"`
+}
+
+// SectionBlockUpdateRequest untuk PUT, mengganti seluruh field
+type SectionBlockUpdateRequest struct {
+	Type         string          `json:"type" binding:"required"`
+	Payload      json.RawMessage `json:"payload" binding:"required"`
+	DisplayOrder int             `json:"display_order"`
+	IsActive     bool            `json:"is_active"`
+}
+
+// SectionBlockPatchRequest untuk PATCH, hanya field yang dikirim yang diubah
+type SectionBlockPatchRequest struct {
+	Type         *string         `json:"type"`
+	Payload      json.RawMessage `json:"payload"`
+	DisplayOrder *int            `json:"display_order"`
+	IsActive     *bool           `json:"is_active"`
+}
+
+type SectionBlockResponse struct {
+	ID           uuid.UUID       `json:"id"`
+	SectionID    uuid.UUID       `json:"section_id"`
+	Type         string          `json:"type"`
+	Payload      json.RawMessage `json:"payload"`
+	DisplayOrder int             `json:"display_order"`
+	IsActive     bool            `json:"is_active"`
+	// Items berisi project/skill hasil resolve untuk block project_list dan skill_list (hanya di endpoint page)
+	Items     interface{} `json:"items,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// PageProjectCard ringkasan project untuk block project_list
+type PageProjectCard struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description"`
+	ImageURL    string    `json:"image_url"`
+	DemoURL     string    `json:"demo_url"`
+	CodeURL     string    `json:"code_url"`
+	IsFeatured  bool      `json:"is_featured"`
+}
+
+// PageSection section aktif beserta block aktifnya yang sudah di-resolve
+type PageSection struct {
+	ID           uuid.UUID              `json:"id"`
+	SectionID    string                 `json:"section_id"`
+	Label        string                 `json:"label"`
+	DisplayOrder int                    `json:"display_order"`
+	Blocks       []SectionBlockResponse `json:"blocks"`
+}
+
 // ============================
 // SOCIAL LINKS MODEL
 // ============================
@@ -411,6 +1027,24 @@ type SocialLinkRequest struct {
 	IsActive     bool   `json:"is_active"`
 }
 
+// SocialLinkUpdateRequest untuk PUT, platform kosong berarti memakai key dari URL / data lama
+type SocialLinkUpdateRequest struct {
+	Platform     string `json:"platform"`
+	URL          string `json:"url" binding:"required"`
+	IconName     string `json:"icon_name"`
+	DisplayOrder int    `json:"display_order"`
+	IsActive     bool   `json:"is_active"`
+}
+
+// SocialLinkPatchRequest untuk PATCH, hanya field yang dikirim yang diubah
+type SocialLinkPatchRequest struct {
+	Platform     *string `json:"platform"`
+	URL          *string `json:"url"`
+	IconName     *string `json:"icon_name"`
+	DisplayOrder *int    `json:"display_order"`
+	IsActive     *bool   `json:"is_active"`
+}
+
 type SocialLinkResponse struct {
 	ID           uuid.UUID `json:"id"`
 	Platform     string    `json:"platform"`
@@ -422,16 +1056,109 @@ type SocialLinkResponse struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// ============================
+// PROFILE MODEL
+// ============================
+
+// Status ketersediaan pemilik portfolio
+const (
+	AvailabilityOpen        = "open"
+	AvailabilityFreelance   = "freelance"
+	AvailabilityBusy        = "busy"
+	AvailabilityUnavailable = "unavailable"
+)
+
+// Profile hanya satu baris. Roles disimpan sebagai array JSON teks,
+// YearsOfExperience nil berarti dihitung dari experience.
+type Profile struct {
+	ID                uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name              string    `json:"name" gorm:"type:varchar(150);not null"`
+	Headline          string    `json:"headline" gorm:"type:varchar(255);not null;default:''"`
+	Roles             string    `json:"roles" gorm:"type:text;not null;default:'[]'"`
+	Bio               string    `json:"bio" gorm:"type:text;not null;default:''"`
+	AvatarURL         string    `json:"avatar_url" gorm:"type:varchar(500);not null;default:''"`
+	ResumeURL         string    `json:"resume_url" gorm:"type:varchar(500);not null;default:''"`
+	Location          string    `json:"location" gorm:"type:varchar(255);not null;default:''"`
+	Availability      string    `json:"availability" gorm:"type:varchar(30);not null;default:'open'"`
+	YearsOfExperience *int      `json:"years_of_experience" gorm:"type:integer"`
+	CreatedAt         time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt         time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (Profile) TableName() string {
+	return "portfolio_profile"
+}
+
+// ProfileRequest untuk PUT, mengganti seluruh field kecuali avatar
+type ProfileRequest struct {
+	Name              string   `json:"name" binding:"required"`
+	Headline          string   `json:"headline"`
+	Roles             []string `json:"roles"`
+	Bio               string   `json:"bio"`
+	ResumeURL         string   `json:"resume_url"`
+	Location          string   `json:"location"`
+	Availability      string   `json:"availability"`
+	YearsOfExperience *int     `json:"years_of_experience"`
+}
+
+// ProfilePatchRequest untuk PATCH, hanya field yang dikirim yang diubah.
+// years_of_experience bernilai null menghapus override.
+type ProfilePatchRequest struct {
+	Name              *string         `json:"name"`
+	Headline          *string         `json:"headline"`
+	Roles             *[]string       `json:"roles"`
+	Bio               *string         `json:"bio"`
+	ResumeURL         *string         `json:"resume_url"`
+	Location          *string         `json:"location"`
+	Availability      *string         `json:"availability"`
+	YearsOfExperience json.RawMessage `json:"years_of_experience"`
+}
+
+type ProfileResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Headline     string    `json:"headline"`
+	Roles        []string  `json:"roles"`
+	Bio          string    `json:"bio"`
+	AvatarURL    string    `json:"avatar_url"`
+	ResumeURL    string    `json:"resume_url"`
+	Location     string    `json:"location"`
+	Availability string    `json:"availability"`
+	// YearsOfExperience nilai efektif: override jika diisi, selain itu hasil hitung dari experience
+	YearsOfExperience         int       `json:"years_of_experience"`
+	YearsOfExperienceOverride *int      `json:"years_of_experience_override"`
+	UpdatedAt                 time.Time `json:"updated_at"`
+}
+
+// ExperiencePeriod periode kerja untuk menghitung total pengalaman
+type ExperiencePeriod struct {
+	StartDate  time.Time
+	EndDate    *time.Time
+	CurrentJob bool
+}
+
 // ============================
 // SETTINGS MODEL
 // ============================
 
+// Tipe data setting yang didukung
+const (
+	SettingTypeString  = "string"
+	SettingTypeNumber  = "number"
+	SettingTypeBoolean = "boolean"
+	SettingTypeJSON    = "json"
+)
+
+// Value disimpan dalam bentuk kanonik sesuai DataType: teks apa adanya untuk string,
+// literal JSON untuk number/boolean/json. Schema berisi JSON Schema opsional (kosong = tanpa schema).
 type Setting struct {
 	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	Key         string    `json:"key" gorm:"type:varchar(100);unique;not null"`
-	Value       string    `json:"value" gorm:"type:text"`
-	DataType    string    `json:"data_type" gorm:"type:varchar(20);default:'string'"`
+	Value       string    `json:"value" gorm:"type:text;not null;default:''"`
+	DataType    string    `json:"data_type" gorm:"type:varchar(20);not null;default:'string'"`
 	Description string    `json:"description" gorm:"type:text"`
+	IsPublic    bool      `json:"is_public" gorm:"not null;default:false"`
+	Schema      string    `json:"schema" gorm:"type:text;not null;default:''"`
 	CreatedAt   time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt   time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
@@ -440,19 +1167,49 @@ func (Setting) TableName() string {
 	return "portfolio_settings"
 }
 
+// Value berupa JSON bertipe (true, 42, {"a":1}); string berisi angka/boolean/JSON juga diterima
+// untuk kompatibilitas dengan klien lama
+// SettingRequest untuk POST (upsert). is_public dan schema yang tidak dikirim mempertahankan nilai lama
+// (false dan tanpa schema untuk setting baru); schema bernilai null menghapus schema.
 type SettingRequest struct {
-	Key         string `json:"key" binding:"required"`
-	Value       string `json:"value"`
-	DataType    string `json:"data_type"`
-	Description string `json:"description"`
+	Key         string          `json:"key" binding:"required"`
+	Value       json.RawMessage `json:"value"`
+	DataType    string          `json:"data_type"`
+	Description string          `json:"description"`
+	IsPublic    *bool           `json:"is_public"`
+	Schema      json.RawMessage `json:"schema"`
+}
+
+// SettingUpdateRequest untuk PUT, key kosong berarti memakai key dari URL / data lama.
+// is_public dan schema diperlakukan sama seperti SettingRequest.
+type SettingUpdateRequest struct {
+	Key         string          `json:"key"`
+	Value       json.RawMessage `json:"value"`
+	DataType    string          `json:"data_type"`
+	Description string          `json:"description"`
+	IsPublic    *bool           `json:"is_public"`
+	Schema      json.RawMessage `json:"schema"`
+}
+
+// SettingPatchRequest untuk PATCH, hanya field yang dikirim yang diubah.
+// Schema bernilai null menghapus schema.
+type SettingPatchRequest struct {
+	Key         *string         `json:"key"`
+	Value       json.RawMessage `json:"value"`
+	DataType    *string         `json:"data_type"`
+	Description *string         `json:"description"`
+	IsPublic    *bool           `json:"is_public"`
+	Schema      json.RawMessage `json:"schema"`
 }
 
 type SettingResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Key         string    `json:"key"`
-	Value       string    `json:"value"`
-	DataType    string    `json:"data_type"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID       `json:"id"`
+	Key         string          `json:"key"`
+	Value       interface{}     `json:"value"`
+	DataType    string          `json:"data_type"`
+	Description string          `json:"description"`
+	IsPublic    bool            `json:"is_public"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
 }
@@ -1,10 +1,15 @@
 package repo
 
 import (
+	"errors"
+	"fmt"
 	model "gintugas/modules/components/all/models"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ============================
@@ -19,6 +24,12 @@ type SkillRepository interface {
 	GetAll() ([]model.Skill, error)
 	GetFeatured() ([]model.Skill, error)
 	GetByCategory(category string) ([]model.Skill, error)
+	FindCategory(ref string) (*model.SkillCategory, error)
+	FindOrCreateCategory(label, slug string) (*model.SkillCategory, error)
+	GetExperienceUsage(id uuid.UUID) ([]model.SkillUsageExperience, error)
+	GetProjectUsage(id uuid.UUID, publishedOnly bool) ([]model.SkillUsageProject, error)
+	GetUsageCounts() ([]model.SkillUsageCount, error)
+	GetUsagePeriods() ([]model.SkillUsagePeriod, error)
 }
 
 type skillRepository struct {
@@ -29,18 +40,19 @@ func NewSkillRepository(db *gorm.DB) SkillRepository {
 	return &skillRepository{db: db}
 }
 
+// Kategori hanya di-preload untuk dibaca, perubahan kategori lewat category_id
 func (r *skillRepository) Create(skill *model.Skill) error {
-	return r.db.Create(skill).Error
+	return r.db.Omit("Category").Create(skill).Error
 }
 
 func (r *skillRepository) GetByID(id uuid.UUID) (*model.Skill, error) {
 	var skill model.Skill
-	err := r.db.Where("id = ?", id).First(&skill).Error
+	err := r.db.Preload("Category").Where("id = ?", id).First(&skill).Error
 	return &skill, err
 }
 
 func (r *skillRepository) Update(skill *model.Skill) error {
-	return r.db.Save(skill).Error
+	return r.db.Omit("Category").Save(skill).Error
 }
 
 func (r *skillRepository) Delete(id uuid.UUID) error {
@@ -49,22 +61,175 @@ func (r *skillRepository) Delete(id uuid.UUID) error {
 
 func (r *skillRepository) GetAll() ([]model.Skill, error) {
 	var skills []model.Skill
-	err := r.db.Order("display_order ASC, created_at DESC").Find(&skills).Error
+	err := r.db.Preload("Category").Order("display_order ASC, created_at DESC").Find(&skills).Error
 	return skills, err
 }
 
 func (r *skillRepository) GetFeatured() ([]model.Skill, error) {
 	var skills []model.Skill
-	err := r.db.Where("is_featured = ?", true).Order("display_order ASC").Find(&skills).Error
+	err := r.db.Preload("Category").Where("is_featured = ?", true).Order("display_order ASC").Find(&skills).Error
 	return skills, err
 }
 
+// GetByCategory menerima ID, slug, atau label kategori tanpa membedakan huruf besar/kecil
 func (r *skillRepository) GetByCategory(category string) ([]model.Skill, error) {
 	var skills []model.Skill
-	err := r.db.Where("category = ?", category).Order("display_order ASC").Find(&skills).Error
+	query := r.db.Preload("Category").
+		Joins("JOIN skill_categories sc ON sc.id = portfolio_skills.category_id")
+	if id, err := uuid.Parse(category); err == nil {
+		query = query.Where("sc.id = ?", id)
+	} else {
+		query = query.Where("LOWER(sc.slug) = LOWER(?) OR LOWER(sc.label) = LOWER(?)", category, category)
+	}
+	err := query.Order("portfolio_skills.display_order ASC").Find(&skills).Error
 	return skills, err
 }
 
+func (r *skillRepository) FindCategory(ref string) (*model.SkillCategory, error) {
+	var category model.SkillCategory
+	query := r.db.Where("LOWER(slug) = LOWER(?) OR LOWER(label) = LOWER(?)", ref, ref)
+	if id, err := uuid.Parse(ref); err == nil {
+		query = r.db.Where("id = ?", id)
+	}
+	err := query.Order("display_order ASC").First(&category).Error
+	return &category, err
+}
+
+// FindOrCreateCategory menambah kategori di urutan terakhir; request bersamaan dengan slug yang sama
+// tidak gagal karena UNIQUE, keduanya mendapat kategori yang sama
+func (r *skillRepository) FindOrCreateCategory(label, slug string) (*model.SkillCategory, error) {
+	err := r.db.Exec(`
+		INSERT INTO skill_categories (label, slug, display_order)
+		SELECT ?, ?, COALESCE(MAX(display_order) + 1, 0) FROM skill_categories
+		ON CONFLICT (slug) DO NOTHING`, label, slug).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var category model.SkillCategory
+	err = r.db.Where("slug = ?", slug).First(&category).Error
+	return &category, err
+}
+
+func (r *skillRepository) GetExperienceUsage(id uuid.UUID) ([]model.SkillUsageExperience, error) {
+	experiences := []model.SkillUsageExperience{}
+	err := r.db.Table("portfolio_experiences e").
+		Select("e.id, e.title, e.company, e.start_year, e.end_year, e.current_job").
+		Joins("JOIN experience_skills es ON es.experience_id = e.id").
+		Where("es.skill_id = ?", id).
+		Order("e.current_job DESC, e.end_date DESC NULLS LAST, e.start_date DESC").
+		Scan(&experiences).Error
+	return experiences, err
+}
+
+func (r *skillRepository) GetProjectUsage(id uuid.UUID, publishedOnly bool) ([]model.SkillUsageProject, error) {
+	projects := []model.SkillUsageProject{}
+	query := r.db.Table("portfolio_projects p").
+		Select("p.id, p.title, p.slug, p.status").
+		Joins("JOIN project_skills pks ON pks.project_id = p.id").
+		Where("pks.skill_id = ?", id)
+	if publishedOnly {
+		query = query.Where("p.status = ?", "published")
+	}
+	err := query.Order("p.display_order ASC, p.created_at DESC").Scan(&projects).Error
+	return projects, err
+}
+
+// GetUsageCounts menghitung pemakaian setiap skill, hanya projek published yang dihitung
+func (r *skillRepository) GetUsageCounts() ([]model.SkillUsageCount, error) {
+	var counts []model.SkillUsageCount
+	err := r.db.Table("portfolio_skills s").
+		Select(`s.id AS skill_id,
+			(SELECT COUNT(*) FROM experience_skills es WHERE es.skill_id = s.id) AS experience_count,
+			(SELECT COUNT(*) FROM project_skills pks
+				JOIN portfolio_projects p ON p.id = pks.project_id AND p.status = 'published'
+				WHERE pks.skill_id = s.id) AS project_count`).
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *skillRepository) GetUsagePeriods() ([]model.SkillUsagePeriod, error) {
+	var periods []model.SkillUsagePeriod
+	err := r.db.Table("experience_skills es").
+		Select("es.skill_id, e.start_date, e.end_date, e.current_job").
+		Joins("JOIN portfolio_experiences e ON e.id = es.experience_id").
+		Where("e.start_date IS NOT NULL").
+		Scan(&periods).Error
+	return periods, err
+}
+
+// ============================
+// SKILL CATEGORIES REPOSITORY
+// ============================
+
+type SkillCategoryRepository interface {
+	Create(category *model.SkillCategory) error
+	GetByID(id uuid.UUID) (*model.SkillCategory, error)
+	Update(category *model.SkillCategory) error
+	Delete(id uuid.UUID) error
+	GetAll() ([]model.SkillCategory, error)
+	SlugExists(slug string, excludeID uuid.UUID) (bool, error)
+	CountSkills() ([]model.SkillCategoryCount, error)
+}
+
+type skillCategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewSkillCategoryRepository(db *gorm.DB) SkillCategoryRepository {
+	return &skillCategoryRepository{db: db}
+}
+
+func (r *skillCategoryRepository) Create(category *model.SkillCategory) error {
+	return r.db.Create(category).Error
+}
+
+func (r *skillCategoryRepository) GetByID(id uuid.UUID) (*model.SkillCategory, error) {
+	var category model.SkillCategory
+	err := r.db.Where("id = ?", id).First(&category).Error
+	return &category, err
+}
+
+func (r *skillCategoryRepository) Update(category *model.SkillCategory) error {
+	return r.db.Save(category).Error
+}
+
+// Delete menghapus kategori, skill di dalamnya menjadi tanpa kategori (ON DELETE SET NULL)
+func (r *skillCategoryRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&model.SkillCategory{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *skillCategoryRepository) GetAll() ([]model.SkillCategory, error) {
+	var categories []model.SkillCategory
+	err := r.db.Order("display_order ASC, label ASC").Find(&categories).Error
+	return categories, err
+}
+
+func (r *skillCategoryRepository) SlugExists(slug string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.SkillCategory{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *skillCategoryRepository) CountSkills() ([]model.SkillCategoryCount, error) {
+	var counts []model.SkillCategoryCount
+	err := r.db.Table("portfolio_skills").
+		Select("category_id, COUNT(*) AS total").
+		Where("category_id IS NOT NULL").
+		Group("category_id").
+		Scan(&counts).Error
+	return counts, err
+}
+
 // ============================
 // CERTIFICATES REPOSITORY
 // ============================
@@ -75,6 +240,7 @@ type CertificateRepository interface {
 	Update(cert *model.Certificate) error
 	Delete(id uuid.UUID) error
 	GetAll() ([]model.Certificate, error)
+	UpdateVerification(id uuid.UUID, httpStatus *int, checkedAt time.Time, verificationError string) error
 }
 
 type certificateRepository struct {
@@ -109,6 +275,17 @@ func (r *certificateRepository) GetAll() ([]model.Certificate, error) {
 	return certs, err
 }
 
+// UpdateVerification hanya menulis kolom hasil verifikasi agar tidak menimpa edit yang terjadi bersamaan
+func (r *certificateRepository) UpdateVerification(id uuid.UUID, httpStatus *int, checkedAt time.Time, verificationError string) error {
+	return r.db.Model(&model.Certificate{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_http_status":   httpStatus,
+			"last_checked_at":    checkedAt,
+			"verification_error": verificationError,
+		}).Error
+}
+
 // ============================
 // EDUCATION REPOSITORY
 // ============================
@@ -275,12 +452,22 @@ type BlogRepository interface {
 	DeleteWithTags(id uuid.UUID) error
 	GetAllWithTags() ([]model.BlogPost, error)
 	GetPublishedWithTags() ([]model.BlogPost, error)
-	IncrementViewCount(id uuid.UUID) error
+	GetRelatedScores(id uuid.UUID) ([]model.BlogRelatedScore, error)
+	SlugExists(slug string, excludeID uuid.UUID) (bool, error)
+	GetCurrentSlug(oldSlug string) (string, error)
 
 	// Tag operations
 	CreateTag(tag *model.BlogTag) error
 	GetOrCreateTag(name string) (*model.BlogTag, error)
 	GetAllTags() ([]model.BlogTag, error)
+	GetTagByID(id uuid.UUID) (*model.BlogTag, error)
+	FindTagByName(name string) (*model.BlogTag, error)
+	GetTagsWithUsage() ([]model.BlogTagUsage, error)
+	CountTagPosts(id uuid.UUID) (int64, error)
+	RenameTag(id uuid.UUID, name string) error
+	MergeTags(targetID uuid.UUID, sourceIDs []uuid.UUID) error
+	DeleteTag(id uuid.UUID) error
+	DeleteUnusedTags() (int64, error)
 }
 
 type blogRepository struct {
@@ -294,15 +481,9 @@ func NewBlogRepository(db *gorm.DB) BlogRepository {
 func (r *blogRepository) CreateWithTags(post *model.BlogPost) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		// Handle tags first - get or create
-		var processedTags []model.BlogTag
-		if len(post.Tags) > 0 {
-			for _, tag := range post.Tags {
-				existingTag, err := r.getOrCreateTagTx(tx, tag.Name)
-				if err != nil {
-					return err
-				}
-				processedTags = append(processedTags, *existingTag)
-			}
+		processedTags, err := r.processTagsTx(tx, post.Tags)
+		if err != nil {
+			return err
 		}
 
 		// Replace tags with processed ones
@@ -320,32 +501,43 @@ func (r *blogRepository) CreateWithTags(post *model.BlogPost) error {
 func (r *blogRepository) GetByIDWithTags(id uuid.UUID) (*model.BlogPost, error) {
 	var post model.BlogPost
 	err := r.db.Preload("Tags").Where("id = ?", id).First(&post).Error
-	return &post, err
+	if err != nil {
+		return &post, err
+	}
+
+	posts := []model.BlogPost{post}
+	err = r.attachCommentCounts(posts)
+	return &posts[0], err
 }
 
 func (r *blogRepository) GetBySlugWithTags(slug string) (*model.BlogPost, error) {
 	var post model.BlogPost
 	err := r.db.Preload("Tags").Where("slug = ?", slug).First(&post).Error
-	return &post, err
+	if err != nil {
+		return &post, err
+	}
+
+	posts := []model.BlogPost{post}
+	err = r.attachCommentCounts(posts)
+	return &posts[0], err
 }
 
 func (r *blogRepository) UpdateWithTags(post *model.BlogPost) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		// Handle tags - get or create
-		var processedTags []model.BlogTag
-		if len(post.Tags) > 0 {
-			for _, tag := range post.Tags {
-				existingTag, err := r.getOrCreateTagTx(tx, tag.Name)
-				if err != nil {
-					return err
-				}
-				processedTags = append(processedTags, *existingTag)
-			}
+		processedTags, err := r.processTagsTx(tx, post.Tags)
+		if err != nil {
+			return err
 		}
 
 		// Replace tags with processed ones
 		post.Tags = processedTags
 
+		// Simpan slug lama ke history jika slug berubah
+		if err := r.recordSlugChangeTx(tx, post); err != nil {
+			return err
+		}
+
 		// Update post and replace associations
 		if err := tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(post).Error; err != nil {
 			return err
@@ -363,7 +555,10 @@ func (r *blogRepository) DeleteWithTags(id uuid.UUID) error {
 func (r *blogRepository) GetAllWithTags() ([]model.BlogPost, error) {
 	var posts []model.BlogPost
 	err := r.db.Preload("Tags").Order("created_at DESC").Find(&posts).Error
-	return posts, err
+	if err != nil {
+		return posts, err
+	}
+	return posts, r.attachCommentCounts(posts)
 }
 
 func (r *blogRepository) GetPublishedWithTags() ([]model.BlogPost, error) {
@@ -372,13 +567,125 @@ func (r *blogRepository) GetPublishedWithTags() ([]model.BlogPost, error) {
 		Where("status = ?", "published").
 		Order("publish_date DESC").
 		Find(&posts).Error
-	return posts, err
+	if err != nil {
+		return posts, err
+	}
+	return posts, r.attachCommentCounts(posts)
 }
 
-func (r *blogRepository) IncrementViewCount(id uuid.UUID) error {
-	return r.db.Model(&model.BlogPost{}).
-		Where("id = ?", id).
-		Update("view_count", gorm.Expr("view_count + 1")).Error
+// attachCommentCounts mengisi CommentCount (hanya komentar approved) untuk setiap post
+func (r *blogRepository) attachCommentCounts(posts []model.BlogPost) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]uuid.UUID, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	var counts []struct {
+		PostID uuid.UUID
+		Total  int
+	}
+	err := r.db.Model(&model.BlogComment{}).
+		Select("post_id, COUNT(*) AS total").
+		Where("post_id IN ? AND status = ?", postIDs, "approved").
+		Group("post_id").
+		Scan(&counts).Error
+	if err != nil {
+		return err
+	}
+
+	countByPost := make(map[uuid.UUID]int, len(counts))
+	for _, count := range counts {
+		countByPost[count.PostID] = count.Total
+	}
+	for i := range posts {
+		posts[i].CommentCount = countByPost[posts[i].ID]
+	}
+
+	return nil
+}
+
+// GetRelatedScores menghitung skor kedekatan post lain yang sudah published berdasarkan tag yang sama.
+// Setiap tag diberi bobot ln((N+1)/df) sehingga tag yang jarang dipakai bernilai lebih tinggi.
+func (r *blogRepository) GetRelatedScores(id uuid.UUID) ([]model.BlogRelatedScore, error) {
+	query := `
+		WITH published AS (
+			SELECT id FROM portfolio_blog_posts WHERE status = 'published'
+		),
+		tag_df AS (
+			SELECT bpt.tag_id, COUNT(*) AS df
+			FROM blog_post_tags bpt
+			JOIN published p ON p.id = bpt.post_id
+			GROUP BY bpt.tag_id
+		),
+		total AS (
+			SELECT COUNT(*) AS n FROM published
+		)
+		SELECT other.post_id,
+		       COUNT(*) AS shared_tags,
+		       SUM(LN((total.n + 1.0) / tag_df.df))::float8 AS score
+		FROM blog_post_tags src
+		JOIN blog_post_tags other ON other.tag_id = src.tag_id AND other.post_id <> src.post_id
+		JOIN published p ON p.id = other.post_id
+		JOIN tag_df ON tag_df.tag_id = src.tag_id
+		CROSS JOIN total
+		WHERE src.post_id = ?
+		GROUP BY other.post_id
+	`
+
+	var scores []model.BlogRelatedScore
+	err := r.db.Raw(query, id).Scan(&scores).Error
+	return scores, err
+}
+
+// SlugExists mengecek apakah slug sudah dipakai post lain, baik sebagai slug aktif maupun slug lama
+func (r *blogRepository) SlugExists(slug string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.BlogPost{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	err = r.db.Model(&model.BlogPostSlugHistory{}).
+		Where("slug = ? AND post_id <> ?", slug, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetCurrentSlug mencari slug aktif dari sebuah slug lama
+func (r *blogRepository) GetCurrentSlug(oldSlug string) (string, error) {
+	var post model.BlogPost
+	err := r.db.
+		Joins("JOIN blog_post_slug_history h ON h.post_id = portfolio_blog_posts.id").
+		Where("h.slug = ?", oldSlug).
+		First(&post).Error
+	return post.Slug, err
+}
+
+func (r *blogRepository) recordSlugChangeTx(tx *gorm.DB, post *model.BlogPost) error {
+	var oldSlug string
+	if err := tx.Model(&model.BlogPost{}).Where("id = ?", post.ID).Pluck("slug", &oldSlug).Error; err != nil {
+		return err
+	}
+	if oldSlug == "" || oldSlug == post.Slug {
+		return nil
+	}
+
+	history := model.BlogPostSlugHistory{Slug: oldSlug, PostID: post.ID}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"post_id", "created_at"}),
+	}).Create(&history).Error; err != nil {
+		return err
+	}
+
+	// Slug baru tidak boleh tetap tercatat sebagai slug lama (misal kembali ke slug sebelumnya)
+	return tx.Where("slug = ?", post.Slug).Delete(&model.BlogPostSlugHistory{}).Error
 }
 
 func (r *blogRepository) CreateTag(tag *model.BlogTag) error {
@@ -389,9 +696,32 @@ func (r *blogRepository) GetOrCreateTag(name string) (*model.BlogTag, error) {
 	return r.getOrCreateTagTx(r.db, name)
 }
 
+// processTagsTx mengganti tag request dengan tag di database; nama yang sama (beda huruf besar/kecil) hanya dipakai sekali
+func (r *blogRepository) processTagsTx(tx *gorm.DB, tags []model.BlogTag) ([]model.BlogTag, error) {
+	var processedTags []model.BlogTag
+	seen := make(map[uuid.UUID]bool)
+	for _, tag := range tags {
+		if strings.TrimSpace(tag.Name) == "" {
+			continue
+		}
+		existingTag, err := r.getOrCreateTagTx(tx, tag.Name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[existingTag.ID] {
+			continue
+		}
+		seen[existingTag.ID] = true
+		processedTags = append(processedTags, *existingTag)
+	}
+	return processedTags, nil
+}
+
 func (r *blogRepository) getOrCreateTagTx(tx *gorm.DB, name string) (*model.BlogTag, error) {
+	name = strings.TrimSpace(name)
+
 	var tag model.BlogTag
-	err := tx.Where("name = ?", name).First(&tag).Error
+	err := tx.Where("LOWER(name) = LOWER(?)", name).First(&tag).Error
 	if err == gorm.ErrRecordNotFound {
 		tag = model.BlogTag{Name: name}
 		if err := tx.Create(&tag).Error; err != nil {
@@ -408,14 +738,485 @@ func (r *blogRepository) GetAllTags() ([]model.BlogTag, error) {
 	return tags, err
 }
 
+func (r *blogRepository) GetTagByID(id uuid.UUID) (*model.BlogTag, error) {
+	var tag model.BlogTag
+	err := r.db.Where("id = ?", id).First(&tag).Error
+	return &tag, err
+}
+
+func (r *blogRepository) FindTagByName(name string) (*model.BlogTag, error) {
+	var tag model.BlogTag
+	err := r.db.Where("LOWER(name) = LOWER(?)", strings.TrimSpace(name)).First(&tag).Error
+	return &tag, err
+}
+
+func (r *blogRepository) GetTagsWithUsage() ([]model.BlogTagUsage, error) {
+	var tags []model.BlogTagUsage
+	err := r.db.Table("blog_tags t").
+		Select("t.id, t.name, t.created_at, COUNT(bpt.post_id) AS post_count").
+		Joins("LEFT JOIN blog_post_tags bpt ON bpt.tag_id = t.id").
+		Group("t.id, t.name, t.created_at").
+		Order("post_count DESC, t.name ASC").
+		Scan(&tags).Error
+	return tags, err
+}
+
+func (r *blogRepository) CountTagPosts(id uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Table("blog_post_tags").Where("tag_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *blogRepository) RenameTag(id uuid.UUID, name string) error {
+	return r.db.Model(&model.BlogTag{}).
+		Where("id = ?", id).
+		Update("name", strings.TrimSpace(name)).Error
+}
+
+// MergeTags memindahkan semua relasi post dari tag sumber ke tag target lalu menghapus tag sumber
+func (r *blogRepository) MergeTags(targetID uuid.UUID, sourceIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Post yang sudah punya tag target tidak perlu relasi baru
+		if err := tx.Exec(`
+			INSERT INTO blog_post_tags (post_id, tag_id)
+			SELECT DISTINCT post_id, ? FROM blog_post_tags WHERE tag_id IN ?
+			ON CONFLICT DO NOTHING
+		`, targetID, sourceIDs).Error; err != nil {
+			return err
+		}
+
+		// Relasi lama ikut terhapus karena ON DELETE CASCADE
+		return tx.Where("id IN ?", sourceIDs).Delete(&model.BlogTag{}).Error
+	})
+}
+
+func (r *blogRepository) DeleteTag(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&model.BlogTag{}).Error
+}
+
+func (r *blogRepository) DeleteUnusedTags() (int64, error) {
+	result := r.db.Where("NOT EXISTS (SELECT 1 FROM blog_post_tags bpt WHERE bpt.tag_id = blog_tags.id)").
+		Delete(&model.BlogTag{})
+	return result.RowsAffected, result.Error
+}
+
+// ============================
+// BLOG COMMENTS REPOSITORY
+// ============================
+
+type CommentRepository interface {
+	Create(comment *model.BlogComment) error
+	GetByID(id uuid.UUID) (*model.BlogComment, error)
+	UpdateStatus(id uuid.UUID, status string) error
+	Delete(id uuid.UUID) error
+	GetApprovedByPost(postID uuid.UUID) ([]model.BlogComment, error)
+	GetAll(filter model.CommentFilter) ([]model.BlogComment, error)
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+func (r *commentRepository) Create(comment *model.BlogComment) error {
+	return r.db.Create(comment).Error
+}
+
+func (r *commentRepository) GetByID(id uuid.UUID) (*model.BlogComment, error) {
+	var comment model.BlogComment
+	err := r.db.Where("id = ?", id).First(&comment).Error
+	return &comment, err
+}
+
+func (r *commentRepository) UpdateStatus(id uuid.UUID, status string) error {
+	return r.db.Model(&model.BlogComment{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": gorm.Expr("NOW()"),
+		}).Error
+}
+
+func (r *commentRepository) Delete(id uuid.UUID) error {
+	// Balasan ikut terhapus karena ON DELETE CASCADE pada parent_id
+	result := r.db.Where("id = ?", id).Delete(&model.BlogComment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *commentRepository) GetApprovedByPost(postID uuid.UUID) ([]model.BlogComment, error) {
+	var comments []model.BlogComment
+	err := r.db.Where("post_id = ? AND status = ?", postID, "approved").
+		Order("created_at ASC").
+		Find(&comments).Error
+	return comments, err
+}
+
+func (r *commentRepository) GetAll(filter model.CommentFilter) ([]model.BlogComment, error) {
+	var comments []model.BlogComment
+	query := r.db.Order("created_at DESC")
+	if filter.PostID != uuid.Nil {
+		query = query.Where("post_id = ?", filter.PostID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	err := query.Find(&comments).Error
+	return comments, err
+}
+
+// ============================
+// BLOG ANALYTICS REPOSITORY
+// ============================
+
+type BlogAnalyticsRepository interface {
+	RecordView(view model.BlogView) (bool, error)
+	PurgeVisitorsBefore(date time.Time) error
+	GetViewsOverTime(filter model.AnalyticsFilter) ([]model.ViewsPointResponse, error)
+	GetTopPosts(filter model.AnalyticsFilter) ([]model.TopPostResponse, error)
+	GetTopReferrers(filter model.AnalyticsFilter) ([]model.ReferrerResponse, error)
+}
+
+type blogAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewBlogAnalyticsRepository(db *gorm.DB) BlogAnalyticsRepository {
+	return &blogAnalyticsRepository{db: db}
+}
+
+// RecordView mencatat satu view ke bucket harian. Mengembalikan false jika pengunjung
+// yang sama sudah tercatat untuk post tersebut pada hari yang sama.
+func (r *blogAnalyticsRepository) RecordView(view model.BlogView) (bool, error) {
+	recorded := false
+	viewDate := view.ViewedAt.UTC().Truncate(24 * time.Hour)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		visitor := model.BlogPostViewVisitor{
+			PostID:      view.PostID,
+			ViewDate:    viewDate,
+			VisitorHash: view.VisitorHash,
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&visitor)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		daily := model.BlogPostViewDaily{PostID: view.PostID, ViewDate: viewDate, Views: 1}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "post_id"}, {Name: "view_date"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"views": gorm.Expr("blog_post_views_daily.views + 1")}),
+		}).Create(&daily).Error; err != nil {
+			return err
+		}
+
+		if view.Referrer != "" {
+			referrer := model.BlogPostReferrerDaily{PostID: view.PostID, ViewDate: viewDate, Referrer: view.Referrer, Views: 1}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "post_id"}, {Name: "view_date"}, {Name: "referrer"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"views": gorm.Expr("blog_post_referrers_daily.views + 1")}),
+			}).Create(&referrer).Error; err != nil {
+				return err
+			}
+		}
+
+		// view_count tetap dipakai sebagai total di response blog
+		if err := tx.Model(&model.BlogPost{}).
+			Where("id = ?", view.PostID).
+			UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error; err != nil {
+			return err
+		}
+
+		recorded = true
+		return nil
+	})
+
+	return recorded, err
+}
+
+// PurgeVisitorsBefore menghapus hash pengunjung lama, hanya dibutuhkan untuk deduplikasi hari berjalan
+func (r *blogAnalyticsRepository) PurgeVisitorsBefore(date time.Time) error {
+	return r.db.Where("view_date < ?", date.UTC().Truncate(24*time.Hour)).
+		Delete(&model.BlogPostViewVisitor{}).Error
+}
+
+func (r *blogAnalyticsRepository) GetViewsOverTime(filter model.AnalyticsFilter) ([]model.ViewsPointResponse, error) {
+	var points []model.ViewsPointResponse
+	query := r.db.Model(&model.BlogPostViewDaily{}).
+		Select("TO_CHAR(view_date, 'YYYY-MM-DD') AS date, SUM(views)::int AS views").
+		Where("view_date BETWEEN ? AND ?", filter.From, filter.To)
+	if filter.PostID != nil {
+		query = query.Where("post_id = ?", *filter.PostID)
+	}
+	err := query.Group("view_date").Order("view_date ASC").Scan(&points).Error
+	return points, err
+}
+
+func (r *blogAnalyticsRepository) GetTopPosts(filter model.AnalyticsFilter) ([]model.TopPostResponse, error) {
+	var posts []model.TopPostResponse
+	err := r.db.Table("blog_post_views_daily AS v").
+		Select("p.id AS post_id, p.title, p.slug, SUM(v.views)::int AS views").
+		Joins("INNER JOIN portfolio_blog_posts p ON p.id = v.post_id").
+		Where("v.view_date BETWEEN ? AND ?", filter.From, filter.To).
+		Group("p.id, p.title, p.slug").
+		Order("views DESC, p.title ASC").
+		Limit(filter.Limit).
+		Scan(&posts).Error
+	return posts, err
+}
+
+func (r *blogAnalyticsRepository) GetTopReferrers(filter model.AnalyticsFilter) ([]model.ReferrerResponse, error) {
+	var referrers []model.ReferrerResponse
+	query := r.db.Model(&model.BlogPostReferrerDaily{}).
+		Select("referrer, SUM(views)::int AS views").
+		Where("view_date BETWEEN ? AND ?", filter.From, filter.To)
+	if filter.PostID != nil {
+		query = query.Where("post_id = ?", *filter.PostID)
+	}
+	err := query.Group("referrer").
+		Order("views DESC, referrer ASC").
+		Limit(filter.Limit).
+		Scan(&referrers).Error
+	return referrers, err
+}
+
+// ============================
+// PREVIEW LINKS REPOSITORY
+// ============================
+
+type PreviewLinkRepository interface {
+	Create(link *model.PreviewLink) error
+	GetByID(id uuid.UUID) (*model.PreviewLink, error)
+	GetActive(filter model.PreviewLinkFilter) ([]model.PreviewLink, error)
+	Revoke(id uuid.UUID) error
+	EntityExists(entityType string, id uuid.UUID) (bool, error)
+}
+
+type previewLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewPreviewLinkRepository(db *gorm.DB) PreviewLinkRepository {
+	return &previewLinkRepository{db: db}
+}
+
+// Tabel sumber untuk setiap entity yang bisa di-preview
+var previewEntityTables = map[string]string{
+	model.PreviewEntityBlogPost: "portfolio_blog_posts",
+	model.PreviewEntityProject:  "portfolio_projects",
+}
+
+func (r *previewLinkRepository) Create(link *model.PreviewLink) error {
+	return r.db.Create(link).Error
+}
+
+func (r *previewLinkRepository) GetByID(id uuid.UUID) (*model.PreviewLink, error) {
+	var link model.PreviewLink
+	err := r.db.Where("id = ?", id).First(&link).Error
+	return &link, err
+}
+
+func (r *previewLinkRepository) GetActive(filter model.PreviewLinkFilter) ([]model.PreviewLink, error) {
+	var links []model.PreviewLink
+	query := r.db.Where("revoked_at IS NULL AND expires_at > NOW()").Order("created_at DESC")
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != uuid.Nil {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	err := query.Find(&links).Error
+	return links, err
+}
+
+func (r *previewLinkRepository) Revoke(id uuid.UUID) error {
+	return r.db.Model(&model.PreviewLink{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", gorm.Expr("NOW()")).Error
+}
+
+func (r *previewLinkRepository) EntityExists(entityType string, id uuid.UUID) (bool, error) {
+	table, ok := previewEntityTables[entityType]
+	if !ok {
+		return false, nil
+	}
+
+	var count int64
+	err := r.db.Table(table).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// ============================
+// DISPLAY ORDER REPOSITORY
+// ============================
+
+// ErrUnknownOrderID dikembalikan saat daftar urutan berisi ID yang tidak ada di tabel
+var ErrUnknownOrderID = errors.New("unknown id in order list")
+
+type DisplayOrderRepository interface {
+	Reorder(entity string, ids []uuid.UUID) ([]model.DisplayOrderItem, error)
+}
+
+type displayOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewDisplayOrderRepository(db *gorm.DB) DisplayOrderRepository {
+	return &displayOrderRepository{db: db}
+}
+
+// Tabel untuk setiap entity yang punya kolom display_order
+var displayOrderTables = map[string]string{
+	model.OrderEntityProjects:        "portfolio_projects",
+	model.OrderEntityExperiences:     "portfolio_experiences",
+	model.OrderEntitySkills:          "portfolio_skills",
+	model.OrderEntitySkillCategories: "skill_categories",
+	model.OrderEntityCertificates:    "portfolio_certificates",
+	model.OrderEntityEducation:       "portfolio_education",
+	model.OrderEntityTestimonials:    "portfolio_testimonials",
+	model.OrderEntitySections:        "portfolio_sections",
+	model.OrderEntitySocialLinks:     "portfolio_social_links",
+}
+
+// Reorder menomori ulang display_order seluruh baris (mulai dari 0) dalam satu transaksi.
+// ID yang dikirim mendapat urutan sesuai posisinya, sisanya menyusul dengan urutan lama.
+// Semua baris dikunci dulu agar dua request reorder tidak saling menimpa.
+func (r *displayOrderRepository) Reorder(entity string, ids []uuid.UUID) ([]model.DisplayOrderItem, error) {
+	table, ok := displayOrderTables[entity]
+	if !ok {
+		return nil, fmt.Errorf("entity %q does not support ordering", entity)
+	}
+	return reorderRows(r.db, table, nil, ids)
+}
+
+// reorderRows menjalankan reorder pada tabel, scope (opsional) membatasi baris yang ikut dinomori
+func reorderRows(db *gorm.DB, table string, scope func(*gorm.DB) *gorm.DB, ids []uuid.UUID) ([]model.DisplayOrderItem, error) {
+	var items []model.DisplayOrderItem
+	err := db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Table(table)
+		if scope != nil {
+			query = query.Scopes(scope)
+		}
+
+		var current []model.DisplayOrderItem
+		if err := query.
+			Select("id, display_order").
+			Order("display_order ASC, created_at DESC, id ASC").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scan(&current).Error; err != nil {
+			return err
+		}
+
+		known := make(map[uuid.UUID]int, len(current))
+		for _, item := range current {
+			known[item.ID] = item.DisplayOrder
+		}
+
+		ordered := make([]uuid.UUID, 0, len(current))
+		listed := make(map[uuid.UUID]bool, len(ids))
+		for _, id := range ids {
+			if _, exists := known[id]; !exists {
+				return fmt.Errorf("%w: %s", ErrUnknownOrderID, id)
+			}
+			listed[id] = true
+			ordered = append(ordered, id)
+		}
+		for _, item := range current {
+			if !listed[item.ID] {
+				ordered = append(ordered, item.ID)
+			}
+		}
+
+		items = make([]model.DisplayOrderItem, 0, len(ordered))
+		for i, id := range ordered {
+			if known[id] != i {
+				if err := tx.Table(table).Where("id = ?", id).UpdateColumn("display_order", i).Error; err != nil {
+					return err
+				}
+			}
+			items = append(items, model.DisplayOrderItem{ID: id, DisplayOrder: i})
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ============================
+// TIMELINE REPOSITORY
+// ============================
+
+type TimelineRepository interface {
+	GetRows(entryType string) ([]model.TimelineRow, error)
+}
+
+type timelineRepository struct {
+	db *gorm.DB
+}
+
+func NewTimelineRepository(db *gorm.DB) TimelineRepository {
+	return &timelineRepository{db: db}
+}
+
+// Query sumber untuk setiap jenis entry. Baris tanpa tanggal tidak bisa diurutkan sehingga dilewati.
+var timelineQueries = map[string]string{
+	model.TimelineExperience: `
+		SELECT 'experience' AS type, id, title, company AS subtitle, '' AS slug, start_date, end_date,
+		       current_job AS current
+		FROM portfolio_experiences
+		WHERE start_date IS NOT NULL`,
+	model.TimelineEducation: `
+		SELECT 'education' AS type, id, CONCAT_WS(' ', NULLIF(degree, ''), major) AS title, school AS subtitle,
+		       '' AS slug, start_date, end_date, is_current AS current
+		FROM portfolio_education
+		WHERE start_date IS NOT NULL`,
+	model.TimelineCertificate: `
+		SELECT 'certificate' AS type, id, name AS title, COALESCE(issuer, '') AS subtitle, '' AS slug,
+		       issue_date AS start_date, issue_date AS end_date, FALSE AS current
+		FROM portfolio_certificates
+		WHERE issue_date IS NOT NULL`,
+	model.TimelineProject: `
+		SELECT 'project' AS type, p.id, p.title, COALESCE(e.company, '') AS subtitle, p.slug,
+		       p.created_at::date AS start_date, p.created_at::date AS end_date, FALSE AS current
+		FROM portfolio_projects p
+		LEFT JOIN portfolio_experiences e ON e.id = p.experience_id
+		WHERE p.status = 'published'`,
+}
+
+func (r *timelineRepository) GetRows(entryType string) ([]model.TimelineRow, error) {
+	query, ok := timelineQueries[entryType]
+	if !ok {
+		return nil, fmt.Errorf("unknown timeline type %q", entryType)
+	}
+
+	var rows []model.TimelineRow
+	err := r.db.Raw(query).Scan(&rows).Error
+	return rows, err
+}
+
 // ============================
 // SECTIONS REPOSITORY
 // ============================
 
 type SectionRepository interface {
 	Create(section *model.Section) error
+	GetByID(id uuid.UUID) (*model.Section, error)
+	GetBySectionID(sectionID string) (*model.Section, error)
+	Update(section *model.Section) error
 	Delete(id uuid.UUID) error
 	GetAll() ([]model.Section, error)
+	GetActive() ([]model.Section, error)
 }
 
 type sectionRepository struct {
@@ -430,6 +1231,22 @@ func (r *sectionRepository) Create(section *model.Section) error {
 	return r.db.Create(section).Error
 }
 
+func (r *sectionRepository) GetByID(id uuid.UUID) (*model.Section, error) {
+	var section model.Section
+	err := r.db.Where("id = ?", id).First(&section).Error
+	return &section, err
+}
+
+func (r *sectionRepository) GetBySectionID(sectionID string) (*model.Section, error) {
+	var section model.Section
+	err := r.db.Where("section_id = ?", sectionID).First(&section).Error
+	return &section, err
+}
+
+func (r *sectionRepository) Update(section *model.Section) error {
+	return r.db.Save(section).Error
+}
+
 func (r *sectionRepository) Delete(id uuid.UUID) error {
 	return r.db.Where("id = ?", id).Delete(&model.Section{}).Error
 }
@@ -440,14 +1257,146 @@ func (r *sectionRepository) GetAll() ([]model.Section, error) {
 	return sections, err
 }
 
+func (r *sectionRepository) GetActive() ([]model.Section, error) {
+	var sections []model.Section
+	err := r.db.Where("is_active = ?", true).Order("display_order ASC").Find(&sections).Error
+	return sections, err
+}
+
+// ============================
+// SECTION BLOCKS REPOSITORY
+// ============================
+
+type SectionBlockRepository interface {
+	Create(block *model.SectionBlock) error
+	GetByID(id uuid.UUID) (*model.SectionBlock, error)
+	Update(block *model.SectionBlock) error
+	Delete(id uuid.UUID) error
+	GetBySection(sectionID uuid.UUID, activeOnly bool) ([]model.SectionBlock, error)
+	GetBySections(sectionIDs []uuid.UUID, activeOnly bool) ([]model.SectionBlock, error)
+	NextDisplayOrder(sectionID uuid.UUID) (int, error)
+	Reorder(sectionID uuid.UUID, ids []uuid.UUID) ([]model.DisplayOrderItem, error)
+	GetProjectCards(mode string, ids []uuid.UUID, limit int) ([]model.PageProjectCard, error)
+}
+
+type sectionBlockRepository struct {
+	db *gorm.DB
+}
+
+func NewSectionBlockRepository(db *gorm.DB) SectionBlockRepository {
+	return &sectionBlockRepository{db: db}
+}
+
+func (r *sectionBlockRepository) Create(block *model.SectionBlock) error {
+	return r.db.Create(block).Error
+}
+
+func (r *sectionBlockRepository) GetByID(id uuid.UUID) (*model.SectionBlock, error) {
+	var block model.SectionBlock
+	err := r.db.Where("id = ?", id).First(&block).Error
+	return &block, err
+}
+
+func (r *sectionBlockRepository) Update(block *model.SectionBlock) error {
+	return r.db.Save(block).Error
+}
+
+func (r *sectionBlockRepository) Delete(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&model.SectionBlock{}).Error
+}
+
+func (r *sectionBlockRepository) GetBySection(sectionID uuid.UUID, activeOnly bool) ([]model.SectionBlock, error) {
+	return r.GetBySections([]uuid.UUID{sectionID}, activeOnly)
+}
+
+func (r *sectionBlockRepository) GetBySections(sectionIDs []uuid.UUID, activeOnly bool) ([]model.SectionBlock, error) {
+	var blocks []model.SectionBlock
+	if len(sectionIDs) == 0 {
+		return blocks, nil
+	}
+
+	query := r.db.Where("section_id IN ?", sectionIDs)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("display_order ASC, created_at ASC").Find(&blocks).Error
+	return blocks, err
+}
+
+func (r *sectionBlockRepository) NextDisplayOrder(sectionID uuid.UUID) (int, error) {
+	var next int
+	err := r.db.Model(&model.SectionBlock{}).
+		Where("section_id = ?", sectionID).
+		Select("COALESCE(MAX(display_order) + 1, 0)").
+		Scan(&next).Error
+	return next, err
+}
+
+// Reorder hanya menomori ulang block di dalam satu section
+func (r *sectionBlockRepository) Reorder(sectionID uuid.UUID, ids []uuid.UUID) ([]model.DisplayOrderItem, error) {
+	return reorderRows(r.db, "section_blocks", func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("section_id = ?", sectionID)
+	}, ids)
+}
+
+// GetProjectCards mengambil project published untuk block project_list.
+// Mode manual mengikuti urutan ids, featured dan latest memakai urutan tampil / terbaru.
+func (r *sectionBlockRepository) GetProjectCards(mode string, ids []uuid.UUID, limit int) ([]model.PageProjectCard, error) {
+	var cards []model.PageProjectCard
+	query := r.db.Table("portfolio_projects").
+		Select("id, title, slug, description, COALESCE(image_url, '') AS image_url, COALESCE(demo_url, '') AS demo_url, code_url, is_featured").
+		Where("status = ?", "published")
+
+	switch mode {
+	case "manual":
+		if len(ids) == 0 {
+			return cards, nil
+		}
+		query = query.Where("id IN ?", ids)
+	case "featured":
+		query = query.Where("is_featured = ?", true).Order("display_order ASC, created_at DESC")
+	default:
+		query = query.Order("created_at DESC")
+	}
+	if limit > 0 && mode != "manual" {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Scan(&cards).Error; err != nil {
+		return nil, err
+	}
+	if mode != "manual" {
+		return cards, nil
+	}
+
+	byID := make(map[uuid.UUID]model.PageProjectCard, len(cards))
+	for _, card := range cards {
+		byID[card.ID] = card
+	}
+	ordered := make([]model.PageProjectCard, 0, len(cards))
+	for _, id := range ids {
+		if card, ok := byID[id]; ok {
+			ordered = append(ordered, card)
+		}
+	}
+	if limit > 0 && len(ordered) > limit {
+		ordered = ordered[:limit]
+	}
+	return ordered, nil
+}
+
 // ============================
 // SOCIAL LINKS REPOSITORY
 // ============================
 
 type SocialLinkRepository interface {
 	Create(link *model.SocialLink) error
+	GetByID(id uuid.UUID) (*model.SocialLink, error)
+	GetByPlatform(platform string) (*model.SocialLink, error)
+	Update(link *model.SocialLink) error
 	Delete(id uuid.UUID) error
 	GetAll() ([]model.SocialLink, error)
+	GetActive() ([]model.SocialLink, error)
 }
 
 type socialLinkRepository struct {
@@ -462,6 +1411,23 @@ func (r *socialLinkRepository) Create(link *model.SocialLink) error {
 	return r.db.Create(link).Error
 }
 
+func (r *socialLinkRepository) GetByID(id uuid.UUID) (*model.SocialLink, error) {
+	var link model.SocialLink
+	err := r.db.Where("id = ?", id).First(&link).Error
+	return &link, err
+}
+
+// GetByPlatform mencocokkan platform tanpa membedakan huruf besar/kecil ("GitHub" = "github")
+func (r *socialLinkRepository) GetByPlatform(platform string) (*model.SocialLink, error) {
+	var link model.SocialLink
+	err := r.db.Where("LOWER(platform) = LOWER(?)", platform).First(&link).Error
+	return &link, err
+}
+
+func (r *socialLinkRepository) Update(link *model.SocialLink) error {
+	return r.db.Save(link).Error
+}
+
 func (r *socialLinkRepository) Delete(id uuid.UUID) error {
 	return r.db.Where("id = ?", id).Delete(&model.SocialLink{}).Error
 }
@@ -472,14 +1438,66 @@ func (r *socialLinkRepository) GetAll() ([]model.SocialLink, error) {
 	return links, err
 }
 
+func (r *socialLinkRepository) GetActive() ([]model.SocialLink, error) {
+	var links []model.SocialLink
+	err := r.db.Where("is_active = ?", true).Order("display_order ASC").Find(&links).Error
+	return links, err
+}
+
+// ============================
+// PROFILE REPOSITORY
+// ============================
+
+type ProfileRepository interface {
+	Get() (*model.Profile, error)
+	Create(profile *model.Profile) error
+	Update(profile *model.Profile) error
+	GetExperiencePeriods() ([]model.ExperiencePeriod, error)
+}
+
+type profileRepository struct {
+	db *gorm.DB
+}
+
+func NewProfileRepository(db *gorm.DB) ProfileRepository {
+	return &profileRepository{db: db}
+}
+
+func (r *profileRepository) Get() (*model.Profile, error) {
+	var profile model.Profile
+	err := r.db.First(&profile).Error
+	return &profile, err
+}
+
+func (r *profileRepository) Create(profile *model.Profile) error {
+	return r.db.Create(profile).Error
+}
+
+func (r *profileRepository) Update(profile *model.Profile) error {
+	return r.db.Save(profile).Error
+}
+
+func (r *profileRepository) GetExperiencePeriods() ([]model.ExperiencePeriod, error) {
+	var periods []model.ExperiencePeriod
+	err := r.db.Table("portfolio_experiences").
+		Select("start_date, end_date, current_job").
+		Where("start_date IS NOT NULL").
+		Scan(&periods).Error
+	return periods, err
+}
+
 // ============================
 // SETTINGS REPOSITORY
 // ============================
 
 type SettingRepository interface {
 	Create(setting *model.Setting) error
+	GetByID(id uuid.UUID) (*model.Setting, error)
+	GetByKey(key string) (*model.Setting, error)
+	Update(setting *model.Setting) error
 	Delete(id uuid.UUID) error
 	GetAll() ([]model.Setting, error)
+	GetPublic() ([]model.Setting, error)
 }
 
 type settingRepository struct {
@@ -494,6 +1512,22 @@ func (r *settingRepository) Create(setting *model.Setting) error {
 	return r.db.Create(setting).Error
 }
 
+func (r *settingRepository) GetByID(id uuid.UUID) (*model.Setting, error) {
+	var setting model.Setting
+	err := r.db.Where("id = ?", id).First(&setting).Error
+	return &setting, err
+}
+
+func (r *settingRepository) GetByKey(key string) (*model.Setting, error) {
+	var setting model.Setting
+	err := r.db.Where("key = ?", key).First(&setting).Error
+	return &setting, err
+}
+
+func (r *settingRepository) Update(setting *model.Setting) error {
+	return r.db.Save(setting).Error
+}
+
 func (r *settingRepository) Delete(id uuid.UUID) error {
 	return r.db.Where("id = ?", id).Delete(&model.Setting{}).Error
 }
@@ -503,3 +1537,9 @@ func (r *settingRepository) GetAll() ([]model.Setting, error) {
 	err := r.db.Order("key ASC").Find(&settings).Error
 	return settings, err
 }
+
+func (r *settingRepository) GetPublic() ([]model.Setting, error) {
+	var settings []model.Setting
+	err := r.db.Where("is_public = ?", true).Order("key ASC").Find(&settings).Error
+	return settings, err
+}
@@ -1,20 +1,32 @@
 package service
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	model "gintugas/modules/components/all/models"
 	"gintugas/modules/components/all/repo"
 	"gintugas/modules/utils"
+	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
 // ============================
@@ -23,6 +35,7 @@ import (
 
 type UploadServiceWrapper interface {
 	UploadFile(file *multipart.FileHeader, folder string) (string, error)
+	UploadBytes(data []byte, filename, folder string) (string, error)
 	DeleteFile(fileURL string) error
 	ValidateFile(file *multipart.FileHeader, maxSizeMB int64, allowedExts []string) error
 }
@@ -40,6 +53,10 @@ func (s *SupabaseUploadWrapper) UploadFile(file *multipart.FileHeader, folder st
 	return s.service.UploadFile(file, folder)
 }
 
+func (s *SupabaseUploadWrapper) UploadBytes(data []byte, filename, folder string) (string, error) {
+	return s.service.UploadBytes(data, filename, folder)
+}
+
 func (s *SupabaseUploadWrapper) DeleteFile(fileURL string) error {
 	return s.service.DeleteFile(fileURL)
 }
@@ -79,6 +96,10 @@ func (l *LocalUploadWrapper) UploadFile(file *multipart.FileHeader, folder strin
 	return l.service.UploadFile(file, folder)
 }
 
+func (l *LocalUploadWrapper) UploadBytes(data []byte, filename, folder string) (string, error) {
+	return l.service.UploadBytes(data, filename, folder)
+}
+
 func (l *LocalUploadWrapper) DeleteFile(fileURL string) error {
 	return l.service.DeleteFile(fileURL)
 }
@@ -119,6 +140,8 @@ type SkillService interface {
 	GetAll(ctx *gin.Context) ([]model.SkillResponse, error)
 	GetFeatured(ctx *gin.Context) ([]model.SkillResponse, error)
 	GetByCategory(ctx *gin.Context) ([]model.SkillResponse, error)
+	GetUsage(ctx *gin.Context) (*model.SkillUsageResponse, error)
+	GetProficiency(ctx *gin.Context) ([]model.SkillProficiencyResponse, error)
 }
 
 type skillService struct {
@@ -208,14 +231,19 @@ func (s *skillService) Create(ctx *gin.Context) (*model.SkillResponse, error) {
 		return nil, err
 	}
 
+	category, err := s.resolveCategory(req.CategoryID, req.Category)
+	if err != nil {
+		return nil, err
+	}
+
 	skill := &model.Skill{
 		Name:         req.Name,
 		Value:        req.Value,
 		IconURL:      req.IconURL,
-		Category:     req.Category,
 		DisplayOrder: req.DisplayOrder,
 		IsFeatured:   req.IsFeatured,
 	}
+	setSkillCategory(skill, category)
 
 	if err := s.repo.Create(skill); err != nil {
 		return nil, err
@@ -241,6 +269,11 @@ func (s *skillService) CreateWithIcon(ctx *gin.Context) (*model.SkillResponse, e
 		return nil, errors.New("nilai skill harus antara 0-100")
 	}
 
+	category, err := s.resolveCategoryRef(form.CategoryID, form.Category)
+	if err != nil {
+		return nil, err
+	}
+
 	// Handle file upload
 	file, err := ctx.FormFile("icon")
 	if err != nil && err != http.ErrMissingFile {
@@ -264,9 +297,6 @@ func (s *skillService) CreateWithIcon(ctx *gin.Context) (*model.SkillResponse, e
 	}
 
 	// Set default values
-	if form.Category == "" {
-		form.Category = "programming"
-	}
 	if form.DisplayOrder == 0 {
 		form.DisplayOrder = 0
 	}
@@ -276,10 +306,10 @@ func (s *skillService) CreateWithIcon(ctx *gin.Context) (*model.SkillResponse, e
 		Name:         form.Name,
 		Value:        form.Value,
 		IconURL:      iconURL,
-		Category:     form.Category,
 		DisplayOrder: form.DisplayOrder,
 		IsFeatured:   form.IsFeatured,
 	}
+	setSkillCategory(skill, category)
 
 	// Save to database
 	if err := s.repo.Create(skill); err != nil {
@@ -329,8 +359,13 @@ func (s *skillService) Update(ctx *gin.Context) (*model.SkillResponse, error) {
 	if req.Value != 0 {
 		existing.Value = req.Value
 	}
+	category, err := s.resolveCategory(req.CategoryID, req.Category)
+	if err != nil {
+		return nil, err
+	}
+
 	existing.IconURL = req.IconURL
-	existing.Category = req.Category
+	setSkillCategory(existing, category)
 	existing.DisplayOrder = req.DisplayOrder
 	existing.IsFeatured = req.IsFeatured
 	existing.UpdatedAt = time.Now()
@@ -358,6 +393,15 @@ func (s *skillService) UpdateWithIcon(ctx *gin.Context) (*model.SkillResponse, e
 		return nil, fmt.Errorf("gagal binding data: %v", err)
 	}
 
+	// Kategori hanya diganti jika dikirim, dicek sebelum upload agar tidak ada file yatim
+	if form.CategoryID != "" || form.Category != "" {
+		category, err := s.resolveCategoryRef(form.CategoryID, form.Category)
+		if err != nil {
+			return nil, err
+		}
+		setSkillCategory(existing, category)
+	}
+
 	// Handle file upload
 	file, err := ctx.FormFile("icon")
 	if err != nil && err != http.ErrMissingFile {
@@ -394,9 +438,6 @@ func (s *skillService) UpdateWithIcon(ctx *gin.Context) (*model.SkillResponse, e
 	if form.Value != 0 {
 		existing.Value = form.Value
 	}
-	if form.Category != "" {
-		existing.Category = form.Category
-	}
 	existing.DisplayOrder = form.DisplayOrder
 	existing.IsFeatured = form.IsFeatured
 	existing.UpdatedAt = time.Now()
@@ -435,6 +476,126 @@ func (s *skillService) Delete(ctx *gin.Context) error {
 	return s.repo.Delete(id)
 }
 
+var ErrInvalidSkillID = errors.New("invalid skill ID")
+
+// GetUsage mengembalikan experience dan projek yang memakai skill. Projek draft hanya terlihat oleh admin.
+func (s *skillService) GetUsage(ctx *gin.Context) (*model.SkillUsageResponse, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, ErrInvalidSkillID
+	}
+
+	skill, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	experiences, err := s.repo.GetExperienceUsage(id)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := s.repo.GetProjectUsage(id, ctx.GetString("user_role") != "admin")
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SkillUsageResponse{
+		Skill:       *s.convertSkillToResponse(skill),
+		Experiences: experiences,
+		Projects:    projects,
+	}, nil
+}
+
+// GetProficiency menurunkan level setiap skill dari jumlah experience/projek yang memakainya
+// dan total lama pemakaian di experience (periode tumpang tindih dihitung sekali)
+func (s *skillService) GetProficiency(ctx *gin.Context) ([]model.SkillProficiencyResponse, error) {
+	skills, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.repo.GetUsageCounts()
+	if err != nil {
+		return nil, err
+	}
+	countBySkill := make(map[uuid.UUID]model.SkillUsageCount, len(counts))
+	for _, count := range counts {
+		countBySkill[count.SkillID] = count
+	}
+
+	periods, err := s.repo.GetUsagePeriods()
+	if err != nil {
+		return nil, err
+	}
+	rangesBySkill := make(map[uuid.UUID][]utils.MonthRange)
+	for _, period := range periods {
+		rangesBySkill[period.SkillID] = append(rangesBySkill[period.SkillID], utils.NewMonthRange(period.StartDate, period.EndDate, period.CurrentJob))
+	}
+
+	responses := make([]model.SkillProficiencyResponse, 0, len(skills))
+	for _, skill := range skills {
+		count := countBySkill[skill.ID]
+		ranges := rangesBySkill[skill.ID]
+		months := utils.TotalMonths(ranges)
+
+		response := model.SkillProficiencyResponse{
+			ID:              skill.ID,
+			Name:            skill.Name,
+			Category:        skillCategoryLabel(&skill),
+			IconURL:         skill.IconURL,
+			Value:           skill.Value,
+			ExperienceCount: count.ExperienceCount,
+			ProjectCount:    count.ProjectCount,
+			UsageCount:      count.ExperienceCount + count.ProjectCount,
+			MonthsUsed:      months,
+			Duration:        utils.FormatMonthDuration(months),
+		}
+
+		// End nil tanpa Current berarti tanggal selesai tidak diketahui, tidak dihitung sebagai masih dipakai
+		var lastUsed *time.Time
+		for _, r := range ranges {
+			switch {
+			case r.Current:
+				response.CurrentlyUsed = true
+			case r.End != nil && (lastUsed == nil || r.End.After(*lastUsed)):
+				lastUsed = r.End
+			}
+		}
+		if !response.CurrentlyUsed {
+			response.LastUsed = utils.FormatMonth(lastUsed)
+		}
+
+		response.Level = skillLevel(response.UsageCount, months)
+		responses = append(responses, response)
+	}
+
+	sort.SliceStable(responses, func(i, j int) bool {
+		if responses[i].UsageCount != responses[j].UsageCount {
+			return responses[i].UsageCount > responses[j].UsageCount
+		}
+		return responses[i].MonthsUsed > responses[j].MonthsUsed
+	})
+
+	return responses, nil
+}
+
+// skillLevel: level naik jika salah satu syarat (jumlah pemakaian atau lama bulan) terpenuhi
+func skillLevel(usage, months int) string {
+	switch {
+	case usage == 0 && months == 0:
+		return model.SkillLevelNone
+	case usage >= 10 || months >= 60:
+		return model.SkillLevelExpert
+	case usage >= 6 || months >= 36:
+		return model.SkillLevelAdvanced
+	case usage >= 3 || months >= 12:
+		return model.SkillLevelIntermediate
+	default:
+		return model.SkillLevelBeginner
+	}
+}
+
 func (s *skillService) GetAll(ctx *gin.Context) ([]model.SkillResponse, error) {
 	skills, err := s.repo.GetAll()
 	if err != nil {
@@ -479,17 +640,325 @@ func (s *skillService) GetByCategory(ctx *gin.Context) ([]model.SkillResponse, e
 }
 
 func (s *skillService) convertSkillToResponse(skill *model.Skill) *model.SkillResponse {
-	return &model.SkillResponse{
+	return toSkillResponse(skill)
+}
+
+func toSkillResponse(skill *model.Skill) *model.SkillResponse {
+	response := &model.SkillResponse{
 		ID:           skill.ID,
 		Name:         skill.Name,
 		Value:        skill.Value,
 		IconURL:      skill.IconURL,
-		Category:     skill.Category,
+		CategoryID:   skill.CategoryID,
 		DisplayOrder: skill.DisplayOrder,
 		IsFeatured:   skill.IsFeatured,
 		CreatedAt:    skill.CreatedAt,
 		UpdatedAt:    skill.UpdatedAt,
 	}
+	if skill.Category != nil {
+		response.Category = skill.Category.Label
+		response.CategorySlug = skill.Category.Slug
+	}
+	return response
+}
+
+var ErrUnknownSkillCategory = errors.New("unknown skill category")
+
+// resolveCategory mengutamakan category_id, lalu category (slug atau label). Keduanya kosong berarti tanpa kategori.
+// category_id harus sudah ada; category berupa teks yang belum ada dibuat sebagai kategori baru seperti teks bebas dulu.
+func (s *skillService) resolveCategory(categoryID *uuid.UUID, category string) (*model.SkillCategory, error) {
+	if categoryID != nil {
+		return s.resolveCategoryRef(categoryID.String(), "")
+	}
+	return s.resolveCategoryRef("", category)
+}
+
+func (s *skillService) resolveCategoryRef(categoryID, category string) (*model.SkillCategory, error) {
+	ref := strings.TrimSpace(categoryID)
+	if ref == "" {
+		ref = strings.TrimSpace(category)
+	}
+	if ref == "" {
+		return nil, nil
+	}
+
+	found, err := s.repo.FindCategory(ref)
+	if errors.Is(err, gorm.ErrRecordNotFound) && strings.TrimSpace(categoryID) == "" {
+		if slug := utils.Slugify(ref); slug != "" {
+			return s.repo.FindOrCreateCategory(ref, slug)
+		}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSkillCategory, ref)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+func skillCategoryLabel(skill *model.Skill) string {
+	if skill.Category == nil {
+		return ""
+	}
+	return skill.Category.Label
+}
+
+func setSkillCategory(skill *model.Skill, category *model.SkillCategory) {
+	skill.Category = category
+	skill.CategoryID = nil
+	if category != nil {
+		skill.CategoryID = &category.ID
+	}
+}
+
+// ============================
+// SKILL CATEGORIES SERVICE
+// ============================
+
+type SkillCategoryService interface {
+	Create(ctx *gin.Context) (*model.SkillCategoryResponse, error)
+	GetByID(ctx *gin.Context) (*model.SkillCategoryResponse, error)
+	Update(ctx *gin.Context) (*model.SkillCategoryResponse, error)
+	Delete(ctx *gin.Context) error
+	GetAll(ctx *gin.Context) ([]model.SkillCategoryResponse, error)
+	GetGrouped(ctx *gin.Context) ([]model.SkillCategoryGroup, error)
+}
+
+var ErrSkillCategorySlugTaken = errors.New("skill category slug already exists")
+
+type skillCategoryService struct {
+	repo      repo.SkillCategoryRepository
+	skillRepo repo.SkillRepository
+}
+
+func NewSkillCategoryService(repo repo.SkillCategoryRepository, skillRepo repo.SkillRepository) SkillCategoryService {
+	return &skillCategoryService{repo: repo, skillRepo: skillRepo}
+}
+
+func (s *skillCategoryService) Create(ctx *gin.Context) (*model.SkillCategoryResponse, error) {
+	var req model.SkillCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
+
+	label := strings.TrimSpace(req.Label)
+	if label == "" {
+		return nil, errors.New("category label is required")
+	}
+	slug, err := s.checkSlug(req.Slug, label, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	category := &model.SkillCategory{
+		Label:        label,
+		Slug:         slug,
+		Icon:         strings.TrimSpace(req.Icon),
+		Description:  strings.TrimSpace(req.Description),
+		DisplayOrder: req.DisplayOrder,
+	}
+	if err := s.repo.Create(category); err != nil {
+		return nil, err
+	}
+
+	return toSkillCategoryResponse(category, 0), nil
+}
+
+func (s *skillCategoryService) GetByID(ctx *gin.Context) (*model.SkillCategoryResponse, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, errors.New("invalid skill category ID")
+	}
+
+	category, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.skillCounts()
+	if err != nil {
+		return nil, err
+	}
+	return toSkillCategoryResponse(category, counts[category.ID]), nil
+}
+
+func (s *skillCategoryService) Update(ctx *gin.Context) (*model.SkillCategoryResponse, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, errors.New("invalid skill category ID")
+	}
+
+	category, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var req model.SkillCategoryUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
+
+	if label := strings.TrimSpace(req.Label); label != "" {
+		category.Label = label
+	}
+	// Slug tidak ikut berubah saat label diganti agar URL lama tetap valid
+	if req.Slug != "" {
+		slug, err := s.checkSlug(req.Slug, category.Label, category.ID)
+		if err != nil {
+			return nil, err
+		}
+		category.Slug = slug
+	}
+	if req.Icon != nil {
+		category.Icon = strings.TrimSpace(*req.Icon)
+	}
+	if req.Description != nil {
+		category.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.DisplayOrder != nil {
+		category.DisplayOrder = *req.DisplayOrder
+	}
+	category.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(category); err != nil {
+		return nil, err
+	}
+
+	counts, err := s.skillCounts()
+	if err != nil {
+		return nil, err
+	}
+	return toSkillCategoryResponse(category, counts[category.ID]), nil
+}
+
+func (s *skillCategoryService) Delete(ctx *gin.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return errors.New("invalid skill category ID")
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *skillCategoryService) GetAll(ctx *gin.Context) ([]model.SkillCategoryResponse, error) {
+	categories, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.skillCounts()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.SkillCategoryResponse, len(categories))
+	for i := range categories {
+		responses[i] = *toSkillCategoryResponse(&categories[i], counts[categories[i].ID])
+	}
+	return responses, nil
+}
+
+// GetGrouped mengembalikan kategori sesuai urutan beserta skill-nya (display_order, lalu nama).
+// Kategori kosong dilewati kecuali ?include_empty=true, skill tanpa kategori dikumpulkan di grup terakhir.
+func (s *skillCategoryService) GetGrouped(ctx *gin.Context) ([]model.SkillCategoryGroup, error) {
+	includeEmpty := ctx.Query("include_empty") == "true"
+	featuredOnly := ctx.Query("featured") == "true"
+
+	categories, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	skills, err := s.skillRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(skills, func(i, j int) bool {
+		if skills[i].DisplayOrder != skills[j].DisplayOrder {
+			return skills[i].DisplayOrder < skills[j].DisplayOrder
+		}
+		return strings.ToLower(skills[i].Name) < strings.ToLower(skills[j].Name)
+	})
+
+	skillsByCategory := make(map[uuid.UUID][]model.SkillResponse)
+	uncategorized := []model.SkillResponse{}
+	for i := range skills {
+		if featuredOnly && !skills[i].IsFeatured {
+			continue
+		}
+		response := *toSkillResponse(&skills[i])
+		if skills[i].CategoryID == nil {
+			uncategorized = append(uncategorized, response)
+			continue
+		}
+		skillsByCategory[*skills[i].CategoryID] = append(skillsByCategory[*skills[i].CategoryID], response)
+	}
+
+	groups := make([]model.SkillCategoryGroup, 0, len(categories)+1)
+	for i := range categories {
+		categorySkills := skillsByCategory[categories[i].ID]
+		if len(categorySkills) == 0 && !includeEmpty {
+			continue
+		}
+		if categorySkills == nil {
+			categorySkills = []model.SkillResponse{}
+		}
+		groups = append(groups, model.SkillCategoryGroup{
+			Category: toSkillCategoryResponse(&categories[i], len(categorySkills)),
+			Skills:   categorySkills,
+		})
+	}
+	if len(uncategorized) > 0 {
+		groups = append(groups, model.SkillCategoryGroup{Skills: uncategorized})
+	}
+
+	return groups, nil
+}
+
+// checkSlug membuat slug dari input (atau label jika kosong) dan memastikan belum dipakai kategori lain
+func (s *skillCategoryService) checkSlug(input, label string, excludeID uuid.UUID) (string, error) {
+	slug := utils.Slugify(input)
+	if slug == "" {
+		slug = utils.Slugify(label)
+	}
+	if slug == "" {
+		return "", errors.New("category slug is required")
+	}
+
+	exists, err := s.repo.SlugExists(slug, excludeID)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return "", ErrSkillCategorySlugTaken
+	}
+	return slug, nil
+}
+
+func (s *skillCategoryService) skillCounts() (map[uuid.UUID]int, error) {
+	counts, err := s.repo.CountSkills()
+	if err != nil {
+		return nil, err
+	}
+	byCategory := make(map[uuid.UUID]int, len(counts))
+	for _, count := range counts {
+		byCategory[count.CategoryID] = count.Total
+	}
+	return byCategory, nil
+}
+
+func toSkillCategoryResponse(category *model.SkillCategory, skillCount int) *model.SkillCategoryResponse {
+	return &model.SkillCategoryResponse{
+		ID:           category.ID,
+		Label:        category.Label,
+		Slug:         category.Slug,
+		Icon:         category.Icon,
+		Description:  category.Description,
+		DisplayOrder: category.DisplayOrder,
+		SkillCount:   skillCount,
+		CreatedAt:    category.CreatedAt,
+		UpdatedAt:    category.UpdatedAt,
+	}
 }
 
 // ============================
@@ -501,18 +970,39 @@ type CertificateService interface {
 	CreateWithImage(ctx *gin.Context) (*model.CertificateResponse, error)
 	GetByID(ctx *gin.Context) (*model.CertificateResponse, error)
 	Update(ctx *gin.Context) (*model.CertificateResponse, error)
+	UpdateWithImage(ctx *gin.Context) (*model.CertificateResponse, error)
 	Delete(ctx *gin.Context) error
 	GetAll(ctx *gin.Context) ([]model.CertificateResponse, error)
+	VerifyAll(ctx *gin.Context) error
+	Verify(ctx *gin.Context) (*model.CertificateResponse, error)
 }
 
+const (
+	// Sertifikat berstatus "expiring" jika kedaluwarsa dalam jumlah hari ini
+	certificateExpiringDays = 30
+	// Default interval pengecekan credential_url, bisa diubah lewat CERTIFICATE_VERIFY_INTERVAL
+	defaultCertificateVerifyInterval = 24 * time.Hour
+	certificateVerifyUserAgent       = "gintugas-credential-checker/1.0"
+)
+
+var (
+	ErrCertificateVerifyRunning = errors.New("verifikasi sertifikat sedang berjalan")
+	ErrNoCredentialURL          = errors.New("sertifikat tidak memiliki credential_url")
+	ErrCertificateNotFound      = errors.New("sertifikat tidak ditemukan")
+	ErrInvalidCertificate       = errors.New("data sertifikat tidak valid")
+)
+
 type certificateService struct {
 	repo          repo.CertificateRepository
 	uploadPath    string
 	uploadService UploadServiceWrapper
+	thumbnailer   utils.PDFThumbnailRenderer
+	httpClient    *http.Client
+	verifying     sync.Mutex
 }
 
 // NewCertificateService untuk local storage
-func NewCertificateService(repo repo.CertificateRepository, uploadPath string) CertificateService {
+func NewCertificateService(repo repo.CertificateRepository, uploadPath string, autoVerify bool) CertificateService {
 	if err := os.MkdirAll(uploadPath, 0755); err != nil {
 		fmt.Printf("⚠️ Warning: gagal membuat folder upload certificate: %v\n", err)
 	}
@@ -535,26 +1025,56 @@ func NewCertificateService(repo repo.CertificateRepository, uploadPath string) C
 		fmt.Println("ℹ️ Using Local Storage for certificates (development)")
 	}
 
-	return &certificateService{
-		repo:          repo,
-		uploadPath:    uploadPath,
-		uploadService: uploadService,
-	}
+	return newCertificateService(repo, uploadPath, uploadService, autoVerify)
 }
 
 // NewCertificateServiceWithUpload untuk custom upload service
-func NewCertificateServiceWithUpload(repo repo.CertificateRepository, uploadService UploadServiceWrapper, folder string) CertificateService {
+func NewCertificateServiceWithUpload(repo repo.CertificateRepository, uploadService UploadServiceWrapper, folder string, autoVerify bool) CertificateService {
 	uploadPath := getUploadPath()
 	localPath := filepath.Join(uploadPath, folder)
 	if err := os.MkdirAll(localPath, 0755); err != nil {
 		fmt.Printf("⚠️ Warning: gagal membuat folder upload: %v\n", err)
 	}
 
-	return &certificateService{
+	return newCertificateService(repo, localPath, uploadService, autoVerify)
+}
+
+// newCertificateService menjalankan verifikasi credential_url berkala sesuai CERTIFICATE_VERIFY_INTERVAL
+// (durasi Go, default 24h; "0" untuk mematikan verifikasi otomatis). autoVerify false (database tidak
+// terhubung) mematikan verifikasi berkala.
+func newCertificateService(repo repo.CertificateRepository, uploadPath string, uploadService UploadServiceWrapper, autoVerify bool) *certificateService {
+	s := &certificateService{
 		repo:          repo,
-		uploadPath:    localPath,
+		uploadPath:    uploadPath,
 		uploadService: uploadService,
+		thumbnailer:   utils.NewPDFThumbnailRendererFromEnv(),
+		httpClient:    utils.NewPublicHTTPClient(15 * time.Second),
+	}
+
+	interval := defaultCertificateVerifyInterval
+	if value := os.Getenv("CERTIFICATE_VERIFY_INTERVAL"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			fmt.Printf("⚠️ CERTIFICATE_VERIFY_INTERVAL tidak valid (%v), memakai default %s\n", err, interval)
+		} else {
+			interval = parsed
+		}
+	}
+
+	if autoVerify && interval > 0 {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for range ticker.C {
+				if !s.verifying.TryLock() {
+					continue
+				}
+				s.runVerifyAll()
+			}
+		}()
 	}
+
+	return s
 }
 
 func (s *certificateService) Create(ctx *gin.Context) (*model.CertificateResponse, error) {
@@ -566,12 +1086,18 @@ func (s *certificateService) Create(ctx *gin.Context) (*model.CertificateRespons
 	cert := &model.Certificate{
 		Name:          req.Name,
 		ImageURL:      req.ImageURL,
+		DocumentURL:   req.DocumentURL,
 		IssueDate:     req.IssueDate,
+		ExpiresAt:     req.ExpiresAt,
 		Issuer:        req.Issuer,
 		CredentialURL: req.CredentialURL,
 		DisplayOrder:  req.DisplayOrder,
 	}
 
+	if err := validateCertificateDates(cert); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.Create(cert); err != nil {
 		return nil, err
 	}
@@ -605,9 +1131,9 @@ func (s *certificateService) CreateWithImage(ctx *gin.Context) (*model.Certifica
 	}
 
 	// Upload ke Supabase atau Local storage
-	imageURL, err := s.uploadService.UploadFile(file, "certificates")
+	imageURL, documentURL, err := s.uploadCertificateFile(file)
 	if err != nil {
-		return nil, fmt.Errorf("gagal upload file: %v", err)
+		return nil, err
 	}
 	fmt.Printf("✅ Certificate image uploaded: %s\n", imageURL)
 
@@ -617,12 +1143,22 @@ func (s *certificateService) CreateWithImage(ctx *gin.Context) (*model.Certifica
 		parsedDate, err := time.Parse("2006-01-02", form.IssueDate)
 		if err != nil {
 			// Cleanup file jika parsing gagal
-			s.uploadService.DeleteFile(imageURL)
-			return nil, fmt.Errorf("format tanggal tidak valid, gunakan format YYYY-MM-DD: %v", err)
+			s.deleteCertificateFiles(imageURL, documentURL)
+			return nil, fmt.Errorf("%w: format tanggal tidak valid, gunakan format YYYY-MM-DD: %v", ErrInvalidCertificate, err)
 		}
 		issueDate = parsedDate
 	}
 
+	expiresAt, err := parseCertificateDate(form.ExpiresAt)
+	if err != nil {
+		s.deleteCertificateFiles(imageURL, documentURL)
+		return nil, err
+	}
+	if expiresAt != nil && !issueDate.IsZero() && expiresAt.Before(issueDate) {
+		s.deleteCertificateFiles(imageURL, documentURL)
+		return nil, errInvalidCertificateExpiry
+	}
+
 	// Set default values
 	if form.DisplayOrder == 0 {
 		form.DisplayOrder = 0
@@ -635,7 +1171,9 @@ func (s *certificateService) CreateWithImage(ctx *gin.Context) (*model.Certifica
 	cert := &model.Certificate{
 		Name:          form.Name,
 		ImageURL:      imageURL, // Full URL dari Supabase atau local
+		DocumentURL:   documentURL,
 		IssueDate:     issueDate,
+		ExpiresAt:     expiresAt,
 		Issuer:        form.Issuer,
 		CredentialURL: form.CredentialURL,
 		DisplayOrder:  form.DisplayOrder,
@@ -644,7 +1182,7 @@ func (s *certificateService) CreateWithImage(ctx *gin.Context) (*model.Certifica
 	// Save to database
 	if err := s.repo.Create(cert); err != nil {
 		// Cleanup file jika gagal save ke database
-		s.uploadService.DeleteFile(imageURL)
+		s.deleteCertificateFiles(imageURL, documentURL)
 		return nil, fmt.Errorf("gagal menyimpan data sertifikat: %v", err)
 	}
 
@@ -663,7 +1201,9 @@ func (s *certificateService) GetByID(ctx *gin.Context) (*model.CertificateRespon
 		return nil, err
 	}
 
-	return s.convertCertToResponse(cert), nil
+	response := s.convertCertToResponse(cert)
+	hideCertificateVerification(ctx, response)
+	return response, nil
 }
 
 func (s *certificateService) Update(ctx *gin.Context) (*model.CertificateResponse, error) {
@@ -691,9 +1231,17 @@ func (s *certificateService) Update(ctx *gin.Context) (*model.CertificateRespons
 	if updateData.ImageURL != "" {
 		existingCert.ImageURL = updateData.ImageURL
 	}
+	if updateData.DocumentURL != "" {
+		existingCert.DocumentURL = updateData.DocumentURL
+	}
 	if !updateData.IssueDate.IsZero() {
 		existingCert.IssueDate = updateData.IssueDate
 	}
+	if updateData.NoExpiry {
+		existingCert.ExpiresAt = nil
+	} else if updateData.ExpiresAt != nil {
+		existingCert.ExpiresAt = updateData.ExpiresAt
+	}
 	if updateData.Issuer != "" {
 		existingCert.Issuer = updateData.Issuer
 	}
@@ -702,6 +1250,10 @@ func (s *certificateService) Update(ctx *gin.Context) (*model.CertificateRespons
 	}
 	existingCert.DisplayOrder = updateData.DisplayOrder
 
+	if err := validateCertificateDates(existingCert); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.Update(existingCert); err != nil {
 		return nil, err
 	}
@@ -709,6 +1261,87 @@ func (s *certificateService) Update(ctx *gin.Context) (*model.CertificateRespons
 	return s.convertCertToResponse(existingCert), nil
 }
 
+func (s *certificateService) UpdateWithImage(ctx *gin.Context) (*model.CertificateResponse, error) {
+	existing, err := s.getCertificate(ctx.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var form model.CertificateUpdateForm
+	if err := ctx.ShouldBind(&form); err != nil {
+		return nil, fmt.Errorf("%w: gagal binding data: %v", ErrInvalidCertificate, err)
+	}
+
+	// Update fields lainnya, divalidasi sebelum upload agar tidak ada file yatim
+	if form.Name != "" {
+		existing.Name = form.Name
+	}
+	if form.IssueDate != "" {
+		issueDate, err := parseCertificateDate(form.IssueDate)
+		if err != nil {
+			return nil, err
+		}
+		existing.IssueDate = *issueDate
+	}
+	if form.NoExpiry {
+		existing.ExpiresAt = nil
+	} else if form.ExpiresAt != "" {
+		expiresAt, err := parseCertificateDate(form.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		existing.ExpiresAt = expiresAt
+	}
+	if form.Issuer != "" {
+		existing.Issuer = form.Issuer
+	}
+	if form.CredentialURL != "" {
+		existing.CredentialURL = form.CredentialURL
+	}
+	if form.DisplayOrder != nil {
+		existing.DisplayOrder = *form.DisplayOrder
+	}
+
+	if err := validateCertificateDates(existing); err != nil {
+		return nil, err
+	}
+
+	// Handle file upload
+	file, err := ctx.FormFile("image")
+	if err != nil && err != http.ErrMissingFile {
+		return nil, fmt.Errorf("gagal mengambil file gambar: %v", err)
+	}
+
+	oldImageURL, oldDocumentURL := existing.ImageURL, existing.DocumentURL
+	if file != nil {
+		allowedExts := []string{".jpg", ".jpeg", ".png", ".webp", ".pdf"}
+		if err := s.uploadService.ValidateFile(file, 10, allowedExts); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCertificate, err)
+		}
+
+		// Gambar baru menggantikan PDF lama sepenuhnya, document_url ikut dikosongkan
+		existing.ImageURL, existing.DocumentURL, err = s.uploadCertificateFile(file)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Update(existing); err != nil {
+		// Cleanup file baru jika gagal update
+		if file != nil {
+			s.deleteCertificateFiles(existing.ImageURL, existing.DocumentURL)
+		}
+		return nil, fmt.Errorf("gagal mengupdate data sertifikat: %v", err)
+	}
+
+	// File lama baru dihapus setelah data tersimpan
+	if file != nil {
+		s.deleteCertificateFiles(oldImageURL, oldDocumentURL)
+	}
+
+	return s.convertCertToResponse(existing), nil
+}
+
 func (s *certificateService) Delete(ctx *gin.Context) error {
 	idStr := ctx.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -728,44 +1361,325 @@ func (s *certificateService) Delete(ctx *gin.Context) error {
 		return fmt.Errorf("gagal menghapus sertifikat: %v", err)
 	}
 
-	// Hapus file image dari Supabase atau local storage jika ada
-	if cert.ImageURL != "" && cert.ImageURL != "#" {
-		if err := s.uploadService.DeleteFile(cert.ImageURL); err != nil {
-			fmt.Printf("⚠️ Warning: gagal menghapus file image: %v\n", err)
-			// Jangan return error karena data sudah terhapus dari DB
-		}
-	}
+	// Hapus file image (dan PDF asli) dari Supabase atau local storage jika ada.
+	// Error tidak di-return karena data sudah terhapus dari DB
+	s.deleteCertificateFiles(cert.ImageURL, cert.DocumentURL)
 
 	return nil
 }
 
+// GetAll mendukung filter ?status=valid|expiring|expired
 func (s *certificateService) GetAll(ctx *gin.Context) ([]model.CertificateResponse, error) {
+	status := strings.ToLower(strings.TrimSpace(ctx.Query("status")))
+	switch status {
+	case "", model.CertificateStatusValid, model.CertificateStatusExpiring, model.CertificateStatusExpired:
+	default:
+		return nil, fmt.Errorf("status tidak valid: %s", status)
+	}
+
 	certs, err := s.repo.GetAll()
 	if err != nil {
 		return nil, err
 	}
 
-	responses := make([]model.CertificateResponse, len(certs))
-	for i, cert := range certs {
-		responses[i] = *s.convertCertToResponse(&cert)
+	responses := make([]model.CertificateResponse, 0, len(certs))
+	for i := range certs {
+		response := s.convertCertToResponse(&certs[i])
+		if status != "" && response.Status != status {
+			continue
+		}
+		hideCertificateVerification(ctx, response)
+		responses = append(responses, *response)
 	}
 
 	return responses, nil
 }
 
+// VerifyAll menjalankan verifikasi semua credential_url di background karena tiap URL bisa
+// memakan waktu sampai timeout; hasilnya dicatat di log seperti verifikasi berkala
+func (s *certificateService) VerifyAll(ctx *gin.Context) error {
+	if !s.verifying.TryLock() {
+		return ErrCertificateVerifyRunning
+	}
+	go s.runVerifyAll()
+	return nil
+}
+
+func (s *certificateService) Verify(ctx *gin.Context) (*model.CertificateResponse, error) {
+	cert, err := s.getCertificate(ctx.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+	if !hasCredentialURL(cert.CredentialURL) {
+		return nil, ErrNoCredentialURL
+	}
+
+	if err := s.verifyCertificate(cert); err != nil {
+		return nil, err
+	}
+	return s.convertCertToResponse(cert), nil
+}
+
+// runVerifyAll dipanggil setelah lock verifying didapat dan melepaskannya setelah selesai
+func (s *certificateService) runVerifyAll() {
+	defer s.verifying.Unlock()
+
+	utils.RunSafely("certificate verification", func() {
+		summary, err := s.verifyAll()
+		if err != nil {
+			fmt.Printf("⚠️ Verifikasi sertifikat gagal: %v\n", err)
+			return
+		}
+		fmt.Printf("🔎 Verifikasi sertifikat: %d reachable, %d unreachable, %d skipped\n",
+			summary.Reachable, summary.Unreachable, summary.Skipped)
+	})
+}
+
+func (s *certificateService) verifyAll() (model.CertificateVerificationSummary, error) {
+	var summary model.CertificateVerificationSummary
+
+	certs, err := s.repo.GetAll()
+	if err != nil {
+		return summary, err
+	}
+
+	for i := range certs {
+		cert := &certs[i]
+		if !hasCredentialURL(cert.CredentialURL) {
+			summary.Skipped++
+			continue
+		}
+
+		if err := s.verifyCertificate(cert); err != nil {
+			return summary, err
+		}
+		summary.Checked++
+		if credentialVerificationStatus(cert) == model.CredentialReachable {
+			summary.Reachable++
+		} else {
+			summary.Unreachable++
+		}
+	}
+
+	return summary, nil
+}
+
+// verifyCertificate mengecek credential_url lalu menyimpan hasilnya. URL yang tidak bisa dihubungi
+// dicatat di verification_error, error hanya dikembalikan jika penyimpanan gagal.
+func (s *certificateService) verifyCertificate(cert *model.Certificate) error {
+	checkedAt := time.Now()
+	httpStatus, err := s.checkCredentialURL(cert.CredentialURL)
+
+	cert.LastHTTPStatus = nil
+	cert.VerificationError = ""
+	if err != nil {
+		cert.VerificationError = err.Error()
+	} else {
+		cert.LastHTTPStatus = &httpStatus
+	}
+	cert.LastCheckedAt = &checkedAt
+
+	return s.repo.UpdateVerification(cert.ID, cert.LastHTTPStatus, checkedAt, cert.VerificationError)
+}
+
+func (s *certificateService) checkCredentialURL(rawURL string) (int, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return 0, errors.New("credential_url tidak valid")
+	}
+
+	status, err := s.requestCredentialURL(http.MethodHead, parsed.String())
+	// Sebagian penerbit menolak HEAD, ulangi dengan GET
+	if err != nil || status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented || status == http.StatusForbidden {
+		return s.requestCredentialURL(http.MethodGet, parsed.String())
+	}
+	return status, nil
+}
+
+func (s *certificateService) requestCredentialURL(method, target string) (int, error) {
+	req, err := http.NewRequest(method, target, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", certificateVerifyUserAgent)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return resp.StatusCode, nil
+}
+
+// uploadCertificateFile menyimpan gambar apa adanya. PDF disimpan sebagai dokumen asli
+// dan image_url diisi thumbnail halaman pertama agar frontend tetap bisa menampilkannya.
+func (s *certificateService) uploadCertificateFile(file *multipart.FileHeader) (imageURL, documentURL string, err error) {
+	if !strings.EqualFold(filepath.Ext(file.Filename), ".pdf") {
+		imageURL, err = s.uploadService.UploadFile(file, "certificates")
+		if err != nil {
+			return "", "", fmt.Errorf("gagal upload file: %v", err)
+		}
+		return imageURL, "", nil
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return "", "", fmt.Errorf("gagal membuka file: %v", err)
+	}
+	data, err := io.ReadAll(src)
+	src.Close()
+	if err != nil {
+		return "", "", fmt.Errorf("gagal membaca file: %v", err)
+	}
+	if !utils.IsPDF(data) {
+		return "", "", fmt.Errorf("%w: file PDF tidak valid", ErrInvalidCertificate)
+	}
+
+	thumbnail, err := utils.GeneratePDFThumbnail(s.thumbnailer, data, utils.DefaultThumbnailWidth)
+	if err != nil {
+		return "", "", fmt.Errorf("gagal membuat thumbnail PDF: %v", err)
+	}
+
+	documentURL, err = s.uploadService.UploadFile(file, "certificates")
+	if err != nil {
+		return "", "", fmt.Errorf("gagal upload file: %v", err)
+	}
+
+	imageURL, err = s.uploadService.UploadBytes(thumbnail, "thumbnail.jpg", "certificates/thumbnails")
+	if err != nil {
+		s.uploadService.DeleteFile(documentURL)
+		return "", "", fmt.Errorf("gagal upload thumbnail: %v", err)
+	}
+
+	return imageURL, documentURL, nil
+}
+
+func (s *certificateService) deleteCertificateFiles(imageURL, documentURL string) {
+	// Sertifikat PDF lama hasil migrasi memakai URL yang sama di kedua kolom
+	if documentURL == imageURL {
+		documentURL = ""
+	}
+	for _, fileURL := range []string{imageURL, documentURL} {
+		if fileURL == "" || fileURL == "#" {
+			continue
+		}
+		if err := s.uploadService.DeleteFile(fileURL); err != nil {
+			fmt.Printf("⚠️ Warning: gagal menghapus file sertifikat: %v\n", err)
+		}
+	}
+}
+
 func (s *certificateService) convertCertToResponse(cert *model.Certificate) *model.CertificateResponse {
+	status, daysUntilExpiry := certificateExpiryStatus(cert.ExpiresAt, time.Now())
+
 	return &model.CertificateResponse{
-		ID:            cert.ID,
-		Name:          cert.Name,
-		ImageURL:      cert.ImageURL,
-		IssueDate:     cert.IssueDate,
-		Issuer:        cert.Issuer,
-		CredentialURL: cert.CredentialURL,
-		DisplayOrder:  cert.DisplayOrder,
-		CreatedAt:     cert.CreatedAt,
+		ID:                 cert.ID,
+		Name:               cert.Name,
+		ImageURL:           cert.ImageURL,
+		DocumentURL:        cert.DocumentURL,
+		IsPDF:              cert.DocumentURL != "",
+		IssueDate:          cert.IssueDate,
+		ExpiresAt:          cert.ExpiresAt,
+		Status:             status,
+		DaysUntilExpiry:    daysUntilExpiry,
+		Issuer:             cert.Issuer,
+		CredentialURL:      cert.CredentialURL,
+		VerificationStatus: credentialVerificationStatus(cert),
+		LastHTTPStatus:     cert.LastHTTPStatus,
+		LastCheckedAt:      cert.LastCheckedAt,
+		VerificationError:  cert.VerificationError,
+		DisplayOrder:       cert.DisplayOrder,
+		CreatedAt:          cert.CreatedAt,
+	}
+}
+
+// hideCertificateVerification mengosongkan hasil verifikasi credential_url untuk selain admin,
+// karena status HTTP dan pesan error dari URL tujuan tidak untuk publik
+func hideCertificateVerification(ctx *gin.Context, response *model.CertificateResponse) {
+	if ctx.GetString("user_role") == "admin" {
+		return
+	}
+	response.VerificationStatus = ""
+	response.LastHTTPStatus = nil
+	response.LastCheckedAt = nil
+	response.VerificationError = ""
+}
+
+var errInvalidCertificateExpiry = fmt.Errorf("%w: tanggal kedaluwarsa tidak boleh sebelum tanggal terbit", ErrInvalidCertificate)
+
+// getCertificate membedakan ID tidak valid, sertifikat tidak ada, dan kegagalan database
+func (s *certificateService) getCertificate(param string) (*model.Certificate, error) {
+	id, err := uuid.Parse(param)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ID sertifikat tidak valid", ErrInvalidCertificate)
+	}
+
+	cert, err := s.repo.GetByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCertificateNotFound
+	}
+	return cert, err
+}
+
+// parseCertificateDate membaca tanggal YYYY-MM-DD dari form-data, string kosong berarti tidak diisi
+func parseCertificateDate(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: format tanggal tidak valid, gunakan format YYYY-MM-DD: %v", ErrInvalidCertificate, err)
+	}
+	return &parsed, nil
+}
+
+func validateCertificateDates(cert *model.Certificate) error {
+	if cert.ExpiresAt != nil && !cert.IssueDate.IsZero() && cert.ExpiresAt.Before(cert.IssueDate) {
+		return errInvalidCertificateExpiry
+	}
+	return nil
+}
+
+// certificateExpiryStatus menghitung status dan sisa hari (negatif jika sudah lewat) per tanggal kalender.
+// Sertifikat tanpa expires_at selalu valid.
+func certificateExpiryStatus(expiresAt *time.Time, now time.Time) (string, *int) {
+	if expiresAt == nil {
+		return model.CertificateStatusValid, nil
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	expiry := time.Date(expiresAt.Year(), expiresAt.Month(), expiresAt.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(expiry.Sub(today).Hours() / 24)
+
+	switch {
+	case days < 0:
+		return model.CertificateStatusExpired, &days
+	case days <= certificateExpiringDays:
+		return model.CertificateStatusExpiring, &days
+	default:
+		return model.CertificateStatusValid, &days
 	}
 }
 
+func credentialVerificationStatus(cert *model.Certificate) string {
+	switch {
+	case cert.LastCheckedAt == nil:
+		return model.CredentialUnchecked
+	case cert.LastHTTPStatus != nil && *cert.LastHTTPStatus >= 200 && *cert.LastHTTPStatus < 400:
+		return model.CredentialReachable
+	default:
+		return model.CredentialUnreachable
+	}
+}
+
+func hasCredentialURL(value string) bool {
+	value = strings.TrimSpace(value)
+	return value != "" && value != "#"
+}
+
 // ============================
 // EDUCATION SERVICE (no upload needed)
 // ============================
@@ -795,12 +1709,13 @@ func (s *educationService) CreateWithAchievements(ctx *gin.Context) (*model.Educ
 	edu := &model.Education{
 		School:       req.School,
 		Major:        req.Major,
-		StartYear:    req.StartYear,
-		EndYear:      req.EndYear,
 		Description:  req.Description,
 		Degree:       req.Degree,
 		DisplayOrder: req.DisplayOrder,
 	}
+	if err := applyEducationDates(edu, req.StartDate, req.StartYear, req.EndDate, req.EndYear); err != nil {
+		return nil, err
+	}
 
 	for _, achReq := range req.Achievements {
 		edu.Achievements = append(edu.Achievements, model.EducationAchievement{
@@ -852,8 +1767,9 @@ func (s *educationService) UpdateWithAchievements(ctx *gin.Context) (*model.Educ
 	if req.Major != "" {
 		existing.Major = req.Major
 	}
-	existing.StartYear = req.StartYear
-	existing.EndYear = req.EndYear
+	if err := applyEducationDates(existing, req.StartDate, req.StartYear, req.EndDate, req.EndYear); err != nil {
+		return nil, err
+	}
 	existing.Description = req.Description
 	existing.Degree = req.Degree
 	existing.DisplayOrder = req.DisplayOrder
@@ -889,6 +1805,13 @@ func (s *educationService) GetAllWithAchievements(ctx *gin.Context) ([]model.Edu
 		return nil, err
 	}
 
+	// ?sort=date: urut kronologis terbaru dulu, default tetap display_order
+	if ctx.Query("sort") == "date" {
+		sort.SliceStable(educations, func(i, j int) bool {
+			return utils.NewerFirst(educationRange(&educations[i]), educationRange(&educations[j]))
+		})
+	}
+
 	var responses []model.EducationResponse
 	for _, edu := range educations {
 		responses = append(responses, *convertEducationToResponse(&edu))
@@ -897,6 +1820,46 @@ func (s *educationService) GetAllWithAchievements(ctx *gin.Context) ([]model.Edu
 	return responses, nil
 }
 
+// applyEducationDates mengisi start/end date dari request. start_date/end_date (YYYY-MM) diutamakan,
+// start_year/end_year lama tetap diterima. Field kosong mempertahankan nilai yang sudah ada;
+// education baru tanpa end date dianggap masih berjalan.
+func applyEducationDates(edu *model.Education, startDate, startYear, endDate, endYear string) error {
+	if startDate != "" || startYear != "" {
+		start, label, err := utils.ParseMonthInput(startDate, startYear, false)
+		if err != nil {
+			return err
+		}
+		edu.StartDate = &start
+		edu.StartYear = label
+	}
+
+	if utils.IsOngoingMonthText(endDate) || utils.IsOngoingMonthText(endYear) {
+		edu.EndDate = nil
+		edu.EndYear = "Present"
+		edu.IsCurrent = true
+	} else if endDate != "" || endYear != "" {
+		end, label, err := utils.ParseMonthInput(endDate, endYear, true)
+		if err != nil {
+			return err
+		}
+		edu.EndDate = &end
+		edu.EndYear = label
+		edu.IsCurrent = false
+	} else if edu.ID == uuid.Nil && edu.StartDate != nil {
+		edu.IsCurrent = true
+	}
+
+	if edu.StartDate != nil {
+		return utils.ValidateMonthRange(*edu.StartDate, edu.EndDate)
+	}
+	return nil
+}
+
+// educationRange periode education; end_date kosong hanya berarti masih berjalan jika is_current
+func educationRange(edu *model.Education) utils.MonthRange {
+	return utils.NewMonthRange(edu.StartDate, edu.EndDate, edu.IsCurrent)
+}
+
 // ============================
 // TESTIMONIALS SERVICE (no upload needed)
 // ============================
@@ -1068,14 +2031,33 @@ type BlogService interface {
 	GetAllWithTags(ctx *gin.Context) ([]model.BlogPostResponse, error)
 	GetPublishedWithTags(ctx *gin.Context) ([]model.BlogPostResponse, error)
 	GetAllTags(ctx *gin.Context) ([]model.TagResponse, error)
+	GetRelated(ctx *gin.Context) ([]model.RelatedPostResponse, error)
+
+	// Tag management (admin)
+	GetTagUsage(ctx *gin.Context) ([]model.BlogTagUsage, error)
+	RenameTag(ctx *gin.Context) (*model.TagResponse, error)
+	MergeTags(ctx *gin.Context) (*model.TagResponse, error)
+	DeleteTag(ctx *gin.Context) error
+	DeleteUnusedTags(ctx *gin.Context) (int64, error)
 }
 
+var (
+	ErrTagNameTaken = errors.New("tag name already exists, merge the tags instead")
+	ErrTagInUse     = errors.New("tag is still used by blog posts, merge it into another tag instead")
+)
+
 type blogService struct {
-	repo repo.BlogRepository
+	repo         repo.BlogRepository
+	views        *BlogViewRecorder
+	relatedCache *utils.MemoryCache
 }
 
-func NewBlogService(repo repo.BlogRepository) BlogService {
-	return &blogService{repo: repo}
+func NewBlogService(repo repo.BlogRepository, views *BlogViewRecorder) BlogService {
+	return &blogService{
+		repo:         repo,
+		views:        views,
+		relatedCache: utils.NewMemoryCache(30 * time.Minute),
+	}
 }
 
 func (s *blogService) CreateWithTags(ctx *gin.Context) (*model.BlogPostResponse, error) {
@@ -1084,19 +2066,38 @@ func (s *blogService) CreateWithTags(ctx *gin.Context) (*model.BlogPostResponse,
 		return nil, err
 	}
 
+	slugSource := req.Slug
+	if slugSource == "" {
+		slugSource = req.Title
+	}
+	slug, err := utils.UniqueSlug(slugSource, "post", func(slug string) (bool, error) {
+		return s.repo.SlugExists(slug, uuid.Nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	post := &model.BlogPost{
 		Title:         req.Title,
 		Content:       req.Content,
 		Excerpt:       req.Excerpt,
-		Slug:          req.Slug,
+		Slug:          slug,
 		FeaturedImage: req.FeaturedImage,
 		PublishDate:   req.PublishDate,
 		Status:        req.Status,
+
+		CommentsEnabled: true,
 	}
 
 	if post.Status == "" {
 		post.Status = "draft"
 	}
+	if req.CommentsEnabled != nil {
+		post.CommentsEnabled = *req.CommentsEnabled
+	}
+	if req.CommentsCloseAfterDays != nil {
+		post.CommentsCloseAfterDays = *req.CommentsCloseAfterDays
+	}
 
 	for _, tagReq := range req.Tags {
 		post.Tags = append(post.Tags, model.BlogTag{Name: tagReq.Name})
@@ -1105,6 +2106,7 @@ func (s *blogService) CreateWithTags(ctx *gin.Context) (*model.BlogPostResponse,
 	if err := s.repo.CreateWithTags(post); err != nil {
 		return nil, err
 	}
+	s.relatedCache.Flush()
 
 	return convertBlogToResponse(post), nil
 }
@@ -1120,8 +2122,12 @@ func (s *blogService) GetByIDWithTags(ctx *gin.Context) (*model.BlogPostResponse
 		return nil, err
 	}
 
-	// Increment view count
-	_ = s.repo.IncrementViewCount(id)
+	// Draft hanya terlihat oleh admin atau pemegang preview token
+	if post.Status != "published" && !utils.CanViewDraft(ctx, model.PreviewEntityBlogPost, post.ID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	s.views.Track(ctx, post)
 
 	return convertBlogToResponse(post), nil
 }
@@ -1131,11 +2137,18 @@ func (s *blogService) GetBySlugWithTags(ctx *gin.Context) (*model.BlogPostRespon
 
 	post, err := s.repo.GetBySlugWithTags(slug)
 	if err != nil {
+		// Slug lama: arahkan ke slug terbaru
+		if currentSlug, historyErr := s.repo.GetCurrentSlug(slug); historyErr == nil {
+			return nil, &utils.SlugMovedError{Slug: currentSlug}
+		}
 		return nil, err
 	}
 
-	// Increment view count
-	_ = s.repo.IncrementViewCount(post.ID)
+	if post.Status != "published" && !utils.CanViewDraft(ctx, model.PreviewEntityBlogPost, post.ID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	s.views.Track(ctx, post)
 
 	return convertBlogToResponse(post), nil
 }
@@ -1161,8 +2174,14 @@ func (s *blogService) UpdateWithTags(ctx *gin.Context) (*model.BlogPostResponse,
 	}
 	existing.Content = req.Content
 	existing.Excerpt = req.Excerpt
-	if req.Slug != "" {
-		existing.Slug = req.Slug
+	if req.Slug != "" && utils.Slugify(req.Slug) != existing.Slug {
+		slug, err := utils.UniqueSlug(req.Slug, "post", func(slug string) (bool, error) {
+			return s.repo.SlugExists(slug, existing.ID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		existing.Slug = slug
 	}
 	existing.FeaturedImage = req.FeaturedImage
 	if !req.PublishDate.IsZero() {
@@ -1171,6 +2190,12 @@ func (s *blogService) UpdateWithTags(ctx *gin.Context) (*model.BlogPostResponse,
 	if req.Status != "" {
 		existing.Status = req.Status
 	}
+	if req.CommentsEnabled != nil {
+		existing.CommentsEnabled = *req.CommentsEnabled
+	}
+	if req.CommentsCloseAfterDays != nil {
+		existing.CommentsCloseAfterDays = *req.CommentsCloseAfterDays
+	}
 	existing.UpdatedAt = time.Now()
 
 	existing.Tags = nil
@@ -1181,6 +2206,7 @@ func (s *blogService) UpdateWithTags(ctx *gin.Context) (*model.BlogPostResponse,
 	if err := s.repo.UpdateWithTags(existing); err != nil {
 		return nil, err
 	}
+	s.relatedCache.Flush()
 
 	return convertBlogToResponse(existing), nil
 }
@@ -1191,7 +2217,12 @@ func (s *blogService) DeleteWithTags(ctx *gin.Context) error {
 		return errors.New("invalid post ID")
 	}
 
-	return s.repo.DeleteWithTags(id)
+	if err := s.repo.DeleteWithTags(id); err != nil {
+		return err
+	}
+	s.relatedCache.Flush()
+
+	return nil
 }
 
 func (s *blogService) GetAllWithTags(ctx *gin.Context) ([]model.BlogPostResponse, error) {
@@ -1202,6 +2233,10 @@ func (s *blogService) GetAllWithTags(ctx *gin.Context) ([]model.BlogPostResponse
 
 	var responses []model.BlogPostResponse
 	for _, post := range posts {
+		// Draft hanya ikut untuk admin atau pemegang preview token post tersebut
+		if post.Status != "published" && !utils.CanViewDraft(ctx, model.PreviewEntityBlogPost, post.ID) {
+			continue
+		}
 		responses = append(responses, *convertBlogToResponse(&post))
 	}
 
@@ -1240,137 +2275,2167 @@ func (s *blogService) GetAllTags(ctx *gin.Context) ([]model.TagResponse, error)
 	return responses, nil
 }
 
-// ============================
-// SECTIONS SERVICE (no upload needed)
-// ============================
+func (s *blogService) GetTagUsage(ctx *gin.Context) ([]model.BlogTagUsage, error) {
+	tags, err := s.repo.GetTagsWithUsage()
+	if err != nil {
+		return nil, err
+	}
 
-type SectionService interface {
-	Create(ctx *gin.Context) (*model.SectionResponse, error)
-	Delete(ctx *gin.Context) error
-	GetAll(ctx *gin.Context) ([]model.SectionResponse, error)
-}
+	if ctx.Query("unused") == "true" {
+		var unused []model.BlogTagUsage
+		for _, tag := range tags {
+			if tag.PostCount == 0 {
+				unused = append(unused, tag)
+			}
+		}
+		return unused, nil
+	}
 
-type sectionService struct {
-	repo repo.SectionRepository
+	return tags, nil
 }
 
-func NewSectionService(repo repo.SectionRepository) SectionService {
-	return &sectionService{repo: repo}
-}
+func (s *blogService) RenameTag(ctx *gin.Context) (*model.TagResponse, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, errors.New("invalid tag ID")
+	}
 
-func (s *sectionService) Create(ctx *gin.Context) (*model.SectionResponse, error) {
-	var req model.SectionRequest
+	var req model.TagRenameRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		return nil, err
 	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("tag name is required")
+	}
+
+	tag, err := s.repo.GetTagByID(id)
+	if err != nil {
+		return nil, err
+	}
 
-	section := &model.Section{
-		SectionID:    req.SectionID,
-		Label:        req.Label,
-		DisplayOrder: req.DisplayOrder,
-		IsActive:     req.IsActive,
+	// Mengubah huruf besar/kecil tag itu sendiri diperbolehkan
+	if other, err := s.repo.FindTagByName(name); err == nil && other.ID != tag.ID {
+		return nil, ErrTagNameTaken
 	}
 
-	if err := s.repo.Create(section); err != nil {
+	if err := s.repo.RenameTag(id, name); err != nil {
 		return nil, err
 	}
+	tag.Name = name
 
-	return convertSectionToResponse(section), nil
+	return &model.TagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		CreatedAt: tag.CreatedAt,
+	}, nil
 }
 
-func (s *sectionService) Delete(ctx *gin.Context) error {
-	id, err := uuid.Parse(ctx.Param("id"))
+// MergeTags menggabungkan tag pada source_ids ke tag :id
+func (s *blogService) MergeTags(ctx *gin.Context) (*model.TagResponse, error) {
+	targetID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		return errors.New("invalid section ID")
+		return nil, errors.New("invalid tag ID")
 	}
 
-	return s.repo.Delete(id)
-}
+	var req model.TagMergeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
 
-func (s *sectionService) GetAll(ctx *gin.Context) ([]model.SectionResponse, error) {
-	sections, err := s.repo.GetAll()
+	target, err := s.repo.GetTagByID(targetID)
 	if err != nil {
 		return nil, err
 	}
 
-	var responses []model.SectionResponse
+	var sourceIDs []uuid.UUID
+	for _, sourceID := range req.SourceIDs {
+		if sourceID == targetID {
+			continue
+		}
+		if _, err := s.repo.GetTagByID(sourceID); err != nil {
+			return nil, fmt.Errorf("tag %s not found", sourceID)
+		}
+		sourceIDs = append(sourceIDs, sourceID)
+	}
+	if len(sourceIDs) == 0 {
+		return nil, errors.New("source_ids must contain at least one tag other than the target")
+	}
+
+	if err := s.repo.MergeTags(targetID, sourceIDs); err != nil {
+		return nil, err
+	}
+	s.relatedCache.Flush()
+
+	return &model.TagResponse{
+		ID:        target.ID,
+		Name:      target.Name,
+		CreatedAt: target.CreatedAt,
+	}, nil
+}
+
+// DeleteTag hanya menghapus tag yang tidak dipakai post manapun
+func (s *blogService) DeleteTag(ctx *gin.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return errors.New("invalid tag ID")
+	}
+
+	if _, err := s.repo.GetTagByID(id); err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountTagPosts(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrTagInUse
+	}
+
+	return s.repo.DeleteTag(id)
+}
+
+func (s *blogService) DeleteUnusedTags(ctx *gin.Context) (int64, error) {
+	return s.repo.DeleteUnusedTags()
+}
+
+// GetRelated mengembalikan post published lain yang paling mirip: skor tag (bobot kelangkaan) lalu kemiripan teks
+func (s *blogService) GetRelated(ctx *gin.Context) ([]model.RelatedPostResponse, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, errors.New("invalid post ID")
+	}
+
+	limit := utils.ParseLimit(ctx.Query("limit"), 5, 20)
+
+	// Post sumber dicek dulu (juga saat cache hit) agar draft tidak bisa diintip lewat related
+	source, err := s.repo.GetByIDWithTags(id)
+	if err != nil {
+		return nil, err
+	}
+	if source.Status != "published" && !utils.CanViewDraft(ctx, model.PreviewEntityBlogPost, source.ID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if cached, ok := s.relatedCache.Get(id.String()); ok {
+		related := cached.([]model.RelatedPostResponse)
+		if len(related) > limit {
+			related = related[:limit]
+		}
+		return related, nil
+	}
+
+	scores, err := s.repo.GetRelatedScores(id)
+	if err != nil {
+		return nil, err
+	}
+
+	scoreByPost := make(map[uuid.UUID]model.BlogRelatedScore)
+	for _, score := range scores {
+		scoreByPost[score.PostID] = score
+	}
+
+	candidates, err := s.repo.GetPublishedWithTags()
+	if err != nil {
+		return nil, err
+	}
+
+	sourceText := source.Title + " " + source.Excerpt
+	related := []model.RelatedPostResponse{}
+	for _, post := range candidates {
+		if post.ID == source.ID {
+			continue
+		}
+
+		score := scoreByPost[post.ID]
+		similarity := utils.TextSimilarity(sourceText, post.Title+" "+post.Excerpt)
+		if score.Score == 0 && similarity == 0 {
+			continue
+		}
+
+		response := convertBlogToResponse(&post)
+		related = append(related, model.RelatedPostResponse{
+			ID:            post.ID,
+			Title:         post.Title,
+			Excerpt:       post.Excerpt,
+			Slug:          post.Slug,
+			FeaturedImage: post.FeaturedImage,
+			PublishDate:   post.PublishDate,
+			Tags:          response.Tags,
+			SharedTags:    score.SharedTags,
+			Score:         score.Score,
+			Similarity:    similarity,
+		})
+	}
+
+	sort.SliceStable(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		if related[i].Similarity != related[j].Similarity {
+			return related[i].Similarity > related[j].Similarity
+		}
+		return related[i].PublishDate.After(related[j].PublishDate)
+	})
+
+	s.relatedCache.Set(id.String(), related)
+
+	if len(related) > limit {
+		related = related[:limit]
+	}
+	return related, nil
+}
+
+// ============================
+// BLOG COMMENTS SERVICE
+// ============================
+
+var (
+	ErrCommentsClosed        = errors.New("comments are closed for this post")
+	ErrCommentPostNotFound   = errors.New("post not found")
+	ErrCommentParentNotFound = errors.New("parent comment not found on this post")
+)
+
+// Komentar dengan link sebanyak ini atau lebih langsung ditandai spam
+const commentSpamLinkThreshold = 3
+
+type CommentService interface {
+	Create(ctx *gin.Context) (*model.CommentResponse, error)
+	GetByPost(ctx *gin.Context) ([]model.CommentResponse, error)
+	GetAll(ctx *gin.Context) ([]model.CommentResponse, error)
+	UpdateStatus(ctx *gin.Context) (*model.CommentResponse, error)
+	Delete(ctx *gin.Context) error
+}
+
+type commentService struct {
+	repo     repo.CommentRepository
+	blogRepo repo.BlogRepository
+}
+
+func NewCommentService(repo repo.CommentRepository, blogRepo repo.BlogRepository) CommentService {
+	return &commentService{
+		repo:     repo,
+		blogRepo: blogRepo,
+	}
+}
+
+func (s *commentService) Create(ctx *gin.Context) (*model.CommentResponse, error) {
+	postID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, errors.New("invalid post ID")
+	}
+
+	post, err := s.publishedPost(postID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.CommentsOpen(time.Now()) {
+		return nil, ErrCommentsClosed
+	}
+
+	var req model.CommentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
+
+	if req.ParentID != nil {
+		// Balasan hanya boleh ke komentar yang sudah tampil (approved)
+		parent, err := s.repo.GetByID(*req.ParentID)
+		if err != nil || parent.PostID != postID || parent.Status != "approved" {
+			return nil, ErrCommentParentNotFound
+		}
+	}
+
+	comment := &model.BlogComment{
+		PostID:      postID,
+		ParentID:    req.ParentID,
+		AuthorName:  strings.TrimSpace(req.AuthorName),
+		AuthorEmail: strings.TrimSpace(req.AuthorEmail),
+		Content:     strings.TrimSpace(req.Content),
+		Status:      "pending",
+		IPAddress:   ctx.ClientIP(),
+		UserAgent:   ctx.Request.UserAgent(),
+	}
+
+	if comment.Content == "" {
+		return nil, errors.New("comment content is required")
+	}
+	if strings.Count(strings.ToLower(comment.Content), "http") >= commentSpamLinkThreshold {
+		comment.Status = "spam"
+	}
+
+	if err := s.repo.Create(comment); err != nil {
+		return nil, err
+	}
+
+	return &model.CommentResponse{
+		ID:         comment.ID,
+		PostID:     comment.PostID,
+		ParentID:   comment.ParentID,
+		AuthorName: comment.AuthorName,
+		Content:    comment.Content,
+		Status:     "pending",
+		CreatedAt:  comment.CreatedAt,
+	}, nil
+}
+
+func (s *commentService) GetByPost(ctx *gin.Context) ([]model.CommentResponse, error) {
+	postID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, errors.New("invalid post ID")
+	}
+
+	post, err := s.blogRepo.GetByIDWithTags(postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentPostNotFound
+		}
+		return nil, err
+	}
+	if post.Status != "published" && !utils.CanViewDraft(ctx, model.PreviewEntityBlogPost, post.ID) {
+		return nil, ErrCommentPostNotFound
+	}
+
+	comments, err := s.repo.GetApprovedByPost(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCommentTree(comments), nil
+}
+
+// publishedPost memastikan komentar baru hanya masuk ke post yang sudah published
+func (s *commentService) publishedPost(postID uuid.UUID) (*model.BlogPost, error) {
+	post, err := s.blogRepo.GetByIDWithTags(postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentPostNotFound
+		}
+		return nil, err
+	}
+	if post.Status != "published" {
+		return nil, ErrCommentPostNotFound
+	}
+	return post, nil
+}
+
+func (s *commentService) GetAll(ctx *gin.Context) ([]model.CommentResponse, error) {
+	filter := model.CommentFilter{Status: ctx.Query("status")}
+	if postID := ctx.Query("post_id"); postID != "" {
+		id, err := uuid.Parse(postID)
+		if err != nil {
+			return nil, errors.New("invalid post ID")
+		}
+		filter.PostID = id
+	}
+
+	comments, err := s.repo.GetAll(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []model.CommentResponse{}
+	for _, comment := range comments {
+		responses = append(responses, *convertCommentToAdminResponse(&comment))
+	}
+
+	return responses, nil
+}
+
+func (s *commentService) UpdateStatus(ctx *gin.Context) (*model.CommentResponse, error) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return nil, errors.New("invalid comment ID")
+	}
+
+	var req model.CommentStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, errors.New("comment not found")
+	}
+
+	if err := s.repo.UpdateStatus(id, req.Status); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertCommentToAdminResponse(comment), nil
+}
+
+func (s *commentService) Delete(ctx *gin.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return errors.New("invalid comment ID")
+	}
+
+	return s.repo.Delete(id)
+}
+
+// ============================
+// BLOG ANALYTICS SERVICE
+// ============================
+
+const (
+	blogViewQueueSize     = 1000
+	blogViewPurgeInterval = time.Hour
+)
+
+// BlogViewRecorder mencatat view blog di goroutine terpisah agar request GET tidak menunggu write ke database.
+// Recorder nil (database tidak terhubung) aman dipakai: Track tidak melakukan apa-apa.
+type BlogViewRecorder struct {
+	repo  repo.BlogAnalyticsRepository
+	queue chan model.BlogView
+	salt  string
+}
+
+func NewBlogViewRecorder(repo repo.BlogAnalyticsRepository) *BlogViewRecorder {
+	salt := os.Getenv("BLOG_VIEW_HASH_SALT")
+	if salt == "" {
+		salt = "gintugas-blog-views"
+	}
+
+	r := &BlogViewRecorder{
+		repo:  repo,
+		queue: make(chan model.BlogView, blogViewQueueSize),
+		salt:  salt,
+	}
+	go r.run()
+	return r
+}
+
+// Track mengantrekan view untuk post published. Request dengan token login atau preview token,
+// prefetch browser, dan bot tidak dihitung.
+func (r *BlogViewRecorder) Track(ctx *gin.Context, post *model.BlogPost) {
+	if r == nil || post.Status != "published" {
+		return
+	}
+	if ctx.GetHeader("Authorization") != "" || utils.IsPreviewRequest(ctx) {
+		return
+	}
+	purpose := strings.ToLower(ctx.GetHeader("Sec-Purpose") + ctx.GetHeader("Purpose"))
+	if strings.Contains(purpose, "prefetch") {
+		return
+	}
+
+	userAgent := ctx.Request.UserAgent()
+	if utils.IsBotUserAgent(userAgent) {
+		return
+	}
+
+	now := time.Now().UTC()
+	view := model.BlogView{
+		PostID:      post.ID,
+		VisitorHash: r.visitorHash(ctx.ClientIP(), userAgent, now),
+		Referrer:    referrerHost(ctx),
+		ViewedAt:    now,
+	}
+
+	select {
+	case r.queue <- view:
+	default:
+		// Antrian penuh: view dibuang daripada menahan request
+	}
+}
+
+func (r *BlogViewRecorder) run() {
+	ticker := time.NewTicker(blogViewPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case view := <-r.queue:
+			utils.RunSafely("blog view recorder", func() {
+				if _, err := r.repo.RecordView(view); err != nil {
+					fmt.Printf("⚠️ Failed to record blog view: %v\n", err)
+				}
+			})
+		case <-ticker.C:
+			// Hash kemarin masih disimpan agar pergantian hari UTC tidak menghitung ulang pengunjung yang sama
+			utils.RunSafely("blog view purge", func() {
+				if err := r.repo.PurgeVisitorsBefore(time.Now().UTC().AddDate(0, 0, -1)); err != nil {
+					fmt.Printf("⚠️ Failed to purge blog view visitors: %v\n", err)
+				}
+			})
+		}
+	}
+}
+
+// visitorHash menggabungkan IP, User-Agent, dan tanggal sehingga pengunjung tidak bisa dilacak lintas hari
+func (r *BlogViewRecorder) visitorHash(ip, userAgent string, now time.Time) string {
+	sum := sha256.Sum256([]byte(r.salt + "|" + now.Format("2006-01-02") + "|" + ip + "|" + userAgent))
+	return hex.EncodeToString(sum[:])
+}
+
+// referrerHost mengambil host referrer dari query ?ref= (dikirim frontend) atau header Referer.
+// Referrer dari host yang sama diabaikan.
+func referrerHost(ctx *gin.Context) string {
+	raw := ctx.Query("ref")
+	if raw == "" {
+		raw = ctx.Request.Referer()
+	}
+	if raw == "" {
+		return ""
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Hostname() == "" {
+		return ""
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	requestHost := strings.TrimPrefix(strings.ToLower(ctx.Request.Host), "www.")
+	if i := strings.Index(requestHost, ":"); i >= 0 {
+		requestHost = requestHost[:i]
+	}
+	if host == requestHost || len(host) > 255 {
+		return ""
+	}
+	return host
+}
+
+type BlogAnalyticsService interface {
+	GetViewsOverTime(ctx *gin.Context) ([]model.ViewsPointResponse, error)
+	GetTopPosts(ctx *gin.Context) ([]model.TopPostResponse, error)
+	GetTopReferrers(ctx *gin.Context) ([]model.ReferrerResponse, error)
+}
+
+type blogAnalyticsService struct {
+	repo repo.BlogAnalyticsRepository
+}
+
+func NewBlogAnalyticsService(repo repo.BlogAnalyticsRepository) BlogAnalyticsService {
+	return &blogAnalyticsService{repo: repo}
+}
+
+// parseAnalyticsFilter membaca query ?days= (default 30, max 365), ?post_id=, dan ?limit= (default 10, max 100)
+func parseAnalyticsFilter(ctx *gin.Context) (model.AnalyticsFilter, error) {
+	days := utils.ParseLimit(ctx.Query("days"), 30, 365)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	filter := model.AnalyticsFilter{
+		From:  today.AddDate(0, 0, -(days - 1)),
+		To:    today,
+		Limit: utils.ParseLimit(ctx.Query("limit"), 10, 100),
+	}
+
+	if postID := ctx.Query("post_id"); postID != "" {
+		id, err := uuid.Parse(postID)
+		if err != nil {
+			return filter, errors.New("invalid post ID")
+		}
+		filter.PostID = &id
+	}
+
+	return filter, nil
+}
+
+func (s *blogAnalyticsService) GetViewsOverTime(ctx *gin.Context) ([]model.ViewsPointResponse, error) {
+	filter, err := parseAnalyticsFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := s.repo.GetViewsOverTime(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Isi hari tanpa view dengan 0 agar grafik tidak bolong
+	views := make(map[string]int, len(points))
+	for _, point := range points {
+		views[point.Date] = point.Views
+	}
+
+	var responses []model.ViewsPointResponse
+	for day := filter.From; !day.After(filter.To); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		responses = append(responses, model.ViewsPointResponse{Date: date, Views: views[date]})
+	}
+
+	return responses, nil
+}
+
+func (s *blogAnalyticsService) GetTopPosts(ctx *gin.Context) ([]model.TopPostResponse, error) {
+	filter, err := parseAnalyticsFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTopPosts(filter)
+}
+
+func (s *blogAnalyticsService) GetTopReferrers(ctx *gin.Context) ([]model.ReferrerResponse, error) {
+	filter, err := parseAnalyticsFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTopReferrers(filter)
+}
+
+// ============================
+// PREVIEW LINKS SERVICE
+// ============================
+
+const defaultPreviewLinkHours = 72
+
+type PreviewLinkService interface {
+	Create(ctx *gin.Context) (*model.PreviewLinkResponse, error)
+	GetActive(ctx *gin.Context) ([]model.PreviewLinkResponse, error)
+	Revoke(ctx *gin.Context) error
+	Validate(token string) (*utils.PreviewAccess, error)
+}
+
+type previewLinkService struct {
+	repo repo.PreviewLinkRepository
+}
+
+func NewPreviewLinkService(repo repo.PreviewLinkRepository) PreviewLinkService {
+	return &previewLinkService{repo: repo}
+}
+
+func (s *previewLinkService) Create(ctx *gin.Context) (*model.PreviewLinkResponse, error) {
+	var req model.PreviewLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repo.EntityExists(req.EntityType, req.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s not found", req.EntityType)
+	}
+
+	hours := req.ExpiresInHours
+	if hours == 0 {
+		hours = defaultPreviewLinkHours
+	}
+
+	link := &model.PreviewLink{
+		ID:         uuid.New(),
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		// Dibulatkan ke detik karena signature memakai unix timestamp
+		ExpiresAt: time.Now().Add(time.Duration(hours) * time.Hour).Truncate(time.Second),
+	}
+	if userID, err := uuid.Parse(ctx.GetString("user_id")); err == nil {
+		link.CreatedBy = &userID
+	}
+
+	if err := s.repo.Create(link); err != nil {
+		return nil, err
+	}
+
+	return convertPreviewLinkToResponse(link), nil
+}
+
+func (s *previewLinkService) GetActive(ctx *gin.Context) ([]model.PreviewLinkResponse, error) {
+	filter := model.PreviewLinkFilter{EntityType: ctx.Query("entity_type")}
+	if entityID := ctx.Query("entity_id"); entityID != "" {
+		id, err := uuid.Parse(entityID)
+		if err != nil {
+			return nil, errors.New("invalid entity ID")
+		}
+		filter.EntityID = id
+	}
+
+	links, err := s.repo.GetActive(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []model.PreviewLinkResponse
+	for i := range links {
+		responses = append(responses, *convertPreviewLinkToResponse(&links[i]))
+	}
+
+	return responses, nil
+}
+
+func (s *previewLinkService) Revoke(ctx *gin.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return errors.New("invalid preview link ID")
+	}
+
+	if _, err := s.repo.GetByID(id); err != nil {
+		return err
+	}
+
+	return s.repo.Revoke(id)
+}
+
+// Validate memeriksa signature, masa berlaku, dan status revoke dari preview token
+func (s *previewLinkService) Validate(token string) (*utils.PreviewAccess, error) {
+	invalid := errors.New("invalid or expired preview token")
+
+	linkID, err := utils.PreviewTokenLinkID(token)
+	if err != nil {
+		return nil, invalid
+	}
+
+	link, err := s.repo.GetByID(linkID)
+	if err != nil {
+		return nil, invalid
+	}
+
+	if !utils.VerifyPreviewToken(token, link.ID, link.EntityType, link.EntityID, link.ExpiresAt) {
+		return nil, invalid
+	}
+	if link.RevokedAt != nil || time.Now().After(link.ExpiresAt) {
+		return nil, invalid
+	}
+
+	return &utils.PreviewAccess{
+		LinkID:     link.ID,
+		EntityType: link.EntityType,
+		EntityID:   link.EntityID,
+	}, nil
+}
+
+// ============================
+// DISPLAY ORDER SERVICE
+// ============================
+
+var (
+	ErrInvalidOrderList = errors.New("invalid order list")
+	ErrUnknownOrderID   = repo.ErrUnknownOrderID
+)
+
+type DisplayOrderService interface {
+	Reorder(ctx *gin.Context, entity string) ([]model.DisplayOrderItem, error)
+}
+
+type displayOrderService struct {
+	repo repo.DisplayOrderRepository
+}
+
+func NewDisplayOrderService(repo repo.DisplayOrderRepository) DisplayOrderService {
+	return &displayOrderService{repo: repo}
+}
+
+func (s *displayOrderService) Reorder(ctx *gin.Context, entity string) ([]model.DisplayOrderItem, error) {
+	var req model.ReorderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOrderList, err)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: duplicate id %s", ErrInvalidOrderList, id)
+		}
+		seen[id] = true
+	}
+
+	return s.repo.Reorder(entity, req.IDs)
+}
+
+// ============================
+// TIMELINE SERVICE
+// ============================
+
+var ErrInvalidTimelineFilter = errors.New("invalid timeline filter")
+
+var defaultTimelineTypes = []string{model.TimelineExperience, model.TimelineEducation, model.TimelineCertificate}
+
+type TimelineService interface {
+	GetTimeline(ctx *gin.Context) ([]model.TimelineEntry, error)
+}
+
+type timelineService struct {
+	repo repo.TimelineRepository
+}
+
+func NewTimelineService(repo repo.TimelineRepository) TimelineService {
+	return &timelineService{repo: repo}
+}
+
+// GetTimeline menggabungkan experience, education, certificate, dan (opsional) project published
+// menjadi satu feed. Query: type (bisa dipisah koma), include_projects, from/to (tahun), order.
+func (s *timelineService) GetTimeline(ctx *gin.Context) ([]model.TimelineEntry, error) {
+	filter, err := parseTimelineFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rangeStart := time.Date(filter.FromYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	rangeEnd := time.Date(filter.ToYear, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	entries := []model.TimelineEntry{}
+	var rows []model.TimelineRow
+	for _, entryType := range filter.Types {
+		typeRows, err := s.repo.GetRows(entryType)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, typeRows...)
+	}
+
+	// Urut berdasarkan tanggal mulai, terbaru dulu kecuali order=asc
+	sort.SliceStable(rows, func(i, j int) bool {
+		if filter.Asc {
+			return rows[i].StartDate.Before(rows[j].StartDate)
+		}
+		return rows[i].StartDate.After(rows[j].StartDate)
+	})
+
+	for _, row := range rows {
+		start := utils.StartOfMonth(row.StartDate)
+		end, ok := utils.MonthRange{Start: start, End: row.EndDate, Current: row.Current}.LastMonth()
+		if !ok {
+			end = start
+		}
+
+		// Entry diambil jika periodenya beririsan dengan rentang tahun
+		if filter.FromYear != 0 && end.Before(rangeStart) {
+			continue
+		}
+		if filter.ToYear != 0 && start.After(rangeEnd) {
+			continue
+		}
+
+		entries = append(entries, buildTimelineEntry(row, start))
+	}
+
+	return entries, nil
+}
+
+func buildTimelineEntry(row model.TimelineRow, start time.Time) model.TimelineEntry {
+	entry := model.TimelineEntry{
+		Type:      row.Type,
+		ID:        row.ID,
+		Title:     row.Title,
+		Subtitle:  row.Subtitle,
+		Slug:      row.Slug,
+		StartDate: start.Format(utils.MonthLayout),
+	}
+
+	// Certificate dan project adalah kejadian satu titik waktu, tidak punya durasi
+	if row.Type == model.TimelineCertificate || row.Type == model.TimelineProject {
+		entry.EndDate = utils.FormatMonth(&start)
+		entry.DateLabel = utils.MonthLabel(start)
+		return entry
+	}
+
+	// Tanggal selesai yang tidak diketahui (data lama tidak valid) tidak diberi label maupun durasi
+	period := utils.MonthRange{Start: start, End: row.EndDate, Current: row.Current}
+	switch {
+	case row.Current:
+		entry.Ongoing = true
+		entry.DateLabel = utils.MonthLabel(start) + " – Present"
+	case row.EndDate != nil:
+		end := utils.StartOfMonth(*row.EndDate)
+		entry.EndDate = utils.FormatMonth(&end)
+		entry.DateLabel = utils.MonthLabel(start) + " – " + utils.MonthLabel(end)
+	default:
+		entry.DateLabel = utils.MonthLabel(start)
+		return entry
+	}
+	months, _ := period.Months()
+	entry.Duration = utils.FormatMonthDuration(months)
+	return entry
+}
+
+func parseTimelineFilter(ctx *gin.Context) (model.TimelineFilter, error) {
+	filter := model.TimelineFilter{}
+
+	validTypes := map[string]bool{
+		model.TimelineExperience: true, model.TimelineEducation: true,
+		model.TimelineCertificate: true, model.TimelineProject: true,
+	}
+	seen := make(map[string]bool)
+	for _, value := range ctx.QueryArray("type") {
+		for _, entryType := range strings.Split(value, ",") {
+			entryType = strings.ToLower(strings.TrimSpace(entryType))
+			if entryType == "" || seen[entryType] {
+				continue
+			}
+			if !validTypes[entryType] {
+				return filter, fmt.Errorf("%w: type must be experience, education, certificate, or project", ErrInvalidTimelineFilter)
+			}
+			seen[entryType] = true
+			filter.Types = append(filter.Types, entryType)
+		}
+	}
+	if len(filter.Types) == 0 {
+		filter.Types = append(filter.Types, defaultTimelineTypes...)
+		if ctx.Query("include_projects") == "true" {
+			filter.Types = append(filter.Types, model.TimelineProject)
+		}
+	}
+
+	for param, target := range map[string]*int{"from": &filter.FromYear, "to": &filter.ToYear} {
+		if value := ctx.Query(param); value != "" {
+			year, err := strconv.Atoi(value)
+			if err != nil || year < 1900 || year > 9999 {
+				return filter, fmt.Errorf("%w: %s must be a year (YYYY)", ErrInvalidTimelineFilter, param)
+			}
+			*target = year
+		}
+	}
+	if filter.FromYear != 0 && filter.ToYear != 0 && filter.FromYear > filter.ToYear {
+		return filter, fmt.Errorf("%w: from must not be after to", ErrInvalidTimelineFilter)
+	}
+
+	switch strings.ToLower(ctx.Query("order")) {
+	case "", "desc":
+	case "asc":
+		filter.Asc = true
+	default:
+		return filter, fmt.Errorf("%w: order must be asc or desc", ErrInvalidTimelineFilter)
+	}
+
+	return filter, nil
+}
+
+// ErrNaturalKeyTaken dikembalikan saat section_id, platform, atau key sudah dipakai data lain
+var ErrNaturalKeyTaken = errors.New("key is already used by another record")
+
+// retryOnUniqueViolation mengulang upsert sekali jika insert kalah balapan dengan request lain yang
+// membuat natural key yang sama; percobaan kedua menemukan baris itu lalu meng-update-nya
+func retryOnUniqueViolation(upsert func() error) error {
+	err := upsert()
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		err = upsert()
+	}
+	return err
+}
+
+// isUUIDRef true jika parameter URL berupa UUID (bukan natural key)
+func isUUIDRef(ref string) bool {
+	_, err := uuid.Parse(ref)
+	return err == nil
+}
+
+// ============================
+// SECTIONS SERVICE (no upload needed)
+// ============================
+
+// Parameter :id pada endpoint section menerima UUID atau section_id
+type SectionService interface {
+	Create(ctx *gin.Context) (*model.SectionResponse, bool, error)
+	GetByID(ctx *gin.Context) (*model.SectionResponse, error)
+	Update(ctx *gin.Context) (*model.SectionResponse, bool, error)
+	Patch(ctx *gin.Context) (*model.SectionResponse, error)
+	Delete(ctx *gin.Context) error
+	GetAll(ctx *gin.Context) ([]model.SectionResponse, error)
+	GetActive(ctx *gin.Context) ([]model.SectionResponse, error)
+}
+
+type sectionService struct {
+	repo repo.SectionRepository
+}
+
+func NewSectionService(repo repo.SectionRepository) SectionService {
+	return &sectionService{repo: repo}
+}
+
+// Create melakukan upsert berdasarkan section_id, bool kedua true jika data baru dibuat
+func (s *sectionService) Create(ctx *gin.Context) (*model.SectionResponse, bool, error) {
+	var req model.SectionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, false, err
+	}
+
+	sectionID := strings.TrimSpace(req.SectionID)
+	if sectionID == "" {
+		return nil, false, errors.New("section_id is required")
+	}
+	var section *model.Section
+	var created bool
+	err := retryOnUniqueViolation(func() error {
+		var err error
+		section, err = s.repo.GetBySectionID(sectionID)
+		created = errors.Is(err, gorm.ErrRecordNotFound)
+		if err != nil && !created {
+			return err
+		}
+		if created {
+			section = &model.Section{SectionID: sectionID}
+		}
+
+		section.Label = req.Label
+		section.DisplayOrder = req.DisplayOrder
+		section.IsActive = req.IsActive
+
+		return s.save(section, created)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return convertSectionToResponse(section), created, nil
+}
+
+func (s *sectionService) GetByID(ctx *gin.Context) (*model.SectionResponse, error) {
+	section, err := s.find(ctx.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+	return convertSectionToResponse(section), nil
+}
+
+// Update mengganti seluruh field. Jika dipanggil dengan section_id yang belum ada, section dibuat (upsert)
+func (s *sectionService) Update(ctx *gin.Context) (*model.SectionResponse, bool, error) {
+	ref := strings.TrimSpace(ctx.Param("id"))
+
+	var req model.SectionUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, false, err
+	}
+
+	var section *model.Section
+	var created bool
+	err := retryOnUniqueViolation(func() error {
+		var err error
+		section, err = s.find(ref)
+		created = errors.Is(err, gorm.ErrRecordNotFound) && !isUUIDRef(ref)
+		if err != nil && !created {
+			return err
+		}
+		if created {
+			section = &model.Section{SectionID: ref}
+		}
+
+		if err := s.rename(section, req.SectionID); err != nil {
+			return err
+		}
+		section.Label = req.Label
+		section.DisplayOrder = req.DisplayOrder
+		section.IsActive = req.IsActive
+
+		return s.save(section, created)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return convertSectionToResponse(section), created, nil
+}
+
+func (s *sectionService) Patch(ctx *gin.Context) (*model.SectionResponse, error) {
+	section, err := s.find(ctx.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req model.SectionPatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
+
+	if req.SectionID != nil {
+		if err := s.rename(section, *req.SectionID); err != nil {
+			return nil, err
+		}
+	}
+	if req.Label != nil {
+		if strings.TrimSpace(*req.Label) == "" {
+			return nil, errors.New("label cannot be empty")
+		}
+		section.Label = *req.Label
+	}
+	if req.DisplayOrder != nil {
+		section.DisplayOrder = *req.DisplayOrder
+	}
+	if req.IsActive != nil {
+		section.IsActive = *req.IsActive
+	}
+
+	if err := s.save(section, false); err != nil {
+		return nil, err
+	}
+	return convertSectionToResponse(section), nil
+}
+
+func (s *sectionService) Delete(ctx *gin.Context) error {
+	section, err := s.find(ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Delete(section.ID)
+}
+
+func (s *sectionService) GetAll(ctx *gin.Context) ([]model.SectionResponse, error) {
+	sections, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []model.SectionResponse
+	for _, section := range sections {
+		responses = append(responses, *convertSectionToResponse(&section))
+	}
+
+	return responses, nil
+}
+
+func (s *sectionService) GetActive(ctx *gin.Context) ([]model.SectionResponse, error) {
+	sections, err := s.repo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.SectionResponse, 0, len(sections))
+	for i := range sections {
+		responses = append(responses, *convertSectionToResponse(&sections[i]))
+	}
+
+	return responses, nil
+}
+
+func (s *sectionService) find(ref string) (*model.Section, error) {
+	return findSection(s.repo, ref)
+}
+
+// findSection mencari section berdasarkan UUID atau section_id
+func findSection(sectionRepo repo.SectionRepository, ref string) (*model.Section, error) {
+	ref = strings.TrimSpace(ref)
+	if id, err := uuid.Parse(ref); err == nil {
+		return sectionRepo.GetByID(id)
+	}
+	return sectionRepo.GetBySectionID(ref)
+}
+
+// rename mengganti section_id jika diisi dan belum dipakai section lain
+func (s *sectionService) rename(section *model.Section, sectionID string) error {
+	sectionID = strings.TrimSpace(sectionID)
+	if sectionID == "" || sectionID == section.SectionID {
+		return nil
+	}
+
+	other, err := s.repo.GetBySectionID(sectionID)
+	if err == nil && other.ID != section.ID {
+		return ErrNaturalKeyTaken
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	section.SectionID = sectionID
+	return nil
+}
+
+func (s *sectionService) save(section *model.Section, created bool) error {
+	if created {
+		return s.repo.Create(section)
+	}
+	section.UpdatedAt = time.Now()
+	return s.repo.Update(section)
+}
+
+// ============================
+// SECTION BLOCKS SERVICE
+// ============================
+
+var (
+	ErrInvalidBlockPayload = errors.New("invalid block payload")
+	ErrUnknownBlockType    = errors.New("unknown block type")
+)
+
+const defaultBlockListLimit = 6
+
+const uuidPattern = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+
+// Schema payload untuk setiap jenis block
+var blockPayloadSchemas = map[string]*utils.JSONSchema{
+	model.BlockTypeRichText: mustCompileSchema(`{
+		"type": "object",
+		"required": ["content"],
+		"additionalProperties": false,
+		"properties": {
+			"format": {"enum": ["markdown", "html", "plain"]},
+			"content": {"type": "string", "minLength": 1}
+		}
+	}`),
+	model.BlockTypeImage: mustCompileSchema(`{
+		"type": "object",
+		"required": ["url"],
+		"additionalProperties": false,
+		"properties": {
+			"url": {"type": "string", "minLength": 1, "maxLength": 500},
+			"alt": {"type": "string", "maxLength": 300},
+			"caption": {"type": "string", "maxLength": 500},
+			"link": {"type": "string", "maxLength": 500}
+		}
+	}`),
+	model.BlockTypeCTA: mustCompileSchema(`{
+		"type": "object",
+		"required": ["label", "url"],
+		"additionalProperties": false,
+		"properties": {
+			"label": {"type": "string", "minLength": 1, "maxLength": 100},
+			"url": {"type": "string", "minLength": 1, "maxLength": 500},
+			"variant": {"enum": ["primary", "secondary", "link"]},
+			"new_tab": {"type": "boolean"}
+		}
+	}`),
+	model.BlockTypeStats: mustCompileSchema(`{
+		"type": "object",
+		"required": ["items"],
+		"additionalProperties": false,
+		"properties": {
+			"items": {
+				"type": "array",
+				"minItems": 1,
+				"maxItems": 12,
+				"items": {
+					"type": "object",
+					"required": ["label", "value"],
+					"additionalProperties": false,
+					"properties": {
+						"label": {"type": "string", "minLength": 1, "maxLength": 100},
+						"value": {"type": "number"},
+						"prefix": {"type": "string", "maxLength": 10},
+						"suffix": {"type": "string", "maxLength": 10}
+					}
+				}
+			}
+		}
+	}`),
+	model.BlockTypeProjectList: mustCompileSchema(`{
+		"type": "object",
+		"required": ["mode"],
+		"additionalProperties": false,
+		"properties": {
+			"title": {"type": "string", "maxLength": 200},
+			"mode": {"enum": ["featured", "latest", "manual"]},
+			"project_ids": {"type": "array", "uniqueItems": true, "items": {"type": "string", "pattern": "` + uuidPattern + `"}},
+			"limit": {"type": "integer", "minimum": 1, "maximum": 50}
+		}
+	}`),
+	model.BlockTypeSkillList: mustCompileSchema(`{
+		"type": "object",
+		"required": ["mode"],
+		"additionalProperties": false,
+		"properties": {
+			"title": {"type": "string", "maxLength": 200},
+			"mode": {"enum": ["all", "featured", "category", "manual"]},
+			"category": {"type": "string", "minLength": 1},
+			"skill_ids": {"type": "array", "uniqueItems": true, "items": {"type": "string", "pattern": "` + uuidPattern + `"}},
+			"limit": {"type": "integer", "minimum": 1, "maximum": 100}
+		}
+	}`),
+}
+
+func mustCompileSchema(schema string) *utils.JSONSchema {
+	compiled, err := utils.CompileJSONSchema([]byte(schema))
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+// Payload block list setelah lolos schema
+type blockListPayload struct {
+	Mode       string      `json:"mode"`
+	Category   string      `json:"category"`
+	ProjectIDs []uuid.UUID `json:"project_ids"`
+	SkillIDs   []uuid.UUID `json:"skill_ids"`
+	Limit      int         `json:"limit"`
+}
+
+// Parameter :id menerima UUID atau section_id, :blockId harus block milik section tersebut
+type SectionBlockService interface {
+	Create(ctx *gin.Context) (*model.SectionBlockResponse, error)
+	GetBySection(ctx *gin.Context) ([]model.SectionBlockResponse, error)
+	GetAllBySection(ctx *gin.Context) ([]model.SectionBlockResponse, error)
+	Update(ctx *gin.Context) (*model.SectionBlockResponse, error)
+	Patch(ctx *gin.Context) (*model.SectionBlockResponse, error)
+	Delete(ctx *gin.Context) error
+	Reorder(ctx *gin.Context) ([]model.DisplayOrderItem, error)
+	GetPage(ctx *gin.Context) ([]model.PageSection, error)
+}
+
+type sectionBlockService struct {
+	repo        repo.SectionBlockRepository
+	sectionRepo repo.SectionRepository
+	skillRepo   repo.SkillRepository
+}
+
+func NewSectionBlockService(repo repo.SectionBlockRepository, sectionRepo repo.SectionRepository, skillRepo repo.SkillRepository) SectionBlockService {
+	return &sectionBlockService{repo: repo, sectionRepo: sectionRepo, skillRepo: skillRepo}
+}
+
+func (s *sectionBlockService) Create(ctx *gin.Context) (*model.SectionBlockResponse, error) {
+	section, err := findSection(s.sectionRepo, ctx.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req model.SectionBlockRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBlockPayload, err)
+	}
+
+	block := &model.SectionBlock{SectionID: section.ID, IsActive: true}
+	if err := applyBlockPayload(block, req.Type, req.Payload); err != nil {
+		return nil, err
+	}
+	if req.IsActive != nil {
+		block.IsActive = *req.IsActive
+	}
+	if req.DisplayOrder != nil {
+		block.DisplayOrder = *req.DisplayOrder
+	} else if block.DisplayOrder, err = s.repo.NextDisplayOrder(section.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(block); err != nil {
+		return nil, err
+	}
+	// Kolom is_active punya default true, simpan ulang agar nilai false tidak diabaikan GORM
+	if !block.IsActive {
+		if err := s.repo.Update(block); err != nil {
+			return nil, err
+		}
+	}
+	return toSectionBlockResponse(block), nil
+}
+
+// GetBySection mengembalikan block aktif untuk publik
+func (s *sectionBlockService) GetBySection(ctx *gin.Context) ([]model.SectionBlockResponse, error) {
+	return s.listBySection(ctx, true)
+}
+
+// GetAllBySection mengembalikan semua block termasuk yang nonaktif, untuk editor admin
+func (s *sectionBlockService) GetAllBySection(ctx *gin.Context) ([]model.SectionBlockResponse, error) {
+	return s.listBySection(ctx, false)
+}
+
+func (s *sectionBlockService) listBySection(ctx *gin.Context, activeOnly bool) ([]model.SectionBlockResponse, error) {
+	section, err := findSection(s.sectionRepo, ctx.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := s.repo.GetBySection(section.ID, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.SectionBlockResponse, 0, len(blocks))
+	for i := range blocks {
+		responses = append(responses, *toSectionBlockResponse(&blocks[i]))
+	}
+	return responses, nil
+}
+
+func (s *sectionBlockService) Update(ctx *gin.Context) (*model.SectionBlockResponse, error) {
+	block, err := s.find(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var req model.SectionBlockUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBlockPayload, err)
+	}
+
+	if err := applyBlockPayload(block, req.Type, req.Payload); err != nil {
+		return nil, err
+	}
+	block.DisplayOrder = req.DisplayOrder
+	block.IsActive = req.IsActive
+	block.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(block); err != nil {
+		return nil, err
+	}
+	return toSectionBlockResponse(block), nil
+}
+
+// Patch memvalidasi ulang payload lama jika hanya type yang diubah
+func (s *sectionBlockService) Patch(ctx *gin.Context) (*model.SectionBlockResponse, error) {
+	block, err := s.find(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var req model.SectionBlockPatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBlockPayload, err)
+	}
+
+	if req.Type != nil || req.Payload != nil {
+		blockType := block.Type
+		if req.Type != nil {
+			blockType = *req.Type
+		}
+		payload := req.Payload
+		if payload == nil {
+			payload = json.RawMessage(block.Payload)
+		}
+		if err := applyBlockPayload(block, blockType, payload); err != nil {
+			return nil, err
+		}
+	}
+	if req.DisplayOrder != nil {
+		block.DisplayOrder = *req.DisplayOrder
+	}
+	if req.IsActive != nil {
+		block.IsActive = *req.IsActive
+	}
+	block.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(block); err != nil {
+		return nil, err
+	}
+	return toSectionBlockResponse(block), nil
+}
+
+func (s *sectionBlockService) Delete(ctx *gin.Context) error {
+	block, err := s.find(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(block.ID)
+}
+
+func (s *sectionBlockService) Reorder(ctx *gin.Context) ([]model.DisplayOrderItem, error) {
+	section, err := findSection(s.sectionRepo, ctx.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req model.ReorderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOrderList, err)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: duplicate id %s", ErrInvalidOrderList, id)
+		}
+		seen[id] = true
+	}
+
+	return s.repo.Reorder(section.ID, req.IDs)
+}
+
+// GetPage mengembalikan seluruh section aktif beserta block aktifnya dengan data project/skill yang sudah di-resolve
+func (s *sectionBlockService) GetPage(ctx *gin.Context) ([]model.PageSection, error) {
+	sections, err := s.sectionRepo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+
+	sectionIDs := make([]uuid.UUID, 0, len(sections))
 	for _, section := range sections {
-		responses = append(responses, *convertSectionToResponse(&section))
+		sectionIDs = append(sectionIDs, section.ID)
+	}
+	blocks, err := s.repo.GetBySections(sectionIDs, true)
+	if err != nil {
+		return nil, err
+	}
+
+	blocksBySection := make(map[uuid.UUID][]model.SectionBlockResponse, len(sections))
+	for i := range blocks {
+		response := toSectionBlockResponse(&blocks[i])
+		if response.Items, err = s.resolveItems(&blocks[i]); err != nil {
+			return nil, err
+		}
+		blocksBySection[blocks[i].SectionID] = append(blocksBySection[blocks[i].SectionID], *response)
+	}
+
+	page := make([]model.PageSection, 0, len(sections))
+	for _, section := range sections {
+		sectionBlocks := blocksBySection[section.ID]
+		if sectionBlocks == nil {
+			sectionBlocks = []model.SectionBlockResponse{}
+		}
+		page = append(page, model.PageSection{
+			ID:           section.ID,
+			SectionID:    section.SectionID,
+			Label:        section.Label,
+			DisplayOrder: section.DisplayOrder,
+			Blocks:       sectionBlocks,
+		})
+	}
+	return page, nil
+}
+
+// find mengambil block dari :blockId dan memastikan block tersebut milik section :id
+func (s *sectionBlockService) find(ctx *gin.Context) (*model.SectionBlock, error) {
+	section, err := findSection(s.sectionRepo, ctx.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	blockID, err := uuid.Parse(ctx.Param("blockId"))
+	if err != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	block, err := s.repo.GetByID(blockID)
+	if err != nil {
+		return nil, err
+	}
+	if block.SectionID != section.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return block, nil
+}
+
+// resolveItems mengisi project/skill untuk block list, block lain tidak punya items
+func (s *sectionBlockService) resolveItems(block *model.SectionBlock) (interface{}, error) {
+	if block.Type != model.BlockTypeProjectList && block.Type != model.BlockTypeSkillList {
+		return nil, nil
+	}
+
+	var payload blockListPayload
+	if err := json.Unmarshal([]byte(block.Payload), &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBlockPayload, err)
+	}
+	limit := payload.Limit
+	if limit <= 0 && payload.Mode != "manual" {
+		limit = defaultBlockListLimit
+	}
+
+	if block.Type == model.BlockTypeProjectList {
+		return s.repo.GetProjectCards(payload.Mode, payload.ProjectIDs, limit)
+	}
+
+	var skills []model.Skill
+	var err error
+	switch payload.Mode {
+	case "featured":
+		skills, err = s.skillRepo.GetFeatured()
+	case "category":
+		skills, err = s.skillRepo.GetByCategory(payload.Category)
+	default:
+		skills, err = s.skillRepo.GetAll()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Mode == "manual" {
+		byID := make(map[uuid.UUID]model.Skill, len(skills))
+		for _, skill := range skills {
+			byID[skill.ID] = skill
+		}
+		skills = skills[:0]
+		for _, id := range payload.SkillIDs {
+			if skill, ok := byID[id]; ok {
+				skills = append(skills, skill)
+			}
+		}
+	}
+	// limit 0 (manual tanpa limit) berarti semua
+	if limit > 0 && len(skills) > limit {
+		skills = skills[:limit]
+	}
+
+	responses := make([]model.SkillResponse, 0, len(skills))
+	for i := range skills {
+		responses = append(responses, *toSkillResponse(&skills[i]))
+	}
+	return responses, nil
+}
+
+// applyBlockPayload memvalidasi payload sesuai type lalu menyimpannya dalam bentuk JSON ringkas
+func applyBlockPayload(block *model.SectionBlock, blockType string, payload json.RawMessage) error {
+	blockType = strings.ToLower(strings.TrimSpace(blockType))
+	schema, ok := blockPayloadSchemas[blockType]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownBlockType, blockType)
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, payload); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBlockPayload, err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(compact.Bytes(), &decoded); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBlockPayload, err)
+	}
+	if err := schema.Validate(decoded); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBlockPayload, err)
+	}
+
+	// Aturan yang tidak bisa diekspresikan dengan subset JSON Schema
+	if blockType == model.BlockTypeProjectList || blockType == model.BlockTypeSkillList {
+		var list blockListPayload
+		if err := json.Unmarshal(compact.Bytes(), &list); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidBlockPayload, err)
+		}
+		switch {
+		case list.Mode == "manual" && len(list.ProjectIDs) == 0 && len(list.SkillIDs) == 0:
+			return fmt.Errorf("%w: manual mode requires at least one id", ErrInvalidBlockPayload)
+		case list.Mode == "category" && strings.TrimSpace(list.Category) == "":
+			return fmt.Errorf("%w: category mode requires category", ErrInvalidBlockPayload)
+		}
+	}
+
+	block.Type = blockType
+	block.Payload = compact.String()
+	return nil
+}
+
+// ============================
+// SOCIAL LINKS SERVICE (no upload needed)
+// ============================
+
+// Parameter :id pada endpoint social link menerima UUID atau nama platform
+type SocialLinkService interface {
+	Create(ctx *gin.Context) (*model.SocialLinkResponse, bool, error)
+	GetByID(ctx *gin.Context) (*model.SocialLinkResponse, error)
+	Update(ctx *gin.Context) (*model.SocialLinkResponse, bool, error)
+	Patch(ctx *gin.Context) (*model.SocialLinkResponse, error)
+	Delete(ctx *gin.Context) error
+	GetAll(ctx *gin.Context) ([]model.SocialLinkResponse, error)
+	GetActive(ctx *gin.Context) ([]model.SocialLinkResponse, error)
+}
+
+type socialLinkService struct {
+	repo repo.SocialLinkRepository
+}
+
+func NewSocialLinkService(repo repo.SocialLinkRepository) SocialLinkService {
+	return &socialLinkService{repo: repo}
+}
+
+// Create melakukan upsert berdasarkan platform, bool kedua true jika data baru dibuat
+func (s *socialLinkService) Create(ctx *gin.Context) (*model.SocialLinkResponse, bool, error) {
+	var req model.SocialLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, false, err
+	}
+
+	platform := strings.TrimSpace(req.Platform)
+	if platform == "" {
+		return nil, false, errors.New("platform is required")
+	}
+	var link *model.SocialLink
+	var created bool
+	err := retryOnUniqueViolation(func() error {
+		var err error
+		link, err = s.repo.GetByPlatform(platform)
+		created = errors.Is(err, gorm.ErrRecordNotFound)
+		if err != nil && !created {
+			return err
+		}
+		if created {
+			link = &model.SocialLink{Platform: platform}
+		}
+
+		link.URL = req.URL
+		link.IconName = req.IconName
+		link.DisplayOrder = req.DisplayOrder
+		link.IsActive = req.IsActive
+
+		return s.save(link, created)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return convertSocialLinkToResponse(link), created, nil
+}
+
+func (s *socialLinkService) GetByID(ctx *gin.Context) (*model.SocialLinkResponse, error) {
+	link, err := s.find(ctx.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+	return convertSocialLinkToResponse(link), nil
+}
+
+// Update mengganti seluruh field. Jika dipanggil dengan platform yang belum ada, link dibuat (upsert)
+func (s *socialLinkService) Update(ctx *gin.Context) (*model.SocialLinkResponse, bool, error) {
+	ref := strings.TrimSpace(ctx.Param("id"))
+
+	var req model.SocialLinkUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, false, err
+	}
+
+	var link *model.SocialLink
+	var created bool
+	err := retryOnUniqueViolation(func() error {
+		var err error
+		link, err = s.find(ref)
+		created = errors.Is(err, gorm.ErrRecordNotFound) && !isUUIDRef(ref)
+		if err != nil && !created {
+			return err
+		}
+		if created {
+			link = &model.SocialLink{Platform: ref}
+		}
+
+		if err := s.rename(link, req.Platform); err != nil {
+			return err
+		}
+		link.URL = req.URL
+		link.IconName = req.IconName
+		link.DisplayOrder = req.DisplayOrder
+		link.IsActive = req.IsActive
+
+		return s.save(link, created)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return convertSocialLinkToResponse(link), created, nil
+}
+
+func (s *socialLinkService) Patch(ctx *gin.Context) (*model.SocialLinkResponse, error) {
+	link, err := s.find(ctx.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var req model.SocialLinkPatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Platform != nil {
+		if err := s.rename(link, *req.Platform); err != nil {
+			return nil, err
+		}
+	}
+	if req.URL != nil {
+		if strings.TrimSpace(*req.URL) == "" {
+			return nil, errors.New("url cannot be empty")
+		}
+		link.URL = *req.URL
+	}
+	if req.IconName != nil {
+		link.IconName = *req.IconName
+	}
+	if req.DisplayOrder != nil {
+		link.DisplayOrder = *req.DisplayOrder
+	}
+	if req.IsActive != nil {
+		link.IsActive = *req.IsActive
+	}
+
+	if err := s.save(link, false); err != nil {
+		return nil, err
+	}
+	return convertSocialLinkToResponse(link), nil
+}
+
+func (s *socialLinkService) Delete(ctx *gin.Context) error {
+	link, err := s.find(ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Delete(link.ID)
+}
+
+func (s *socialLinkService) GetAll(ctx *gin.Context) ([]model.SocialLinkResponse, error) {
+	links, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []model.SocialLinkResponse
+	for _, link := range links {
+		responses = append(responses, *convertSocialLinkToResponse(&link))
+	}
+
+	return responses, nil
+}
+
+func (s *socialLinkService) GetActive(ctx *gin.Context) ([]model.SocialLinkResponse, error) {
+	links, err := s.repo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]model.SocialLinkResponse, 0, len(links))
+	for i := range links {
+		responses = append(responses, *convertSocialLinkToResponse(&links[i]))
+	}
+
+	return responses, nil
+}
+
+func (s *socialLinkService) find(ref string) (*model.SocialLink, error) {
+	ref = strings.TrimSpace(ref)
+	if id, err := uuid.Parse(ref); err == nil {
+		return s.repo.GetByID(id)
+	}
+	return s.repo.GetByPlatform(ref)
+}
+
+// rename mengganti platform jika diisi dan belum dipakai link lain
+func (s *socialLinkService) rename(link *model.SocialLink, platform string) error {
+	platform = strings.TrimSpace(platform)
+	if platform == "" || platform == link.Platform {
+		return nil
+	}
+
+	other, err := s.repo.GetByPlatform(platform)
+	if err == nil && other.ID != link.ID {
+		return ErrNaturalKeyTaken
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	link.Platform = platform
+	return nil
+}
+
+func (s *socialLinkService) save(link *model.SocialLink, created bool) error {
+	if created {
+		return s.repo.Create(link)
+	}
+	link.UpdatedAt = time.Now()
+	return s.repo.Update(link)
+}
+
+// ============================
+// PROFILE SERVICE
+// ============================
+
+var ErrInvalidProfile = errors.New("invalid profile")
+
+const (
+	maxProfileRoles      = 10
+	maxProfileRoleLength = 100
+	maxYearsOfExperience = 80
+)
+
+type ProfileService interface {
+	Get(ctx *gin.Context) (*model.ProfileResponse, error)
+	Update(ctx *gin.Context) (*model.ProfileResponse, error)
+	Patch(ctx *gin.Context) (*model.ProfileResponse, error)
+	UploadAvatar(ctx *gin.Context) (*model.ProfileResponse, error)
+	DeleteAvatar(ctx *gin.Context) (*model.ProfileResponse, error)
+}
+
+type profileService struct {
+	repo          repo.ProfileRepository
+	uploadPath    string
+	uploadService UploadServiceWrapper
+}
+
+func NewProfileService(repo repo.ProfileRepository, uploadPath string) ProfileService {
+	if err := os.MkdirAll(uploadPath, 0755); err != nil {
+		fmt.Printf("⚠️ Warning: gagal membuat folder upload profile: %v\n", err)
+	}
+
+	var uploadService UploadServiceWrapper
+
+	if getUploadProvider() == "supabase" {
+		supabaseService := createSupabaseUploadService()
+		if supabaseService != nil {
+			uploadService = NewSupabaseUploadWrapper(supabaseService)
+			fmt.Println("✅ Using Supabase Storage for profile")
+		} else {
+			localService := utils.NewLocalUploadService(uploadPath)
+			uploadService = NewLocalUploadWrapper(localService)
+			fmt.Println("⚠️ Using Local Storage for profile (Supabase not configured)")
+		}
+	} else {
+		localService := utils.NewLocalUploadService(uploadPath)
+		uploadService = NewLocalUploadWrapper(localService)
+		fmt.Println("ℹ️ Using Local Storage for profile (development)")
+	}
+
+	return &profileService{
+		repo:          repo,
+		uploadPath:    uploadPath,
+		uploadService: uploadService,
+	}
+}
+
+// NewProfileServiceWithUpload untuk custom upload service
+func NewProfileServiceWithUpload(repo repo.ProfileRepository, uploadService UploadServiceWrapper, folder string) ProfileService {
+	uploadPath := getUploadPath()
+	localPath := filepath.Join(uploadPath, folder)
+	if err := os.MkdirAll(localPath, 0755); err != nil {
+		fmt.Printf("⚠️ Warning: gagal membuat folder upload: %v\n", err)
+	}
+
+	return &profileService{
+		repo:          repo,
+		uploadPath:    localPath,
+		uploadService: uploadService,
+	}
+}
+
+func (s *profileService) Get(ctx *gin.Context) (*model.ProfileResponse, error) {
+	profile, err := s.repo.Get()
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(profile)
+}
+
+// Update mengganti seluruh field profil, profil dibuat jika belum ada
+func (s *profileService) Update(ctx *gin.Context) (*model.ProfileResponse, error) {
+	var req model.ProfileRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProfile, err)
 	}
 
-	return responses, nil
-}
+	profile, err := s.repo.Get()
+	created := errors.Is(err, gorm.ErrRecordNotFound)
+	if err != nil && !created {
+		return nil, err
+	}
+	if created {
+		profile = &model.Profile{}
+	}
 
-// ============================
-// SOCIAL LINKS SERVICE (no upload needed)
-// ============================
+	profile.Name = req.Name
+	profile.Headline = req.Headline
+	profile.Bio = req.Bio
+	profile.ResumeURL = req.ResumeURL
+	profile.Location = req.Location
+	profile.Availability = req.Availability
+	profile.YearsOfExperience = req.YearsOfExperience
+	if err := setProfileRoles(profile, req.Roles); err != nil {
+		return nil, err
+	}
 
-type SocialLinkService interface {
-	Create(ctx *gin.Context) (*model.SocialLinkResponse, error)
-	Delete(ctx *gin.Context) error
-	GetAll(ctx *gin.Context) ([]model.SocialLinkResponse, error)
+	if err := s.save(profile, created); err != nil {
+		return nil, err
+	}
+	return s.toResponse(profile)
 }
 
-type socialLinkService struct {
-	repo repo.SocialLinkRepository
-}
+func (s *profileService) Patch(ctx *gin.Context) (*model.ProfileResponse, error) {
+	profile, err := s.repo.Get()
+	if err != nil {
+		return nil, err
+	}
 
-func NewSocialLinkService(repo repo.SocialLinkRepository) SocialLinkService {
-	return &socialLinkService{repo: repo}
+	var req model.ProfilePatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProfile, err)
+	}
+
+	if req.Name != nil {
+		profile.Name = *req.Name
+	}
+	if req.Headline != nil {
+		profile.Headline = *req.Headline
+	}
+	if req.Roles != nil {
+		if err := setProfileRoles(profile, *req.Roles); err != nil {
+			return nil, err
+		}
+	}
+	if req.Bio != nil {
+		profile.Bio = *req.Bio
+	}
+	if req.ResumeURL != nil {
+		profile.ResumeURL = *req.ResumeURL
+	}
+	if req.Location != nil {
+		profile.Location = *req.Location
+	}
+	if req.Availability != nil {
+		profile.Availability = *req.Availability
+	}
+	if req.YearsOfExperience != nil {
+		var years *int
+		if err := json.Unmarshal(req.YearsOfExperience, &years); err != nil {
+			return nil, fmt.Errorf("%w: years_of_experience must be an integer or null", ErrInvalidProfile)
+		}
+		profile.YearsOfExperience = years
+	}
+
+	if err := s.save(profile, false); err != nil {
+		return nil, err
+	}
+	return s.toResponse(profile)
 }
 
-func (s *socialLinkService) Create(ctx *gin.Context) (*model.SocialLinkResponse, error) {
-	var req model.SocialLinkRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+// UploadAvatar mengganti avatar dari form field "avatar", file lama dihapus setelah tersimpan
+func (s *profileService) UploadAvatar(ctx *gin.Context) (*model.ProfileResponse, error) {
+	profile, err := s.repo.Get()
+	if err != nil {
 		return nil, err
 	}
 
-	link := &model.SocialLink{
-		Platform:     req.Platform,
-		URL:          req.URL,
-		IconName:     req.IconName,
-		DisplayOrder: req.DisplayOrder,
-		IsActive:     req.IsActive,
+	file, err := ctx.FormFile("avatar")
+	if err != nil {
+		return nil, fmt.Errorf("%w: file avatar harus diupload", ErrInvalidProfile)
 	}
 
-	if err := s.repo.Create(link); err != nil {
+	allowedExts := []string{".jpg", ".jpeg", ".png", ".webp"}
+	if err := s.uploadService.ValidateFile(file, 5, allowedExts); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProfile, err)
+	}
+
+	avatarURL, err := s.uploadService.UploadFile(file, "profile")
+	if err != nil {
+		return nil, fmt.Errorf("gagal upload avatar: %v", err)
+	}
+
+	oldAvatar := profile.AvatarURL
+	profile.AvatarURL = avatarURL
+	if err := s.save(profile, false); err != nil {
+		s.uploadService.DeleteFile(avatarURL)
 		return nil, err
 	}
 
-	return convertSocialLinkToResponse(link), nil
+	if oldAvatar != "" {
+		if err := s.uploadService.DeleteFile(oldAvatar); err != nil {
+			fmt.Printf("⚠️ Warning: gagal menghapus avatar lama: %v\n", err)
+		}
+	}
+	return s.toResponse(profile)
 }
 
-func (s *socialLinkService) Delete(ctx *gin.Context) error {
-	id, err := uuid.Parse(ctx.Param("id"))
+func (s *profileService) DeleteAvatar(ctx *gin.Context) (*model.ProfileResponse, error) {
+	profile, err := s.repo.Get()
 	if err != nil {
-		return errors.New("invalid social link ID")
+		return nil, err
+	}
+	if profile.AvatarURL == "" {
+		return s.toResponse(profile)
 	}
 
-	return s.repo.Delete(id)
+	oldAvatar := profile.AvatarURL
+	profile.AvatarURL = ""
+	if err := s.save(profile, false); err != nil {
+		return nil, err
+	}
+
+	if err := s.uploadService.DeleteFile(oldAvatar); err != nil {
+		fmt.Printf("⚠️ Warning: gagal menghapus avatar: %v\n", err)
+	}
+	return s.toResponse(profile)
 }
 
-func (s *socialLinkService) GetAll(ctx *gin.Context) ([]model.SocialLinkResponse, error) {
-	links, err := s.repo.GetAll()
-	if err != nil {
-		return nil, err
+// save menormalkan dan memvalidasi field sebelum disimpan
+func (s *profileService) save(profile *model.Profile, created bool) error {
+	profile.Name = strings.TrimSpace(profile.Name)
+	profile.Headline = strings.TrimSpace(profile.Headline)
+	profile.ResumeURL = strings.TrimSpace(profile.ResumeURL)
+	profile.Location = strings.TrimSpace(profile.Location)
+	profile.Availability = strings.ToLower(strings.TrimSpace(profile.Availability))
+	if profile.Availability == "" {
+		profile.Availability = model.AvailabilityOpen
 	}
 
-	var responses []model.SocialLinkResponse
-	for _, link := range links {
-		responses = append(responses, *convertSocialLinkToResponse(&link))
+	switch {
+	case profile.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidProfile)
+	case !isProfileAvailability(profile.Availability):
+		return fmt.Errorf("%w: availability must be one of open, freelance, busy, unavailable", ErrInvalidProfile)
+	case profile.YearsOfExperience != nil && (*profile.YearsOfExperience < 0 || *profile.YearsOfExperience > maxYearsOfExperience):
+		return fmt.Errorf("%w: years_of_experience must be between 0 and %d", ErrInvalidProfile, maxYearsOfExperience)
 	}
 
-	return responses, nil
+	if created {
+		return s.repo.Create(profile)
+	}
+	profile.UpdatedAt = time.Now()
+	return s.repo.Update(profile)
+}
+
+func (s *profileService) toResponse(profile *model.Profile) (*model.ProfileResponse, error) {
+	var roles []string
+	if err := json.Unmarshal([]byte(profile.Roles), &roles); err != nil || roles == nil {
+		roles = []string{}
+	}
+
+	years := 0
+	if profile.YearsOfExperience != nil {
+		years = *profile.YearsOfExperience
+	} else {
+		periods, err := s.repo.GetExperiencePeriods()
+		if err != nil {
+			return nil, err
+		}
+		ranges := make([]utils.MonthRange, 0, len(periods))
+		for _, period := range periods {
+			ranges = append(ranges, utils.MonthRange{Start: period.StartDate, End: period.EndDate, Current: period.CurrentJob})
+		}
+		years = utils.TotalMonths(ranges) / 12
+	}
+
+	return &model.ProfileResponse{
+		ID:                        profile.ID,
+		Name:                      profile.Name,
+		Headline:                  profile.Headline,
+		Roles:                     roles,
+		Bio:                       profile.Bio,
+		AvatarURL:                 profile.AvatarURL,
+		ResumeURL:                 profile.ResumeURL,
+		Location:                  profile.Location,
+		Availability:              profile.Availability,
+		YearsOfExperience:         years,
+		YearsOfExperienceOverride: profile.YearsOfExperience,
+		UpdatedAt:                 profile.UpdatedAt,
+	}, nil
+}
+
+// setProfileRoles merapikan daftar role (trim, tanpa duplikat) lalu menyimpannya sebagai JSON
+func setProfileRoles(profile *model.Profile, roles []string) error {
+	cleaned := make([]string, 0, len(roles))
+	seen := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		role = strings.TrimSpace(role)
+		if role == "" || seen[strings.ToLower(role)] {
+			continue
+		}
+		if utf8.RuneCountInString(role) > maxProfileRoleLength {
+			return fmt.Errorf("%w: each role must be at most %d characters", ErrInvalidProfile, maxProfileRoleLength)
+		}
+		seen[strings.ToLower(role)] = true
+		cleaned = append(cleaned, role)
+	}
+	if len(cleaned) > maxProfileRoles {
+		return fmt.Errorf("%w: at most %d roles are allowed", ErrInvalidProfile, maxProfileRoles)
+	}
+
+	encoded, err := json.Marshal(cleaned)
+	if err != nil {
+		return err
+	}
+	profile.Roles = string(encoded)
+	return nil
+}
+
+func isProfileAvailability(availability string) bool {
+	switch availability {
+	case model.AvailabilityOpen, model.AvailabilityFreelance, model.AvailabilityBusy, model.AvailabilityUnavailable:
+		return true
+	}
+	return false
 }
 
 // ============================
 // SETTINGS SERVICE (no upload needed)
 // ============================
 
+// ErrInvalidSettingValue dikembalikan saat value tidak sesuai data_type atau schema
+var ErrInvalidSettingValue = errors.New("invalid setting value")
+
+// Parameter :key pada endpoint setting menerima key atau UUID.
+// GetByKey dan GetAll hanya melihat setting publik, versi Admin melihat semuanya.
 type SettingService interface {
-	Create(ctx *gin.Context) (*model.SettingResponse, error)
+	Create(ctx *gin.Context) (*model.SettingResponse, bool, error)
+	GetByKey(ctx *gin.Context) (*model.SettingResponse, error)
+	GetByKeyAdmin(ctx *gin.Context) (*model.SettingResponse, error)
+	Update(ctx *gin.Context) (*model.SettingResponse, bool, error)
+	Patch(ctx *gin.Context) (*model.SettingResponse, error)
 	Delete(ctx *gin.Context) error
 	GetAll(ctx *gin.Context) ([]model.SettingResponse, error)
+	GetAllAdmin(ctx *gin.Context) ([]model.SettingResponse, error)
 }
 
 type settingService struct {
@@ -1381,57 +4446,390 @@ func NewSettingService(repo repo.SettingRepository) SettingService {
 	return &settingService{repo: repo}
 }
 
-func (s *settingService) Create(ctx *gin.Context) (*model.SettingResponse, error) {
+// Create melakukan upsert berdasarkan key, bool kedua true jika data baru dibuat
+func (s *settingService) Create(ctx *gin.Context) (*model.SettingResponse, bool, error) {
 	var req model.SettingRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, false, err
+	}
+
+	key := strings.TrimSpace(req.Key)
+	if key == "" {
+		return nil, false, errors.New("key is required")
+	}
+	var setting *model.Setting
+	var created bool
+	err := retryOnUniqueViolation(func() error {
+		var err error
+		setting, err = s.repo.GetByKey(key)
+		created = errors.Is(err, gorm.ErrRecordNotFound)
+		if err != nil && !created {
+			return err
+		}
+		if created {
+			setting = &model.Setting{Key: key}
+		}
+
+		schema, err := upsertSettingSchema(setting, req.Schema)
+		if err != nil {
+			return err
+		}
+		if err := applySettingValue(setting, req.DataType, req.Value, schema); err != nil {
+			return err
+		}
+		setting.Description = req.Description
+		if req.IsPublic != nil {
+			setting.IsPublic = *req.IsPublic
+		}
+
+		return s.save(setting, created)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return convertSettingToResponse(setting), created, nil
+}
+
+// GetByKey untuk endpoint publik, setting privat diperlakukan seperti tidak ada
+func (s *settingService) GetByKey(ctx *gin.Context) (*model.SettingResponse, error) {
+	setting, err := s.find(ctx.Param("key"))
+	if err != nil {
+		return nil, err
+	}
+	if !setting.IsPublic {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return convertSettingToResponse(setting), nil
+}
+
+func (s *settingService) GetByKeyAdmin(ctx *gin.Context) (*model.SettingResponse, error) {
+	setting, err := s.find(ctx.Param("key"))
+	if err != nil {
+		return nil, err
+	}
+	return convertSettingToResponse(setting), nil
+}
+
+// Update mengganti seluruh field. Jika key belum ada, setting dibuat (upsert)
+func (s *settingService) Update(ctx *gin.Context) (*model.SettingResponse, bool, error) {
+	ref := strings.TrimSpace(ctx.Param("key"))
+
+	var req model.SettingUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, false, err
+	}
+
+	var setting *model.Setting
+	var created bool
+	err := retryOnUniqueViolation(func() error {
+		var err error
+		setting, err = s.find(ref)
+		created = errors.Is(err, gorm.ErrRecordNotFound) && !isUUIDRef(ref)
+		if err != nil && !created {
+			return err
+		}
+		if created {
+			setting = &model.Setting{Key: ref}
+		}
+
+		if err := s.rename(setting, req.Key); err != nil {
+			return err
+		}
+		schema, err := upsertSettingSchema(setting, req.Schema)
+		if err != nil {
+			return err
+		}
+		if err := applySettingValue(setting, req.DataType, req.Value, schema); err != nil {
+			return err
+		}
+		setting.Description = req.Description
+		if req.IsPublic != nil {
+			setting.IsPublic = *req.IsPublic
+		}
+
+		return s.save(setting, created)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return convertSettingToResponse(setting), created, nil
+}
+
+// Patch memvalidasi ulang value lama jika hanya data_type atau schema yang diubah
+func (s *settingService) Patch(ctx *gin.Context) (*model.SettingResponse, error) {
+	setting, err := s.find(ctx.Param("key"))
+	if err != nil {
 		return nil, err
 	}
 
-	setting := &model.Setting{
-		Key:         req.Key,
-		Value:       req.Value,
-		DataType:    req.DataType,
-		Description: req.Description,
+	var req model.SettingPatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, err
 	}
 
-	if setting.DataType == "" {
-		setting.DataType = "string"
+	if req.Key != nil {
+		if err := s.rename(setting, *req.Key); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := s.repo.Create(setting); err != nil {
+	dataType := setting.DataType
+	if req.DataType != nil {
+		dataType = *req.DataType
+	}
+	value := req.Value
+	if value == nil {
+		value = settingRawValue(setting)
+	}
+	schema := setting.Schema
+	if req.Schema != nil {
+		if schema, err = normalizeSettingSchema(req.Schema); err != nil {
+			return nil, err
+		}
+	}
+	if err := applySettingValue(setting, dataType, value, schema); err != nil {
 		return nil, err
 	}
 
+	if req.Description != nil {
+		setting.Description = *req.Description
+	}
+	if req.IsPublic != nil {
+		setting.IsPublic = *req.IsPublic
+	}
+
+	if err := s.save(setting, false); err != nil {
+		return nil, err
+	}
 	return convertSettingToResponse(setting), nil
 }
 
 func (s *settingService) Delete(ctx *gin.Context) error {
-	id, err := uuid.Parse(ctx.Param("id"))
+	setting, err := s.find(ctx.Param("key"))
 	if err != nil {
-		return errors.New("invalid setting ID")
+		return err
 	}
 
-	return s.repo.Delete(id)
+	return s.repo.Delete(setting.ID)
 }
 
+// GetAll untuk endpoint publik, hanya setting dengan is_public = true
 func (s *settingService) GetAll(ctx *gin.Context) ([]model.SettingResponse, error) {
+	settings, err := s.repo.GetPublic()
+	if err != nil {
+		return nil, err
+	}
+	return convertSettingsToResponses(settings), nil
+}
+
+func (s *settingService) GetAllAdmin(ctx *gin.Context) ([]model.SettingResponse, error) {
 	settings, err := s.repo.GetAll()
 	if err != nil {
 		return nil, err
 	}
+	return convertSettingsToResponses(settings), nil
+}
+
+func (s *settingService) find(ref string) (*model.Setting, error) {
+	ref = strings.TrimSpace(ref)
+	if id, err := uuid.Parse(ref); err == nil {
+		return s.repo.GetByID(id)
+	}
+	return s.repo.GetByKey(ref)
+}
 
-	var responses []model.SettingResponse
-	for _, setting := range settings {
-		responses = append(responses, *convertSettingToResponse(&setting))
+// rename mengganti key jika diisi dan belum dipakai setting lain
+func (s *settingService) rename(setting *model.Setting, key string) error {
+	key = strings.TrimSpace(key)
+	if key == "" || key == setting.Key {
+		return nil
 	}
 
-	return responses, nil
+	other, err := s.repo.GetByKey(key)
+	if err == nil && other.ID != setting.ID {
+		return ErrNaturalKeyTaken
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	setting.Key = key
+	return nil
+}
+
+func (s *settingService) save(setting *model.Setting, created bool) error {
+	if created {
+		return s.repo.Create(setting)
+	}
+	setting.UpdatedAt = time.Now()
+	return s.repo.Update(setting)
+}
+
+// applySettingValue memvalidasi value terhadap data_type dan schema, lalu menyimpannya dalam bentuk kanonik
+func applySettingValue(setting *model.Setting, dataType string, raw json.RawMessage, schema string) error {
+	dataType = strings.ToLower(strings.TrimSpace(dataType))
+	if dataType == "" {
+		dataType = model.SettingTypeString
+	}
+
+	stored, typed, err := normalizeSettingValue(dataType, raw)
+	if err != nil {
+		return err
+	}
+
+	if schema != "" {
+		compiled, err := utils.CompileJSONSchema([]byte(schema))
+		if err != nil {
+			return err
+		}
+		if err := compiled.Validate(typed); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSettingValue, err)
+		}
+	}
+
+	setting.DataType = dataType
+	setting.Value = stored
+	setting.Schema = schema
+	return nil
+}
+
+// normalizeSettingValue mengubah value JSON menjadi teks kanonik untuk database dan nilai bertipe untuk schema.
+// String berisi angka, boolean, atau objek/array JSON tetap diterima untuk tipe yang sesuai.
+func normalizeSettingValue(dataType string, raw json.RawMessage) (string, interface{}, error) {
+	raw = json.RawMessage(strings.TrimSpace(string(raw)))
+	if len(raw) == 0 || string(raw) == "null" {
+		if dataType == model.SettingTypeString {
+			return "", "", nil
+		}
+		if !isSettingType(dataType) {
+			return "", nil, fmt.Errorf("%w: unknown data_type %q", ErrInvalidSettingValue, dataType)
+		}
+		return "", nil, nil
+	}
+
+	var text string
+	isString := json.Unmarshal(raw, &text) == nil
+
+	switch dataType {
+	case model.SettingTypeString:
+		if !isString {
+			return "", nil, fmt.Errorf("%w: value must be a string", ErrInvalidSettingValue)
+		}
+		return text, text, nil
+
+	case model.SettingTypeNumber:
+		literal := string(raw)
+		if isString {
+			literal = strings.TrimSpace(text)
+		}
+		var number float64
+		if err := json.Unmarshal([]byte(literal), &number); err != nil {
+			return "", nil, fmt.Errorf("%w: value must be a number", ErrInvalidSettingValue)
+		}
+		return literal, number, nil
+
+	case model.SettingTypeBoolean:
+		var flag bool
+		if err := json.Unmarshal(raw, &flag); err != nil {
+			parsed, parseErr := strconv.ParseBool(strings.TrimSpace(text))
+			if !isString || parseErr != nil {
+				return "", nil, fmt.Errorf("%w: value must be a boolean", ErrInvalidSettingValue)
+			}
+			flag = parsed
+		}
+		return strconv.FormatBool(flag), flag, nil
+
+	case model.SettingTypeJSON:
+		literal := []byte(raw)
+		if trimmed := strings.TrimSpace(text); isString && (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+			literal = []byte(trimmed)
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, literal); err != nil {
+			return "", nil, fmt.Errorf("%w: value must be valid JSON", ErrInvalidSettingValue)
+		}
+		var typed interface{}
+		if err := json.Unmarshal(compact.Bytes(), &typed); err != nil {
+			return "", nil, fmt.Errorf("%w: value must be valid JSON", ErrInvalidSettingValue)
+		}
+		return compact.String(), typed, nil
+
+	default:
+		return "", nil, fmt.Errorf("%w: unknown data_type %q", ErrInvalidSettingValue, dataType)
+	}
+}
+
+func isSettingType(dataType string) bool {
+	switch dataType {
+	case model.SettingTypeString, model.SettingTypeNumber, model.SettingTypeBoolean, model.SettingTypeJSON:
+		return true
+	}
+	return false
+}
+
+// normalizeSettingSchema memastikan schema valid dan menyimpannya dalam bentuk ringkas, null/kosong berarti tanpa schema
+func normalizeSettingSchema(raw json.RawMessage) (string, error) {
+	trimmed := strings.TrimSpace(string(raw))
+	if trimmed == "" || trimmed == "null" {
+		return "", nil
+	}
+	if _, err := utils.CompileJSONSchema([]byte(trimmed)); err != nil {
+		return "", err
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(trimmed)); err != nil {
+		return "", fmt.Errorf("%w: %v", utils.ErrInvalidSchema, err)
+	}
+	return compact.String(), nil
+}
+
+// upsertSettingSchema: schema yang tidak dikirim mempertahankan schema lama, null menghapusnya
+func upsertSettingSchema(setting *model.Setting, raw json.RawMessage) (string, error) {
+	if len(raw) == 0 {
+		return setting.Schema, nil
+	}
+	return normalizeSettingSchema(raw)
+}
+
+// settingRawValue mengembalikan value tersimpan sebagai JSON, dipakai saat PATCH tidak mengirim value
+func settingRawValue(setting *model.Setting) json.RawMessage {
+	if setting.DataType == model.SettingTypeString || !json.Valid([]byte(setting.Value)) {
+		encoded, _ := json.Marshal(setting.Value)
+		return encoded
+	}
+	return json.RawMessage(setting.Value)
+}
+
+// settingTypedValue mengubah value tersimpan menjadi nilai JSON bertipe untuk response
+func settingTypedValue(setting *model.Setting) interface{} {
+	if setting.DataType == model.SettingTypeString {
+		return setting.Value
+	}
+	if setting.Value == "" {
+		return nil
+	}
+	if json.Valid([]byte(setting.Value)) {
+		return json.RawMessage(setting.Value)
+	}
+	return setting.Value
 }
 
 // ============================
 // HELPER FUNCTIONS
 // ============================
 
+func convertPreviewLinkToResponse(link *model.PreviewLink) *model.PreviewLinkResponse {
+	return &model.PreviewLinkResponse{
+		ID:         link.ID,
+		EntityType: link.EntityType,
+		EntityID:   link.EntityID,
+		Token:      utils.SignPreviewToken(link.ID, link.EntityType, link.EntityID, link.ExpiresAt),
+		ExpiresAt:  link.ExpiresAt,
+		CreatedBy:  link.CreatedBy,
+		CreatedAt:  link.CreatedAt,
+	}
+}
+
 func convertEducationToResponse(edu *model.Education) *model.EducationResponse {
 	var achievements []model.AchievementResponse
 	for _, ach := range edu.Achievements {
@@ -1444,12 +4842,21 @@ func convertEducationToResponse(edu *model.Education) *model.EducationResponse {
 		})
 	}
 
+	var duration string
+	if months, known := educationRange(edu).Months(); known {
+		duration = utils.FormatMonthDuration(months)
+	}
+
 	return &model.EducationResponse{
 		ID:           edu.ID,
 		School:       edu.School,
 		Major:        edu.Major,
 		StartYear:    edu.StartYear,
 		EndYear:      edu.EndYear,
+		StartDate:    utils.FormatMonth(edu.StartDate),
+		EndDate:      utils.FormatMonth(edu.EndDate),
+		Ongoing:      edu.IsCurrent,
+		Duration:     duration,
 		Description:  edu.Description,
 		Degree:       edu.Degree,
 		DisplayOrder: edu.DisplayOrder,
@@ -1497,6 +4904,61 @@ func convertBlogToResponse(post *model.BlogPost) *model.BlogPostResponse {
 		Tags:          tags,
 		CreatedAt:     post.CreatedAt,
 		UpdatedAt:     post.UpdatedAt,
+
+		CommentCount:           post.CommentCount,
+		CommentsEnabled:        post.CommentsEnabled,
+		CommentsCloseAfterDays: post.CommentsCloseAfterDays,
+		CommentsOpen:           post.CommentsOpen(time.Now()),
+	}
+}
+
+// buildCommentTree menyusun komentar flat menjadi thread berdasarkan parent_id.
+// Balasan yang parent-nya tidak ada di daftar (misal belum approved) tidak ditampilkan.
+func buildCommentTree(comments []model.BlogComment) []model.CommentResponse {
+	childrenOf := make(map[uuid.UUID][]model.BlogComment)
+	var roots []model.BlogComment
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			roots = append(roots, comment)
+			continue
+		}
+		childrenOf[*comment.ParentID] = append(childrenOf[*comment.ParentID], comment)
+	}
+
+	var build func(comment model.BlogComment) model.CommentResponse
+	build = func(comment model.BlogComment) model.CommentResponse {
+		response := model.CommentResponse{
+			ID:         comment.ID,
+			PostID:     comment.PostID,
+			ParentID:   comment.ParentID,
+			AuthorName: comment.AuthorName,
+			Content:    comment.Content,
+			CreatedAt:  comment.CreatedAt,
+		}
+		for _, child := range childrenOf[comment.ID] {
+			response.Replies = append(response.Replies, build(child))
+		}
+		return response
+	}
+
+	responses := []model.CommentResponse{}
+	for _, root := range roots {
+		responses = append(responses, build(root))
+	}
+	return responses
+}
+
+func convertCommentToAdminResponse(comment *model.BlogComment) *model.CommentResponse {
+	return &model.CommentResponse{
+		ID:          comment.ID,
+		PostID:      comment.PostID,
+		ParentID:    comment.ParentID,
+		AuthorName:  comment.AuthorName,
+		AuthorEmail: comment.AuthorEmail,
+		Content:     comment.Content,
+		Status:      comment.Status,
+		IPAddress:   comment.IPAddress,
+		CreatedAt:   comment.CreatedAt,
 	}
 }
 
@@ -1525,14 +4987,40 @@ func convertSocialLinkToResponse(link *model.SocialLink) *model.SocialLinkRespon
 	}
 }
 
+func toSectionBlockResponse(block *model.SectionBlock) *model.SectionBlockResponse {
+	return &model.SectionBlockResponse{
+		ID:           block.ID,
+		SectionID:    block.SectionID,
+		Type:         block.Type,
+		Payload:      json.RawMessage(block.Payload),
+		DisplayOrder: block.DisplayOrder,
+		IsActive:     block.IsActive,
+		CreatedAt:    block.CreatedAt,
+		UpdatedAt:    block.UpdatedAt,
+	}
+}
+
 func convertSettingToResponse(setting *model.Setting) *model.SettingResponse {
-	return &model.SettingResponse{
+	response := &model.SettingResponse{
 		ID:          setting.ID,
 		Key:         setting.Key,
-		Value:       setting.Value,
+		Value:       settingTypedValue(setting),
 		DataType:    setting.DataType,
 		Description: setting.Description,
+		IsPublic:    setting.IsPublic,
 		CreatedAt:   setting.CreatedAt,
 		UpdatedAt:   setting.UpdatedAt,
 	}
+	if setting.Schema != "" {
+		response.Schema = json.RawMessage(setting.Schema)
+	}
+	return response
+}
+
+func convertSettingsToResponses(settings []model.Setting) []model.SettingResponse {
+	responses := make([]model.SettingResponse, 0, len(settings))
+	for i := range settings {
+		responses = append(responses, *convertSettingToResponse(&settings[i]))
+	}
+	return responses
 }
@@ -21,12 +21,28 @@ type Experience struct {
 	DisplayOrder int       `json:"display_order" gorm:"type:integer;default:0"`
 	CreatedAt    time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt    time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Tanggal presisi bulan (selalu tanggal 1), EndDate nil berarti masih bekerja di sini jika CurrentJob.
+	// StartYears/EndYears tetap diisi sebagai teks tampilan untuk client lama.
+	StartDate *time.Time `json:"start_date" gorm:"column:start_date;type:date"`
+	EndDate   *time.Time `json:"end_date" gorm:"column:end_date;type:date"`
 }
 
 type ExperienceWithRelations struct {
 	Experience
 	Responsibilities []ExperienceResponsibility `json:"responsibilities"`
 	Skills           []ExperienceSkill          `json:"skills"`
+	Projects         []ExperienceProject        `json:"projects"`
+}
+
+// ExperienceProject project yang dibuat saat bekerja di experience ini (portfolio_projects.experience_id)
+type ExperienceProject struct {
+	ExperienceID uuid.UUID `json:"-"`
+	ID           uuid.UUID `json:"id"`
+	Title        string    `json:"title"`
+	Slug         string    `json:"slug"`
+	ImageURL     string    `json:"image_url"`
+	Status       string    `json:"status"`
 }
 
 func (Experience) TableName() string {
@@ -53,9 +69,12 @@ func (ExperienceResponsibility) TableName() string {
 // SKILL MODEL - PERBAIKAN: Hapus CreatedAt
 // ============================
 
+// ExperienceSkill relasi experience ke katalog portfolio_skills.
+// SkillName hanya dibaca lewat join agar rename skill langsung terlihat di semua experience.
 type ExperienceSkill struct {
 	ExperienceID uuid.UUID `json:"experience_id" gorm:"type:uuid;primaryKey"`
-	SkillName    string    `json:"skill_name" gorm:"type:varchar(100);primaryKey"`
+	SkillID      uuid.UUID `json:"skill_id" gorm:"type:uuid;primaryKey"`
+	SkillName    string    `json:"skill_name" gorm:"->;-:migration"`
 	// HAPUS CreatedAt karena tidak ada di table database
 }
 
@@ -71,33 +90,60 @@ type ExperienceRequest struct {
 	Title            string                  `json:"title" binding:"required"`
 	Company          string                  `json:"company" binding:"required"`
 	Location         string                  `json:"location" binding:"required"`
-	StartYears       string                  `json:"start_year" binding:"required"`
-	EndYears         string                  `json:"end_year" binding:"required"`
+	StartDate        string                  `json:"start_date"` // YYYY-MM
+	EndDate          string                  `json:"end_date"`   // YYYY-MM, kosong jika current_job
+	StartYears       string                  `json:"start_year"` // Format lama, dipakai jika start_date kosong
+	EndYears         string                  `json:"end_year"`
 	CurrentJob       bool                    `json:"current_job"`
 	DisplayOrder     int                     `json:"display_order"`
 	Responsibilities []ResponsibilityRequest `json:"responsibilities"`
 	Skills           []SkillRequest          `json:"skills"`
 }
 
+// ExperienceUpdateRequest: responsibilities/skills yang tidak dikirim (null) tidak diubah
 type ExperienceUpdateRequest struct {
 	Title            string                  `json:"title"`
 	Company          string                  `json:"company"`
 	Location         string                  `json:"location"`
+	StartDate        string                  `json:"start_date"`
+	EndDate          string                  `json:"end_date"`
 	StartYears       string                  `json:"start_year"`
 	EndYears         string                  `json:"end_year"`
-	CurrentJob       bool                    `json:"current_job"`
+	CurrentJob       *bool                   `json:"current_job"` // nil mempertahankan status yang tersimpan
 	DisplayOrder     int                     `json:"display_order"`
 	Responsibilities []ResponsibilityRequest `json:"responsibilities"`
 	Skills           []SkillRequest          `json:"skills"`
 }
 
+// ResponsibilityRequest pada update experience: isi id untuk mengubah responsibility yang sudah ada
+// (ID tetap), kosongkan untuk menambah baru. Responsibility yang tidak disebut akan dihapus.
 type ResponsibilityRequest struct {
+	ID           *uuid.UUID `json:"id"`
+	Description  string     `json:"description" binding:"required"`
+	DisplayOrder int        `json:"display_order"`
+}
+
+// ResponsibilityCreateRequest untuk POST /experiences/:id/responsibilities,
+// display_order kosong berarti ditaruh paling akhir
+type ResponsibilityCreateRequest struct {
 	Description  string `json:"description" binding:"required"`
-	DisplayOrder int    `json:"display_order"`
+	DisplayOrder *int   `json:"display_order"`
+}
+
+type ResponsibilityUpdateRequest struct {
+	Description  *string `json:"description" binding:"omitempty,min=1"`
+	DisplayOrder *int    `json:"display_order"`
 }
 
+type ResponsibilityOrderRequest struct {
+	IDs []uuid.UUID `json:"ids" binding:"required,min=1"`
+}
+
+// SkillRequest cukup salah satu: skill_id dari katalog, atau skill_name yang dicocokkan
+// tanpa membedakan huruf besar/kecil dan dibuat otomatis jika belum ada
 type SkillRequest struct {
-	SkillName string `json:"skill_name" binding:"required"`
+	SkillID   *uuid.UUID `json:"skill_id"`
+	SkillName string     `json:"skill_name"`
 }
 
 // ============================
@@ -111,10 +157,15 @@ type ExperienceResponse struct {
 	Location         string                   `json:"location"`
 	StartYears       string                   `json:"start_year"`
 	EndYears         string                   `json:"end_year"`
+	StartDate        *string                  `json:"start_date"`
+	EndDate          *string                  `json:"end_date"`
+	DurationMonths   int                      `json:"duration_months"`
+	Duration         string                   `json:"duration"` // contoh: "2 yrs 3 mos"
 	CurrentJob       bool                     `json:"current_job"`
 	DisplayOrder     int                      `json:"display_order"`
 	Responsibilities []ResponsibilityResponse `json:"responsibilities"`
 	Skills           []SkillResponse          `json:"skills"`
+	Projects         []ExperienceProject      `json:"projects"`
 	CreatedAt        time.Time                `json:"created_at"`
 	UpdatedAt        time.Time                `json:"updated_at"`
 }
@@ -129,6 +180,16 @@ type ResponsibilityResponse struct {
 
 type SkillResponse struct {
 	ExperienceID uuid.UUID `json:"experience_id"`
+	SkillID      uuid.UUID `json:"skill_id"`
 	SkillName    string    `json:"skill_name"`
 	// HAPUS CreatedAt karena tidak ada di table database
 }
+
+// ExperienceSummary total lama pengalaman kerja, periode yang tumpang tindih hanya dihitung sekali
+type ExperienceSummary struct {
+	TotalMonths     int     `json:"total_months"`
+	TotalYears      float64 `json:"total_years"`
+	TotalExperience string  `json:"total_experience"`
+	Positions       int     `json:"positions"`
+	FirstStartDate  *string `json:"first_start_date"`
+}
@@ -1,19 +1,38 @@
 package repo
 
 import (
+	"errors"
+	"fmt"
 	"gintugas/modules/components/experiences/model"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// ErrInvalidInput menandai kesalahan input (referensi atau urutan yang tidak valid), bukan kegagalan database
+var ErrInvalidInput = errors.New("input tidak valid")
+
 type ExperiencesRepository interface {
 	CreateExperienceWithRelations(experience *model.ExperienceWithRelations) error
 	GetExperienceByIDWithRelations(experienceID uuid.UUID) (*model.ExperienceWithRelations, error)
 	UpdateExperienceWithRelations(experience *model.ExperienceWithRelations) error
 	DeleteExperienceWithRelations(experienceID uuid.UUID) error
 	GetAllExperiencesWithRelations() ([]model.ExperienceWithRelations, error)
+
+	// Sub-resource: satu responsibility/skill tanpa mengirim ulang seluruh experience
+	GetResponsibilities(experienceID uuid.UUID) ([]model.ExperienceResponsibility, error)
+	GetResponsibilityByID(experienceID, responsibilityID uuid.UUID) (*model.ExperienceResponsibility, error)
+	CreateResponsibility(responsibility *model.ExperienceResponsibility, appendToEnd bool) error
+	UpdateResponsibility(responsibility *model.ExperienceResponsibility) error
+	DeleteResponsibility(experienceID, responsibilityID uuid.UUID) error
+	ReorderResponsibilities(experienceID uuid.UUID, ids []uuid.UUID) ([]model.ExperienceResponsibility, error)
+	AddSkill(experienceID uuid.UUID, skill model.ExperienceSkill) (*model.ExperienceSkill, error)
+	RemoveSkill(experienceID, skillID uuid.UUID) error
+
+	GetProjectsForExperiences(experienceIDs []uuid.UUID, publishedOnly bool) (map[uuid.UUID][]model.ExperienceProject, error)
 }
 
 type experienceRepository struct {
@@ -50,25 +69,89 @@ func (r *experienceRepository) CreateExperienceWithRelations(experience *model.E
 		}
 
 		// Create skills
-		if len(experience.Skills) > 0 {
-			skills := make([]model.ExperienceSkill, len(experience.Skills))
-			for i, skill := range experience.Skills {
-				skills[i] = model.ExperienceSkill{
-					ExperienceID: experience.ID,
-					SkillName:    skill.SkillName,
-				}
+		skills, err := resolveSkillsTx(tx, experience.ID, experience.Skills)
+		if err != nil {
+			return err
+		}
+		experience.Skills = skills
+		return createSkillsTx(tx, skills)
+	})
+}
+
+// resolveSkillsTx mengubah request skill menjadi relasi ke portfolio_skills. Skill dengan ID harus sudah ada,
+// skill dengan nama dicocokkan tanpa membedakan huruf besar/kecil dan dibuat jika belum ada.
+func resolveSkillsTx(tx *gorm.DB, experienceID uuid.UUID, requested []model.ExperienceSkill) ([]model.ExperienceSkill, error) {
+	var skills []model.ExperienceSkill
+	seen := make(map[uuid.UUID]bool)
+
+	for _, req := range requested {
+		var catalog struct {
+			ID   uuid.UUID
+			Name string
+		}
+
+		if req.SkillID != uuid.Nil {
+			err := tx.Table("portfolio_skills").Select("id, name").Where("id = ?", req.SkillID).Take(&catalog).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: skill %s tidak ditemukan", ErrInvalidInput, req.SkillID)
 			}
-			if err := tx.Clauses(clause.OnConflict{
-				Columns:   []clause.Column{{Name: "experience_id"}, {Name: "skill_name"}},
-				DoNothing: true,
-			}).Create(&skills).Error; err != nil {
-				return err
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			name := strings.TrimSpace(req.SkillName)
+			if name == "" {
+				return nil, fmt.Errorf("%w: skill_id atau skill_name wajib diisi", ErrInvalidInput)
+			}
+
+			err := tx.Table("portfolio_skills").Select("id, name").
+				Where("LOWER(name) = LOWER(?)", name).
+				Order("created_at ASC, id ASC").
+				Take(&catalog).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				err = tx.Raw(`INSERT INTO portfolio_skills (name, value) VALUES (?, 0)
+					ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+					RETURNING id, name`, name).Scan(&catalog).Error
 			}
-			experience.Skills = skills
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if seen[catalog.ID] {
+			continue
 		}
+		seen[catalog.ID] = true
+		skills = append(skills, model.ExperienceSkill{
+			ExperienceID: experienceID,
+			SkillID:      catalog.ID,
+			SkillName:    catalog.Name,
+		})
+	}
 
+	return skills, nil
+}
+
+func createSkillsTx(tx *gorm.DB, skills []model.ExperienceSkill) error {
+	if len(skills) == 0 {
 		return nil
-	})
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "experience_id"}, {Name: "skill_id"}},
+		DoNothing: true,
+	}).Create(&skills).Error
+}
+
+// loadSkills mengambil skill beserta nama terbaru dari katalog portfolio_skills
+func loadSkills(db *gorm.DB, experienceIDs []uuid.UUID) ([]model.ExperienceSkill, error) {
+	var skills []model.ExperienceSkill
+	err := db.Table("experience_skills es").
+		Select("es.experience_id, es.skill_id, ps.name AS skill_name").
+		Joins("JOIN portfolio_skills ps ON ps.id = es.skill_id").
+		Where("es.experience_id IN ?", experienceIDs).
+		Order("ps.display_order ASC, ps.name ASC").
+		Scan(&skills).Error
+	return skills, err
 }
 
 func (r *experienceRepository) GetExperienceByIDWithRelations(experienceID uuid.UUID) (*model.ExperienceWithRelations, error) {
@@ -88,8 +171,7 @@ func (r *experienceRepository) GetExperienceByIDWithRelations(experienceID uuid.
 	}
 
 	// Load skills manually
-	var skills []model.ExperienceSkill
-	err = r.db.Where("experience_id = ?", experienceID).Find(&skills).Error
+	skills, err := loadSkills(r.db, []uuid.UUID{experienceID})
 	if err != nil {
 		return nil, err
 	}
@@ -129,8 +211,7 @@ func (r *experienceRepository) GetAllExperiencesWithRelations() ([]model.Experie
 	}
 
 	// Load all skills for these experiences
-	var allSkills []model.ExperienceSkill
-	err = r.db.Where("experience_id IN (?)", experienceIDs).Find(&allSkills).Error
+	allSkills, err := loadSkills(r.db, experienceIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -160,45 +241,269 @@ func (r *experienceRepository) GetAllExperiencesWithRelations() ([]model.Experie
 	return result, nil
 }
 
+// UpdateExperienceWithRelations menyinkronkan responsibilities dan skills: baris yang masih ada di-update
+// di tempat (ID tetap), yang baru di-insert, dan yang tidak disebut lagi dihapus
 func (r *experienceRepository) UpdateExperienceWithRelations(experience *model.ExperienceWithRelations) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockExperienceTx(tx, experience.ID); err != nil {
+			return err
+		}
+
 		// Update main experience
 		if err := tx.Save(&experience.Experience).Error; err != nil {
 			return err
 		}
 
-		// Delete existing responsibilities
-		if err := tx.Where("experience_id = ?", experience.ID).Delete(&model.ExperienceResponsibility{}).Error; err != nil {
+		keepIDs := make([]uuid.UUID, 0, len(experience.Responsibilities))
+		for i := range experience.Responsibilities {
+			resp := &experience.Responsibilities[i]
+			resp.ExperienceID = experience.ID
+
+			if resp.ID != uuid.Nil {
+				result := tx.Model(&model.ExperienceResponsibility{}).
+					Where("id = ? AND experience_id = ?", resp.ID, experience.ID).
+					Updates(map[string]interface{}{"description": resp.Description, "display_order": resp.DisplayOrder})
+				if result.Error != nil {
+					return result.Error
+				}
+				if result.RowsAffected == 0 {
+					return fmt.Errorf("%w: responsibility %s tidak ditemukan di experience ini", ErrInvalidInput, resp.ID)
+				}
+			} else if err := tx.Create(resp).Error; err != nil {
+				return err
+			}
+			keepIDs = append(keepIDs, resp.ID)
+		}
+
+		deleteResp := tx.Where("experience_id = ?", experience.ID)
+		if len(keepIDs) > 0 {
+			deleteResp = deleteResp.Where("id NOT IN ?", keepIDs)
+		}
+		if err := deleteResp.Delete(&model.ExperienceResponsibility{}).Error; err != nil {
 			return err
 		}
 
-		// Create new responsibilities
-		if len(experience.Responsibilities) > 0 {
-			for i := range experience.Responsibilities {
-				experience.Responsibilities[i].ExperienceID = experience.ID
-				experience.Responsibilities[i].ID = uuid.Nil // Let DB generate
+		skills, err := resolveSkillsTx(tx, experience.ID, experience.Skills)
+		if err != nil {
+			return err
+		}
+		experience.Skills = skills
+
+		deleteSkills := tx.Where("experience_id = ?", experience.ID)
+		if len(skills) > 0 {
+			skillIDs := make([]uuid.UUID, len(skills))
+			for i, skill := range skills {
+				skillIDs[i] = skill.SkillID
 			}
-			if err := tx.Create(&experience.Responsibilities).Error; err != nil {
+			deleteSkills = deleteSkills.Where("skill_id NOT IN ?", skillIDs)
+		}
+		if err := deleteSkills.Delete(&model.ExperienceSkill{}).Error; err != nil {
+			return err
+		}
+
+		return createSkillsTx(tx, skills)
+	})
+}
+
+// lockExperienceTx mengunci baris experience agar edit bersamaan pada sub-resource diproses bergantian
+func lockExperienceTx(tx *gorm.DB, experienceID uuid.UUID) error {
+	var experience model.Experience
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", experienceID).
+		First(&experience).Error
+}
+
+// touchExperienceTx memperbarui updated_at experience setelah sub-resource berubah
+func touchExperienceTx(tx *gorm.DB, experienceID uuid.UUID) error {
+	return tx.Model(&model.Experience{}).Where("id = ?", experienceID).UpdateColumn("updated_at", time.Now()).Error
+}
+
+// ============================
+// RESPONSIBILITIES
+// ============================
+
+func (r *experienceRepository) GetResponsibilities(experienceID uuid.UUID) ([]model.ExperienceResponsibility, error) {
+	var experience model.Experience
+	if err := r.db.Select("id").Where("id = ?", experienceID).First(&experience).Error; err != nil {
+		return nil, err
+	}
+
+	responsibilities := []model.ExperienceResponsibility{}
+	err := r.db.Where("experience_id = ?", experienceID).
+		Order("display_order ASC, created_at ASC").
+		Find(&responsibilities).Error
+	return responsibilities, err
+}
+
+func (r *experienceRepository) GetResponsibilityByID(experienceID, responsibilityID uuid.UUID) (*model.ExperienceResponsibility, error) {
+	var responsibility model.ExperienceResponsibility
+	err := r.db.Where("id = ? AND experience_id = ?", responsibilityID, experienceID).First(&responsibility).Error
+	return &responsibility, err
+}
+
+// CreateResponsibility menambah satu responsibility, appendToEnd mengisi display_order setelah urutan terakhir
+func (r *experienceRepository) CreateResponsibility(responsibility *model.ExperienceResponsibility, appendToEnd bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockExperienceTx(tx, responsibility.ExperienceID); err != nil {
+			return err
+		}
+
+		if appendToEnd {
+			var next int
+			if err := tx.Model(&model.ExperienceResponsibility{}).
+				Select("COALESCE(MAX(display_order) + 1, 0)").
+				Where("experience_id = ?", responsibility.ExperienceID).
+				Scan(&next).Error; err != nil {
 				return err
 			}
+			responsibility.DisplayOrder = next
+		}
+
+		if err := tx.Create(responsibility).Error; err != nil {
+			return err
+		}
+		return touchExperienceTx(tx, responsibility.ExperienceID)
+	})
+}
+
+func (r *experienceRepository) UpdateResponsibility(responsibility *model.ExperienceResponsibility) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockExperienceTx(tx, responsibility.ExperienceID); err != nil {
+			return err
 		}
 
-		// Delete existing skills
-		if err := tx.Where("experience_id = ?", experience.ID).Delete(&model.ExperienceSkill{}).Error; err != nil {
+		result := tx.Model(&model.ExperienceResponsibility{}).
+			Where("id = ? AND experience_id = ?", responsibility.ID, responsibility.ExperienceID).
+			Updates(map[string]interface{}{
+				"description":   responsibility.Description,
+				"display_order": responsibility.DisplayOrder,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return touchExperienceTx(tx, responsibility.ExperienceID)
+	})
+}
+
+func (r *experienceRepository) DeleteResponsibility(experienceID, responsibilityID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockExperienceTx(tx, experienceID); err != nil {
 			return err
 		}
 
-		// Create new skills
-		if len(experience.Skills) > 0 {
-			for i := range experience.Skills {
-				experience.Skills[i].ExperienceID = experience.ID
+		result := tx.Where("id = ? AND experience_id = ?", responsibilityID, experienceID).
+			Delete(&model.ExperienceResponsibility{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return touchExperienceTx(tx, experienceID)
+	})
+}
+
+// ReorderResponsibilities menomori ulang display_order mulai dari 0 sesuai urutan ID.
+// Responsibility yang tidak disebut menyusul di belakang dengan urutan lama.
+func (r *experienceRepository) ReorderResponsibilities(experienceID uuid.UUID, ids []uuid.UUID) ([]model.ExperienceResponsibility, error) {
+	var responsibilities []model.ExperienceResponsibility
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockExperienceTx(tx, experienceID); err != nil {
+			return err
+		}
+
+		var current []model.ExperienceResponsibility
+		if err := tx.Where("experience_id = ?", experienceID).
+			Order("display_order ASC, created_at ASC").
+			Find(&current).Error; err != nil {
+			return err
+		}
+
+		byID := make(map[uuid.UUID]model.ExperienceResponsibility, len(current))
+		for _, resp := range current {
+			byID[resp.ID] = resp
+		}
+
+		listed := make(map[uuid.UUID]bool, len(ids))
+		ordered := make([]model.ExperienceResponsibility, 0, len(current))
+		for _, id := range ids {
+			resp, exists := byID[id]
+			if !exists {
+				return fmt.Errorf("%w: responsibility %s tidak ditemukan di experience ini", ErrInvalidInput, id)
 			}
-			if err := tx.Create(&experience.Skills).Error; err != nil {
-				return err
+			if listed[id] {
+				return fmt.Errorf("%w: responsibility %s disebut lebih dari sekali", ErrInvalidInput, id)
+			}
+			listed[id] = true
+			ordered = append(ordered, resp)
+		}
+		for _, resp := range current {
+			if !listed[resp.ID] {
+				ordered = append(ordered, resp)
 			}
 		}
 
-		return nil
+		for i := range ordered {
+			if ordered[i].DisplayOrder != i {
+				if err := tx.Model(&model.ExperienceResponsibility{}).
+					Where("id = ?", ordered[i].ID).
+					UpdateColumn("display_order", i).Error; err != nil {
+					return err
+				}
+				ordered[i].DisplayOrder = i
+			}
+		}
+
+		responsibilities = ordered
+		return touchExperienceTx(tx, experienceID)
+	})
+	return responsibilities, err
+}
+
+// ============================
+// SKILLS
+// ============================
+
+// AddSkill menautkan satu skill (berdasarkan ID atau nama) ke experience
+func (r *experienceRepository) AddSkill(experienceID uuid.UUID, skill model.ExperienceSkill) (*model.ExperienceSkill, error) {
+	var added model.ExperienceSkill
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockExperienceTx(tx, experienceID); err != nil {
+			return err
+		}
+
+		skills, err := resolveSkillsTx(tx, experienceID, []model.ExperienceSkill{skill})
+		if err != nil {
+			return err
+		}
+		if err := createSkillsTx(tx, skills); err != nil {
+			return err
+		}
+
+		added = skills[0]
+		return touchExperienceTx(tx, experienceID)
+	})
+	return &added, err
+}
+
+func (r *experienceRepository) RemoveSkill(experienceID, skillID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockExperienceTx(tx, experienceID); err != nil {
+			return err
+		}
+
+		result := tx.Where("experience_id = ? AND skill_id = ?", experienceID, skillID).Delete(&model.ExperienceSkill{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return touchExperienceTx(tx, experienceID)
 	})
 }
 
@@ -218,3 +523,31 @@ func (r *experienceRepository) DeleteExperienceWithRelations(experienceID uuid.U
 		return tx.Where("id = ?", experienceID).Delete(&model.Experience{}).Error
 	})
 }
+
+// ============================
+// PROJECTS
+// ============================
+
+// GetProjectsForExperiences mengelompokkan project per experience, publishedOnly menyembunyikan draft
+func (r *experienceRepository) GetProjectsForExperiences(experienceIDs []uuid.UUID, publishedOnly bool) (map[uuid.UUID][]model.ExperienceProject, error) {
+	result := make(map[uuid.UUID][]model.ExperienceProject)
+	if len(experienceIDs) == 0 {
+		return result, nil
+	}
+
+	var projects []model.ExperienceProject
+	query := r.db.Table("portfolio_projects").
+		Select("experience_id, id, title, slug, COALESCE(image_url, '') AS image_url, status").
+		Where("experience_id IN ?", experienceIDs)
+	if publishedOnly {
+		query = query.Where("status = ?", "published")
+	}
+	if err := query.Order("display_order ASC, created_at DESC").Scan(&projects).Error; err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		result[project.ExperienceID] = append(result[project.ExperienceID], project)
+	}
+	return result, nil
+}
@@ -2,8 +2,12 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"gintugas/modules/components/experiences/model"
 	"gintugas/modules/components/experiences/repo"
+	"gintugas/modules/utils"
+	"math"
+	"sort"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,6 +20,15 @@ type ExperiencesService interface {
 	UpdateExperienceWithRelations(ctx *gin.Context) (*model.ExperienceResponse, error)
 	DeleteExperienceWithRelations(ctx *gin.Context) error
 	GetAllExperiencesWithRelations(ctx *gin.Context) ([]model.ExperienceResponse, error)
+	GetExperienceSummary(ctx *gin.Context) (*model.ExperienceSummary, error)
+
+	GetResponsibilities(ctx *gin.Context) ([]model.ResponsibilityResponse, error)
+	CreateResponsibility(ctx *gin.Context) (*model.ResponsibilityResponse, error)
+	UpdateResponsibility(ctx *gin.Context) (*model.ResponsibilityResponse, error)
+	DeleteResponsibility(ctx *gin.Context) error
+	ReorderResponsibilities(ctx *gin.Context) ([]model.ResponsibilityResponse, error)
+	AddSkill(ctx *gin.Context) (*model.SkillResponse, error)
+	RemoveSkill(ctx *gin.Context) error
 }
 
 type experiencesService struct {
@@ -39,13 +52,15 @@ func (s *experiencesService) CreateExperienceWithRelations(ctx *gin.Context) (*m
 			Title:        experienceReq.Title,
 			Company:      experienceReq.Company,
 			Location:     experienceReq.Location,
-			StartYears:   experienceReq.StartYears,
-			EndYears:     experienceReq.EndYears,
-			CurrentJob:   experienceReq.CurrentJob,
 			DisplayOrder: experienceReq.DisplayOrder,
 		},
 	}
 
+	if err := applyExperienceDates(&experience.Experience, experienceReq.StartDate, experienceReq.StartYears,
+		experienceReq.EndDate, experienceReq.EndYears, &experienceReq.CurrentJob); err != nil {
+		return nil, err
+	}
+
 	// Convert responsibilities
 	for _, respReq := range experienceReq.Responsibilities {
 		experience.Responsibilities = append(experience.Responsibilities, model.ExperienceResponsibility{
@@ -56,9 +71,7 @@ func (s *experiencesService) CreateExperienceWithRelations(ctx *gin.Context) (*m
 
 	// Convert skills
 	for _, skillReq := range experienceReq.Skills {
-		experience.Skills = append(experience.Skills, model.ExperienceSkill{
-			SkillName: skillReq.SkillName,
-		})
+		experience.Skills = append(experience.Skills, toExperienceSkill(skillReq))
 	}
 
 	if err := s.experienceRepo.CreateExperienceWithRelations(experience); err != nil {
@@ -80,7 +93,12 @@ func (s *experiencesService) GetExperienceByIDWithRelations(ctx *gin.Context) (*
 		return nil, err
 	}
 
-	return s.convertToResponse(experience), nil
+	experiences := []model.ExperienceWithRelations{*experience}
+	if err := s.attachProjects(ctx, experiences); err != nil {
+		return nil, err
+	}
+
+	return s.convertToResponse(&experiences[0]), nil
 }
 
 func (s *experiencesService) UpdateExperienceWithRelations(ctx *gin.Context) (*model.ExperienceResponse, error) {
@@ -111,31 +129,34 @@ func (s *experiencesService) UpdateExperienceWithRelations(ctx *gin.Context) (*m
 	if experienceReq.Location != "" {
 		existingExperience.Location = experienceReq.Location
 	}
-	if experienceReq.StartYears != "" {
-		existingExperience.StartYears = experienceReq.StartYears
-	}
-	if experienceReq.EndYears != "" {
-		existingExperience.EndYears = experienceReq.EndYears
+	if err := applyExperienceDates(&existingExperience.Experience, experienceReq.StartDate, experienceReq.StartYears,
+		experienceReq.EndDate, experienceReq.EndYears, experienceReq.CurrentJob); err != nil {
+		return nil, err
 	}
-	existingExperience.CurrentJob = experienceReq.CurrentJob
 	existingExperience.DisplayOrder = experienceReq.DisplayOrder
 	existingExperience.UpdatedAt = time.Now()
 
-	// Update responsibilities
-	existingExperience.Responsibilities = nil
-	for _, respReq := range experienceReq.Responsibilities {
-		existingExperience.Responsibilities = append(existingExperience.Responsibilities, model.ExperienceResponsibility{
-			Description:  respReq.Description,
-			DisplayOrder: respReq.DisplayOrder,
-		})
+	// Update responsibilities, field yang tidak dikirim mempertahankan data lama
+	if experienceReq.Responsibilities != nil {
+		existingExperience.Responsibilities = make([]model.ExperienceResponsibility, 0, len(experienceReq.Responsibilities))
+		for _, respReq := range experienceReq.Responsibilities {
+			resp := model.ExperienceResponsibility{
+				Description:  respReq.Description,
+				DisplayOrder: respReq.DisplayOrder,
+			}
+			if respReq.ID != nil {
+				resp.ID = *respReq.ID
+			}
+			existingExperience.Responsibilities = append(existingExperience.Responsibilities, resp)
+		}
 	}
 
 	// Update skills
-	existingExperience.Skills = nil
-	for _, skillReq := range experienceReq.Skills {
-		existingExperience.Skills = append(existingExperience.Skills, model.ExperienceSkill{
-			SkillName: skillReq.SkillName,
-		})
+	if experienceReq.Skills != nil {
+		existingExperience.Skills = make([]model.ExperienceSkill, 0, len(experienceReq.Skills))
+		for _, skillReq := range experienceReq.Skills {
+			existingExperience.Skills = append(existingExperience.Skills, toExperienceSkill(skillReq))
+		}
 	}
 
 	if err := s.experienceRepo.UpdateExperienceWithRelations(existingExperience); err != nil {
@@ -161,6 +182,15 @@ func (s *experiencesService) GetAllExperiencesWithRelations(ctx *gin.Context) ([
 		return nil, err
 	}
 
+	// ?sort=date: urut kronologis terbaru dulu, default tetap display_order
+	if ctx.Query("sort") == "date" {
+		sortExperiencesByDate(experiences)
+	}
+
+	if err := s.attachProjects(ctx, experiences); err != nil {
+		return nil, err
+	}
+
 	var responses []model.ExperienceResponse
 	for _, exp := range experiences {
 		responses = append(responses, *s.convertToResponse(&exp))
@@ -187,10 +217,22 @@ func (s *experiencesService) convertToResponse(experience *model.ExperienceWithR
 	for _, skill := range experience.Skills {
 		skillResponses = append(skillResponses, model.SkillResponse{
 			ExperienceID: skill.ExperienceID,
+			SkillID:      skill.SkillID,
 			SkillName:    skill.SkillName,
 		})
 	}
 
+	projects := experience.Projects
+	if projects == nil {
+		projects = []model.ExperienceProject{}
+	}
+
+	var duration string
+	durationMonths, known := experienceRange(&experience.Experience).Months()
+	if known {
+		duration = utils.FormatMonthDuration(durationMonths)
+	}
+
 	return &model.ExperienceResponse{
 		ID:               experience.ID,
 		Title:            experience.Title,
@@ -198,11 +240,302 @@ func (s *experiencesService) convertToResponse(experience *model.ExperienceWithR
 		Location:         experience.Location,
 		StartYears:       experience.StartYears,
 		EndYears:         experience.EndYears,
+		StartDate:        utils.FormatMonth(experience.StartDate),
+		EndDate:          utils.FormatMonth(experience.EndDate),
+		DurationMonths:   durationMonths,
+		Duration:         duration,
 		CurrentJob:       experience.CurrentJob,
 		DisplayOrder:     experience.DisplayOrder,
 		Responsibilities: respResponses,
 		Skills:           skillResponses,
+		Projects:         projects,
 		CreatedAt:        experience.CreatedAt,
 		UpdatedAt:        experience.UpdatedAt,
 	}
 }
+
+// attachProjects mengisi project tiap experience. Project draft hanya terlihat oleh admin.
+func (s *experiencesService) attachProjects(ctx *gin.Context, experiences []model.ExperienceWithRelations) error {
+	ids := make([]uuid.UUID, len(experiences))
+	for i := range experiences {
+		ids[i] = experiences[i].ID
+	}
+
+	projects, err := s.experienceRepo.GetProjectsForExperiences(ids, ctx.GetString("user_role") != "admin")
+	if err != nil {
+		return err
+	}
+
+	for i := range experiences {
+		experiences[i].Projects = projects[experiences[i].ID]
+	}
+	return nil
+}
+
+// GetExperienceSummary menghitung total pengalaman kerja dari semua posisi yang punya tanggal
+func (s *experiencesService) GetExperienceSummary(ctx *gin.Context) (*model.ExperienceSummary, error) {
+	experiences, err := s.experienceRepo.GetAllExperiencesWithRelations()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.ExperienceSummary{}
+	var ranges []utils.MonthRange
+	var firstStart *time.Time
+	for _, exp := range experiences {
+		if exp.StartDate == nil {
+			continue
+		}
+		ranges = append(ranges, experienceRange(&exp.Experience))
+		if firstStart == nil || exp.StartDate.Before(*firstStart) {
+			firstStart = exp.StartDate
+		}
+	}
+
+	summary.Positions = len(ranges)
+	summary.TotalMonths = utils.TotalMonths(ranges)
+	summary.TotalYears = math.Round(float64(summary.TotalMonths)/12*10) / 10
+	summary.TotalExperience = utils.FormatMonthDuration(summary.TotalMonths)
+	summary.FirstStartDate = utils.FormatMonth(firstStart)
+
+	return summary, nil
+}
+
+// applyExperienceDates mengisi start/end date dari request. start_date/end_date (YYYY-MM) diutamakan,
+// start_year/end_year lama tetap diterima. Field kosong mempertahankan nilai yang sudah ada;
+// currentJob nil mempertahankan current_job kecuali end date baru dikirim.
+func applyExperienceDates(exp *model.Experience, startDate, startYear, endDate, endYear string, currentJobInput *bool) error {
+	if startDate != "" || startYear != "" {
+		start, label, err := utils.ParseMonthInput(startDate, startYear, false)
+		if err != nil {
+			return err
+		}
+		exp.StartDate = &start
+		exp.StartYears = label
+	}
+	if exp.StartDate == nil {
+		return errors.New("start_date wajib diisi (format YYYY-MM)")
+	}
+
+	currentJob := exp.CurrentJob && endDate == "" && endYear == ""
+	if currentJobInput != nil {
+		currentJob = *currentJobInput
+	}
+	if utils.IsOngoingMonthText(endDate) || utils.IsOngoingMonthText(endYear) {
+		currentJob = true
+	}
+
+	if currentJob {
+		exp.CurrentJob = true
+		exp.EndDate = nil
+		exp.EndYears = "Present"
+		return nil
+	}
+
+	exp.CurrentJob = false
+	if endDate != "" || endYear != "" {
+		end, label, err := utils.ParseMonthInput(endDate, endYear, true)
+		if err != nil {
+			return err
+		}
+		exp.EndDate = &end
+		exp.EndYears = label
+	}
+	if exp.EndDate == nil {
+		return errors.New("end_date wajib diisi kecuali current_job bernilai true")
+	}
+
+	return utils.ValidateMonthRange(*exp.StartDate, exp.EndDate)
+}
+
+// experienceRange periode experience; end_date kosong hanya berarti masih berjalan jika current_job
+func experienceRange(exp *model.Experience) utils.MonthRange {
+	return utils.NewMonthRange(exp.StartDate, exp.EndDate, exp.CurrentJob)
+}
+
+// sortExperiencesByDate: pekerjaan saat ini dulu, lalu end date dan start date terbaru
+func sortExperiencesByDate(experiences []model.ExperienceWithRelations) {
+	sort.SliceStable(experiences, func(i, j int) bool {
+		return utils.NewerFirst(experienceRange(&experiences[i].Experience), experienceRange(&experiences[j].Experience))
+	})
+}
+
+// toExperienceSkill meneruskan skill_id atau skill_name, pencocokan ke katalog dilakukan repository
+func toExperienceSkill(req model.SkillRequest) model.ExperienceSkill {
+	skill := model.ExperienceSkill{SkillName: req.SkillName}
+	if req.SkillID != nil {
+		skill.SkillID = *req.SkillID
+	}
+	return skill
+}
+
+// ============================
+// RESPONSIBILITIES & SKILLS SUB-RESOURCE
+// ============================
+
+// ErrInvalidInput: parameter, body, atau referensi yang tidak valid (400)
+var ErrInvalidInput = repo.ErrInvalidInput
+
+func parseExperienceParam(ctx *gin.Context, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(ctx.Param(name))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: format %s tidak valid", ErrInvalidInput, name)
+	}
+	return id, nil
+}
+
+func (s *experiencesService) GetResponsibilities(ctx *gin.Context) ([]model.ResponsibilityResponse, error) {
+	experienceID, err := parseExperienceParam(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	responsibilities, err := s.experienceRepo.GetResponsibilities(experienceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResponsibilityResponses(responsibilities), nil
+}
+
+func (s *experiencesService) CreateResponsibility(ctx *gin.Context) (*model.ResponsibilityResponse, error) {
+	experienceID, err := parseExperienceParam(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var req model.ResponsibilityCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	responsibility := &model.ExperienceResponsibility{
+		ExperienceID: experienceID,
+		Description:  req.Description,
+	}
+	if req.DisplayOrder != nil {
+		responsibility.DisplayOrder = *req.DisplayOrder
+	}
+
+	if err := s.experienceRepo.CreateResponsibility(responsibility, req.DisplayOrder == nil); err != nil {
+		return nil, err
+	}
+
+	return &toResponsibilityResponses([]model.ExperienceResponsibility{*responsibility})[0], nil
+}
+
+func (s *experiencesService) UpdateResponsibility(ctx *gin.Context) (*model.ResponsibilityResponse, error) {
+	experienceID, err := parseExperienceParam(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	responsibilityID, err := parseExperienceParam(ctx, "responsibilityId")
+	if err != nil {
+		return nil, err
+	}
+
+	var req model.ResponsibilityUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	responsibility, err := s.experienceRepo.GetResponsibilityByID(experienceID, responsibilityID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Description != nil {
+		responsibility.Description = *req.Description
+	}
+	if req.DisplayOrder != nil {
+		responsibility.DisplayOrder = *req.DisplayOrder
+	}
+
+	if err := s.experienceRepo.UpdateResponsibility(responsibility); err != nil {
+		return nil, err
+	}
+
+	return &toResponsibilityResponses([]model.ExperienceResponsibility{*responsibility})[0], nil
+}
+
+func (s *experiencesService) DeleteResponsibility(ctx *gin.Context) error {
+	experienceID, err := parseExperienceParam(ctx, "id")
+	if err != nil {
+		return err
+	}
+	responsibilityID, err := parseExperienceParam(ctx, "responsibilityId")
+	if err != nil {
+		return err
+	}
+
+	return s.experienceRepo.DeleteResponsibility(experienceID, responsibilityID)
+}
+
+func (s *experiencesService) ReorderResponsibilities(ctx *gin.Context) ([]model.ResponsibilityResponse, error) {
+	experienceID, err := parseExperienceParam(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var req model.ResponsibilityOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	responsibilities, err := s.experienceRepo.ReorderResponsibilities(experienceID, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResponsibilityResponses(responsibilities), nil
+}
+
+func (s *experiencesService) AddSkill(ctx *gin.Context) (*model.SkillResponse, error) {
+	experienceID, err := parseExperienceParam(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var req model.SkillRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	skill, err := s.experienceRepo.AddSkill(experienceID, toExperienceSkill(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SkillResponse{
+		ExperienceID: skill.ExperienceID,
+		SkillID:      skill.SkillID,
+		SkillName:    skill.SkillName,
+	}, nil
+}
+
+func (s *experiencesService) RemoveSkill(ctx *gin.Context) error {
+	experienceID, err := parseExperienceParam(ctx, "id")
+	if err != nil {
+		return err
+	}
+	skillID, err := parseExperienceParam(ctx, "skillId")
+	if err != nil {
+		return err
+	}
+
+	return s.experienceRepo.RemoveSkill(experienceID, skillID)
+}
+
+func toResponsibilityResponses(responsibilities []model.ExperienceResponsibility) []model.ResponsibilityResponse {
+	responses := make([]model.ResponsibilityResponse, 0, len(responsibilities))
+	for _, resp := range responsibilities {
+		responses = append(responses, model.ResponsibilityResponse{
+			ID:           resp.ID,
+			ExperienceID: resp.ExperienceID,
+			Description:  resp.Description,
+			DisplayOrder: resp.DisplayOrder,
+			CreatedAt:    resp.CreatedAt,
+		})
+	}
+	return responses
+}
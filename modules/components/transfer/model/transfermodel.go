@@ -0,0 +1,114 @@
+package model
+
+import "time"
+
+// ============================
+// PORTFOLIO TRANSFER DOCUMENT
+// ============================
+
+const (
+	DocumentFormat  = "gintugas-portfolio"
+	DocumentVersion = 1
+)
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+const (
+	ImportModeMerge   = "merge"
+	ImportModeReplace = "replace"
+)
+
+// Row adalah satu baris tabel apa adanya (nama kolom -> nilai JSON)
+type Row map[string]interface{}
+
+// Document berisi seluruh entity portfolio beserta relasinya, dikelompokkan per tabel.
+// Schema adalah ID migrasi terakhir di database sumber, hanya sebagai informasi.
+type Document struct {
+	Format     string           `json:"format" yaml:"format"`
+	Version    int              `json:"version" yaml:"version"`
+	Schema     string           `json:"schema,omitempty" yaml:"schema,omitempty"`
+	ExportedAt time.Time        `json:"exported_at" yaml:"exported_at"`
+	Entities   map[string][]Row `json:"entities" yaml:"entities"`
+	Media      []MediaFile      `json:"media,omitempty" yaml:"media,omitempty"`
+}
+
+// MediaFile memetakan URL yang dipakai di entity ke file di dalam arsip zip
+type MediaFile struct {
+	URL  string `json:"url" yaml:"url"`
+	File string `json:"file" yaml:"file"`
+}
+
+// ============================
+// EXPORT / IMPORT OPTIONS
+// ============================
+
+type ExportOptions struct {
+	Format string
+	Media  bool // bundel file media ke dalam zip
+}
+
+// ExportFile adalah hasil export yang siap dikirim/disimpan
+type ExportFile struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+type ImportOptions struct {
+	Mode     string
+	DryRun   bool
+	FreshIDs bool // baris yang tidak cocok dengan data yang ada mendapat UUID baru
+}
+
+// ============================
+// IMPORT REPORT
+// ============================
+
+type ImportReport struct {
+	DryRun   bool            `json:"dry_run"`
+	Mode     string          `json:"mode"`
+	Schema   string          `json:"schema,omitempty"`
+	Tables   []TableReport   `json:"tables"`
+	Remapped []RemappedID    `json:"remapped"`
+	Media    []MediaReport   `json:"media,omitempty"`
+	Errors   []ImportProblem `json:"errors"`
+	Warnings []ImportProblem `json:"warnings"`
+}
+
+type TableReport struct {
+	Table   string `json:"table"`
+	Rows    int    `json:"rows"`
+	Created int    `json:"created"`
+	Updated int    `json:"updated"`
+	Skipped int    `json:"skipped"`
+	Deleted int64  `json:"deleted"`
+}
+
+// RemappedID mencatat ID dari dokumen yang diganti, karena cocok dengan data yang ada
+// (Key berisi natural key-nya) atau karena fresh_ids
+type RemappedID struct {
+	Table string `json:"table"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Key   string `json:"key,omitempty"`
+}
+
+type MediaReport struct {
+	From   string `json:"from"`
+	To     string `json:"to,omitempty"`
+	Status string `json:"status"` // uploaded, existing, planned
+}
+
+// ImportProblem menunjuk baris ke-Row (mulai dari 0) pada tabel, Row -1 berarti seluruh tabel/dokumen
+type ImportProblem struct {
+	Table   string `json:"table,omitempty"`
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+func (r *ImportReport) HasErrors() bool {
+	return len(r.Errors) > 0
+}
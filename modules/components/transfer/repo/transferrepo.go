@@ -0,0 +1,205 @@
+package repo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gintugas/modules/components/transfer/model"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ============================
+// TRANSFER REPOSITORY
+// ============================
+
+// Nama tabel dan kolom selalu berasal dari daftar tabel di service atau dari
+// information_schema, tidak pernah dari input dokumen secara langsung.
+type TransferRepository interface {
+	SchemaVersion() string
+	Columns(table string) (map[string]bool, error)
+	ExportRows(table, orderBy string) ([]model.Row, error)
+	FindIDByKey(table string, keyColumns []string, values []string) (string, error)
+	Exists(table, column, value string) (bool, error)
+	Upsert(table string, pk []string, columns []string, row []byte) (created bool, changed bool, err error)
+	DeleteExcept(table string, pk []string, keep []string) (int64, error)
+	SavePoint(name string) error
+	RollbackTo(name string) error
+	Transaction(fn func(tx TransferRepository) error) error
+}
+
+type transferRepository struct {
+	db *gorm.DB
+}
+
+func NewTransferRepository(db *gorm.DB) TransferRepository {
+	return &transferRepository{db: db}
+}
+
+// SchemaVersion mengembalikan ID migrasi terakhir yang sudah dijalankan, kosong jika tidak diketahui
+func (r *transferRepository) SchemaVersion() string {
+	var id string
+	if err := r.db.Raw(`SELECT id FROM gorp_migrations ORDER BY id DESC LIMIT 1`).Scan(&id).Error; err != nil {
+		return ""
+	}
+	return id
+}
+
+func (r *transferRepository) Columns(table string) (map[string]bool, error) {
+	var names []string
+	err := r.db.Raw(`
+		SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = ?`, table).
+		Scan(&names).Error
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]bool, len(names))
+	for _, name := range names {
+		columns[name] = true
+	}
+	return columns, nil
+}
+
+// ExportRows membaca seluruh baris lewat to_jsonb sehingga semua kolom ikut tanpa perlu model Go
+func (r *transferRepository) ExportRows(table, orderBy string) ([]model.Row, error) {
+	var raws []string
+	query := fmt.Sprintf(`SELECT to_jsonb(t)::text FROM %s t ORDER BY %s`, quoteIdent(table), orderBy)
+	if err := r.db.Raw(query).Scan(&raws).Error; err != nil {
+		return nil, fmt.Errorf("gagal membaca %s: %v", table, err)
+	}
+
+	rows := make([]model.Row, 0, len(raws))
+	for _, raw := range raws {
+		decoder := json.NewDecoder(bytes.NewReader([]byte(raw)))
+		decoder.UseNumber()
+		var row model.Row
+		if err := decoder.Decode(&row); err != nil {
+			return nil, fmt.Errorf("gagal membaca %s: %v", table, err)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// FindIDByKey mencari id baris dengan natural key yang sama (tidak case-sensitive)
+func (r *transferRepository) FindIDByKey(table string, keyColumns []string, values []string) (string, error) {
+	conditions := make([]string, len(keyColumns))
+	args := make([]interface{}, len(values))
+	for i, column := range keyColumns {
+		conditions[i] = fmt.Sprintf("LOWER(%s::text) = LOWER(?)", quoteIdent(column))
+		args[i] = values[i]
+	}
+
+	var ids []string
+	query := fmt.Sprintf(`SELECT id::text FROM %s WHERE %s LIMIT 1`, quoteIdent(table), strings.Join(conditions, " AND "))
+	if err := r.db.Raw(query, args...).Scan(&ids).Error; err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", nil
+	}
+	return ids[0], nil
+}
+
+// Exists membandingkan sebagai text agar nilai yang bukan UUID tidak membatalkan transaksi
+func (r *transferRepository) Exists(table, column, value string) (bool, error) {
+	var exists bool
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE %s::text = ?)`, quoteIdent(table), quoteIdent(column))
+	if err := r.db.Raw(query, value).Scan(&exists).Error; err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// Upsert menulis satu baris dari JSON lewat jsonb_populate_record, sehingga konversi tipe
+// (uuid, date, timestamp, boolean) dilakukan oleh Postgres. Tabel relasi tanpa kolom lain
+// memakai DO NOTHING: changed=false berarti relasinya sudah ada. Tabel lain hanya diupdate jika
+// ada kolom yang berbeda, jadi changed=false juga berarti baris yang ada sudah sama.
+func (r *transferRepository) Upsert(table string, pk []string, columns []string, row []byte) (bool, bool, error) {
+	quoted := quoteIdents(columns)
+	isPK := make(map[string]bool, len(pk))
+	for _, column := range pk {
+		isPK[column] = true
+	}
+
+	var updates, current, incoming []string
+	for _, column := range columns {
+		if !isPK[column] {
+			updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", quoteIdent(column), quoteIdent(column)))
+			current = append(current, quoteIdent(table)+"."+quoteIdent(column))
+			incoming = append(incoming, "EXCLUDED."+quoteIdent(column))
+		}
+	}
+	conflict := "DO NOTHING"
+	if len(updates) > 0 {
+		// Baris yang isinya sama tidak diupdate dan tidak dikembalikan, sehingga terhitung skipped
+		conflict = fmt.Sprintf("DO UPDATE SET %s WHERE (%s) IS DISTINCT FROM (%s)",
+			strings.Join(updates, ", "), strings.Join(current, ", "), strings.Join(incoming, ", "))
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s (%[2]s)
+		SELECT %[2]s FROM jsonb_populate_record(NULL::%[1]s, CAST(? AS jsonb))
+		ON CONFLICT (%[3]s) %[4]s
+		RETURNING (xmax = 0)`,
+		quoteIdent(table), strings.Join(quoted, ", "), strings.Join(quoteIdents(pk), ", "), conflict)
+
+	var created []bool
+	if err := r.db.Raw(query, string(row)).Scan(&created).Error; err != nil {
+		return false, false, err
+	}
+	if len(created) == 0 {
+		return false, false, nil
+	}
+	return created[0], true, nil
+}
+
+// DeleteExcept menghapus baris yang primary key-nya tidak ada di keep.
+// Primary key gabungan dibandingkan sebagai "a|b", sama dengan format dari service.
+func (r *transferRepository) DeleteExcept(table string, pk []string, keep []string) (int64, error) {
+	expr := quoteIdent(pk[0]) + "::text"
+	if len(pk) > 1 {
+		parts := make([]string, len(pk))
+		for i, column := range pk {
+			parts[i] = quoteIdent(column) + "::text"
+		}
+		expr = fmt.Sprintf("concat_ws('|', %s)", strings.Join(parts, ", "))
+	}
+
+	var result *gorm.DB
+	if len(keep) == 0 {
+		result = r.db.Exec(fmt.Sprintf(`DELETE FROM %s`, quoteIdent(table)))
+	} else {
+		result = r.db.Exec(fmt.Sprintf(`DELETE FROM %s WHERE %s NOT IN ?`, quoteIdent(table), expr), keep)
+	}
+	return result.RowsAffected, result.Error
+}
+
+func (r *transferRepository) SavePoint(name string) error {
+	return r.db.SavePoint(name).Error
+}
+
+func (r *transferRepository) RollbackTo(name string) error {
+	return r.db.RollbackTo(name).Error
+}
+
+func (r *transferRepository) Transaction(fn func(tx TransferRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&transferRepository{db: tx})
+	})
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteIdents(names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quoteIdent(name)
+	}
+	return quoted
+}
@@ -0,0 +1,946 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gintugas/modules/components/transfer/model"
+	"gintugas/modules/components/transfer/repo"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	ErrInvalidDocument   = errors.New("invalid portfolio document")
+	ErrUnsupportedFormat = errors.New("unsupported format")
+	ErrInvalidImportMode = errors.New("invalid import mode")
+	ErrImportRejected    = errors.New("import has validation errors, nothing was changed")
+	errDryRun            = errors.New("dry run")
+)
+
+const (
+	maxMediaFileSize = 50 << 20
+	savePointName    = "transfer_row"
+)
+
+// ============================
+// TABLE SPECS
+// ============================
+
+// tableSpec menjelaskan satu tabel yang ikut export/import.
+// Key adalah natural key untuk mencocokkan baris dengan data yang sudah ada (merge antar database),
+// Refs adalah foreign key (kolom -> tabel) yang ID-nya ikut dipetakan ulang.
+type tableSpec struct {
+	Name    string
+	PK      []string
+	Key     []string
+	Refs    map[string]string
+	Media   []string
+	OrderBy string
+}
+
+// Urutan mengikuti dependensi: tabel induk selalu sebelum tabel yang mereferensikannya.
+// Data operasional (user, pesan kontak, statistik blog, preview link, statistik GitHub) tidak ikut.
+var transferTables = []tableSpec{
+	{Name: "portfolio_profile", Key: []string{"singleton"}, Media: []string{"avatar_url", "resume_url"}},
+	{Name: "portfolio_settings", Key: []string{"key"}},
+	{Name: "portfolio_social_links", Key: []string{"platform"}},
+	{Name: "skill_categories", Key: []string{"slug"}},
+	{Name: "portfolio_skills", Key: []string{"name"}, Refs: map[string]string{"category_id": "skill_categories"}, Media: []string{"icon_url"}},
+	{Name: "portfolio_experiences"},
+	{Name: "experience_responsibilities", Refs: map[string]string{"experience_id": "portfolio_experiences"}},
+	{Name: "experience_skills", PK: []string{"experience_id", "skill_id"}, Refs: map[string]string{"experience_id": "portfolio_experiences", "skill_id": "portfolio_skills"}},
+	{Name: "portfolio_projects", Key: []string{"slug"}, Refs: map[string]string{"experience_id": "portfolio_experiences"}, Media: []string{"image_url"}},
+	{Name: "project_tags", Key: []string{"name"}},
+	{Name: "project_tag_relations", PK: []string{"project_id", "tag_id"}, Refs: map[string]string{"project_id": "portfolio_projects", "tag_id": "project_tags"}},
+	{Name: "project_skills", PK: []string{"project_id", "skill_id"}, Refs: map[string]string{"project_id": "portfolio_projects", "skill_id": "portfolio_skills"}},
+	{Name: "project_media", Refs: map[string]string{"project_id": "portfolio_projects"}, Media: []string{"url"}},
+	{Name: "project_slug_history", PK: []string{"slug"}, Refs: map[string]string{"project_id": "portfolio_projects"}},
+	{Name: "portfolio_certificates", Media: []string{"image_url", "document_url"}},
+	{Name: "portfolio_education"},
+	{Name: "education_achievements", Refs: map[string]string{"education_id": "portfolio_education"}},
+	{Name: "portfolio_testimonials", Media: []string{"avatar_url"}},
+	{Name: "blog_tags", Key: []string{"name"}},
+	{Name: "portfolio_blog_posts", Key: []string{"slug"}, Media: []string{"featured_image"}},
+	{Name: "blog_post_tags", PK: []string{"post_id", "tag_id"}, Refs: map[string]string{"post_id": "portfolio_blog_posts", "tag_id": "blog_tags"}},
+	{Name: "blog_post_slug_history", PK: []string{"slug"}, Refs: map[string]string{"post_id": "portfolio_blog_posts"}},
+	// Urut created_at agar komentar induk selalu masuk sebelum balasannya
+	{Name: "blog_comments", Refs: map[string]string{"post_id": "portfolio_blog_posts", "parent_id": "blog_comments"}, OrderBy: "created_at ASC, id ASC"},
+	// Payload block project_list/skill_list berisi ID project dan skill, jadi section terakhir
+	{Name: "portfolio_sections", Key: []string{"section_id"}},
+	{Name: "section_blocks", Refs: map[string]string{"section_id": "portfolio_sections"}},
+}
+
+func (s tableSpec) primaryKey() []string {
+	if len(s.PK) > 0 {
+		return s.PK
+	}
+	return []string{"id"}
+}
+
+func (s tableSpec) hasID() bool {
+	return len(s.PK) == 0
+}
+
+func (s tableSpec) orderBy() string {
+	if s.OrderBy != "" {
+		return s.OrderBy
+	}
+	order := make([]string, 0, len(s.primaryKey()))
+	for _, column := range s.primaryKey() {
+		order = append(order, column+" ASC")
+	}
+	return strings.Join(order, ", ")
+}
+
+func findTableSpec(name string) (tableSpec, bool) {
+	for _, spec := range transferTables {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return tableSpec{}, false
+}
+
+// ============================
+// TRANSFER SERVICE
+// ============================
+
+// MediaStore dipenuhi oleh UploadServiceWrapper (Supabase maupun lokal)
+type MediaStore interface {
+	UploadBytes(data []byte, filename, folder string) (string, error)
+	DeleteFile(fileURL string) error
+}
+
+// TransferService tidak bergantung pada gin.Context agar bisa dipakai handler maupun CLI
+type TransferService interface {
+	Export(ctx context.Context, opts model.ExportOptions) (*model.ExportFile, error)
+	Import(ctx context.Context, data []byte, opts model.ImportOptions) (*model.ImportReport, error)
+}
+
+type transferService struct {
+	repo       repo.TransferRepository
+	store      MediaStore
+	localPath  string // folder fisik untuk URL /uploads/...
+	publicBase string // prefix URL publik storage Supabase, kosong jika storage lokal
+	client     *http.Client
+}
+
+// NewTransferService membuat service export/import. File media yang dikelola aplikasi dikenali
+// dari prefix /uploads/ (dibaca dari localPath) atau publicBase (diunduh lewat HTTP).
+func NewTransferService(repo repo.TransferRepository, store MediaStore, localPath, publicBase string) TransferService {
+	return &transferService{
+		repo:       repo,
+		store:      store,
+		localPath:  localPath,
+		publicBase: publicBase,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// ============================
+// EXPORT
+// ============================
+
+func (s *transferService) Export(ctx context.Context, opts model.ExportOptions) (*model.ExportFile, error) {
+	format, err := normalizeFormat(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &model.Document{
+		Format:     model.DocumentFormat,
+		Version:    model.DocumentVersion,
+		Schema:     s.repo.SchemaVersion(),
+		ExportedAt: time.Now().UTC(),
+		Entities:   make(map[string][]model.Row, len(transferTables)),
+	}
+	for _, spec := range transferTables {
+		rows, err := s.repo.ExportRows(spec.Name, spec.orderBy())
+		if err != nil {
+			return nil, err
+		}
+		doc.Entities[spec.Name] = rows
+	}
+
+	stamp := doc.ExportedAt.Format("20060102-150405")
+	if !opts.Media {
+		data, err := encodeDocument(doc, format)
+		if err != nil {
+			return nil, err
+		}
+		return &model.ExportFile{
+			Name:        fmt.Sprintf("portfolio-export-%s.%s", stamp, format),
+			ContentType: formatContentType(format),
+			Data:        data,
+		}, nil
+	}
+
+	data, err := s.exportArchive(ctx, doc, format)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ExportFile{
+		Name:        fmt.Sprintf("portfolio-export-%s.zip", stamp),
+		ContentType: "application/zip",
+		Data:        data,
+	}, nil
+}
+
+// exportArchive membuat zip berisi portfolio.<format> dan media/<folder>/<file>.
+// URL eksternal (mis. gambar dari CDN lain) tidak dibundel dan tetap apa adanya.
+func (s *transferService) exportArchive(ctx context.Context, doc *model.Document, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	bundled := make(map[string]bool)
+
+	for _, spec := range transferTables {
+		for _, row := range doc.Entities[spec.Name] {
+			for _, column := range spec.Media {
+				url, _ := row[column].(string)
+				if url == "" || bundled[url] {
+					continue
+				}
+				file, ok := s.mediaPath(url)
+				if !ok {
+					continue
+				}
+				content, err := s.readMedia(ctx, url)
+				if err != nil {
+					fmt.Printf("⚠️ Media %s tidak ikut export: %v\n", url, err)
+					continue
+				}
+
+				writer, err := archive.Create(file)
+				if err != nil {
+					return nil, err
+				}
+				if _, err := writer.Write(content); err != nil {
+					return nil, err
+				}
+				bundled[url] = true
+				doc.Media = append(doc.Media, model.MediaFile{URL: url, File: file})
+			}
+		}
+	}
+
+	data, err := encodeDocument(doc, format)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := archive.Create("portfolio." + format)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mediaPath mengubah URL media yang dikelola aplikasi menjadi path di dalam zip
+func (s *transferService) mediaPath(url string) (string, bool) {
+	var relative string
+	switch {
+	case strings.HasPrefix(url, "/uploads/"):
+		relative = strings.TrimPrefix(url, "/uploads/")
+	case s.publicBase != "" && strings.HasPrefix(url, s.publicBase):
+		relative = strings.TrimPrefix(url, s.publicBase)
+	default:
+		return "", false
+	}
+
+	relative = path.Clean("/" + strings.SplitN(relative, "?", 2)[0])
+	if relative == "/" {
+		return "", false
+	}
+	return "media" + relative, true
+}
+
+func (s *transferService) readMedia(ctx context.Context, url string) ([]byte, error) {
+	if strings.HasPrefix(url, "/uploads/") {
+		file, ok := s.mediaPath(url)
+		if !ok {
+			return nil, fmt.Errorf("path tidak valid")
+		}
+		return os.ReadFile(filepath.Join(s.localPath, filepath.FromSlash(strings.TrimPrefix(file, "media/"))))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxMediaFileSize))
+}
+
+// mediaExists dipakai saat import agar file yang sudah ada di storage tujuan tidak diupload ulang
+func (s *transferService) mediaExists(ctx context.Context, url string) bool {
+	if strings.HasPrefix(url, "/uploads/") {
+		file, ok := s.mediaPath(url)
+		if !ok || s.publicBase != "" {
+			return false
+		}
+		_, err := os.Stat(filepath.Join(s.localPath, filepath.FromSlash(strings.TrimPrefix(file, "media/"))))
+		return err == nil
+	}
+	if s.publicBase == "" || !strings.HasPrefix(url, s.publicBase) {
+		return false
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// ============================
+// IMPORT
+// ============================
+
+// importState menyimpan pemetaan ID selama satu import
+type importState struct {
+	opts     model.ImportOptions
+	report   *model.ImportReport
+	remap    map[string]map[string]string // tabel -> id lama -> id baru
+	imported map[string]map[string]bool   // tabel -> primary key yang ada di dokumen (setelah remap)
+	present  map[string]bool              // tabel yang ada di dokumen
+	media    map[string]string            // URL lama -> URL baru
+}
+
+// Import membaca dokumen JSON/YAML atau zip hasil export. Semua perubahan berjalan dalam satu
+// transaksi: jika dry run atau ada error, transaksi di-rollback dan hanya laporan yang dikembalikan.
+func (s *transferService) Import(ctx context.Context, data []byte, opts model.ImportOptions) (*model.ImportReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = model.ImportModeMerge
+	}
+	if opts.Mode != model.ImportModeMerge && opts.Mode != model.ImportModeReplace {
+		return nil, fmt.Errorf("%w: %q (use merge or replace)", ErrInvalidImportMode, opts.Mode)
+	}
+
+	doc, files, err := decodeInput(data)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &importState{
+		opts: opts,
+		report: &model.ImportReport{
+			DryRun:   opts.DryRun,
+			Mode:     opts.Mode,
+			Schema:   doc.Schema,
+			Tables:   []model.TableReport{},
+			Remapped: []model.RemappedID{},
+			Errors:   []model.ImportProblem{},
+			Warnings: []model.ImportProblem{},
+		},
+		remap:    make(map[string]map[string]string),
+		imported: make(map[string]map[string]bool),
+		present:  make(map[string]bool),
+		media:    make(map[string]string),
+	}
+
+	if current := s.repo.SchemaVersion(); doc.Schema != "" && current != "" && doc.Schema != current {
+		state.warn("", -1, fmt.Sprintf("document was exported at schema %s, this database is at %s", doc.Schema, current))
+	}
+	var unknown []string
+	for table := range doc.Entities {
+		if _, ok := findTableSpec(table); !ok {
+			unknown = append(unknown, table)
+			continue
+		}
+		state.present[table] = true
+	}
+	sort.Strings(unknown)
+	for _, table := range unknown {
+		state.warn(table, -1, "unknown table, ignored")
+	}
+
+	uploaded, err := s.importMedia(ctx, doc, files, state)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.Transaction(func(tx repo.TransferRepository) error {
+		for _, spec := range transferTables {
+			if !state.present[spec.Name] {
+				continue
+			}
+			if err := s.importTable(tx, spec, doc.Entities[spec.Name], state); err != nil {
+				return err
+			}
+		}
+		if opts.Mode == model.ImportModeReplace && !state.report.HasErrors() {
+			if err := s.deleteMissing(tx, state); err != nil {
+				return err
+			}
+		}
+
+		if state.report.HasErrors() {
+			return ErrImportRejected
+		}
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+
+	if err != nil {
+		// File yang sudah diupload tidak dipakai jika transaksi batal
+		for _, url := range uploaded {
+			if delErr := s.store.DeleteFile(url); delErr != nil {
+				fmt.Printf("⚠️ Gagal menghapus media %s: %v\n", url, delErr)
+			}
+		}
+		switch {
+		case errors.Is(err, errDryRun):
+			return state.report, nil
+		case errors.Is(err, ErrImportRejected):
+			return state.report, ErrImportRejected
+		default:
+			return nil, err
+		}
+	}
+	return state.report, nil
+}
+
+// importMedia mengupload file dari zip ke storage tujuan dan mencatat URL penggantinya
+func (s *transferService) importMedia(ctx context.Context, doc *model.Document, files map[string]*zip.File, state *importState) ([]string, error) {
+	if len(doc.Media) == 0 {
+		return nil, nil
+	}
+	if files == nil {
+		state.warn("", -1, "document lists media files but was not imported as a zip, media URLs are kept as is")
+		return nil, nil
+	}
+
+	var uploaded []string
+	for i, media := range doc.Media {
+		folder, valid := mediaFolder(media.File)
+		if !valid {
+			state.warn("", i, fmt.Sprintf("media file %q is outside the media/ folder, skipped", media.File))
+			continue
+		}
+		file, ok := files[media.File]
+		if media.URL == "" || !ok {
+			state.warn("", i, fmt.Sprintf("media file %q is missing from the archive", media.File))
+			continue
+		}
+		if s.mediaExists(ctx, media.URL) {
+			state.report.Media = append(state.report.Media, model.MediaReport{From: media.URL, To: media.URL, Status: "existing"})
+			continue
+		}
+		if state.opts.DryRun || s.store == nil {
+			state.report.Media = append(state.report.Media, model.MediaReport{From: media.URL, Status: "planned"})
+			continue
+		}
+
+		content, err := readZipFile(file)
+		if err != nil {
+			state.warn("", i, fmt.Sprintf("media file %q: %v", media.File, err))
+			continue
+		}
+		url, err := s.store.UploadBytes(content, path.Base(media.File), folder)
+		if err != nil {
+			for _, done := range uploaded {
+				s.store.DeleteFile(done)
+			}
+			return nil, fmt.Errorf("gagal upload media %s: %v", media.File, err)
+		}
+		uploaded = append(uploaded, url)
+		state.media[media.URL] = url
+		state.report.Media = append(state.report.Media, model.MediaReport{From: media.URL, To: url, Status: "uploaded"})
+	}
+	return uploaded, nil
+}
+
+// mediaFolder mengambil folder upload dari path media di arsip. Path harus berada di bawah media/,
+// bukan path absolut dan tanpa segmen "..", agar isi arsip tidak bisa menulis ke luar folder upload.
+func mediaFolder(file string) (string, bool) {
+	if file == "" || strings.HasPrefix(file, "/") || strings.Contains(file, "\\") {
+		return "", false
+	}
+	for _, segment := range strings.Split(file, "/") {
+		if segment == ".." {
+			return "", false
+		}
+	}
+	cleaned := path.Clean(file)
+	if cleaned != file || !strings.HasPrefix(cleaned, "media/") {
+		return "", false
+	}
+	return strings.Trim(strings.TrimPrefix(path.Dir(cleaned), "media"), "/"), true
+}
+
+func (s *transferService) importTable(tx repo.TransferRepository, spec tableSpec, rows []model.Row, state *importState) error {
+	columns, err := tx.Columns(spec.Name)
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		state.warn(spec.Name, -1, "table does not exist in this database, ignored")
+		return nil
+	}
+
+	report := model.TableReport{Table: spec.Name, Rows: len(rows)}
+	ignored := make(map[string]bool)
+	if state.imported[spec.Name] == nil {
+		state.imported[spec.Name] = make(map[string]bool)
+	}
+
+	for i, row := range rows {
+		if row == nil {
+			state.fail(spec.Name, i, "row must be an object")
+			continue
+		}
+
+		if spec.hasID() {
+			if err := s.resolveID(tx, spec, row, state); err != nil {
+				return err
+			}
+		}
+		s.rewriteRow(spec, row, state)
+
+		pk, problem := rowPrimaryKey(spec, row)
+		if problem != "" {
+			state.fail(spec.Name, i, problem)
+			continue
+		}
+		if ok, err := s.checkRefs(tx, spec, row, i, state); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		names := make([]string, 0, len(row))
+		for column := range row {
+			if columns[column] {
+				names = append(names, column)
+			} else {
+				ignored[column] = true
+			}
+		}
+		sort.Strings(names)
+		payload, err := json.Marshal(row)
+		if err != nil {
+			state.fail(spec.Name, i, err.Error())
+			continue
+		}
+
+		// Savepoint per baris agar satu baris gagal tidak menghentikan validasi baris lainnya
+		if err := tx.SavePoint(savePointName); err != nil {
+			return err
+		}
+		created, changed, err := tx.Upsert(spec.Name, spec.primaryKey(), names, payload)
+		if err != nil {
+			if rbErr := tx.RollbackTo(savePointName); rbErr != nil {
+				return rbErr
+			}
+			state.fail(spec.Name, i, err.Error())
+			continue
+		}
+
+		state.imported[spec.Name][pk] = true
+		switch {
+		case created:
+			report.Created++
+		case changed:
+			report.Updated++
+		default:
+			report.Skipped++
+		}
+	}
+
+	unknown := make([]string, 0, len(ignored))
+	for column := range ignored {
+		unknown = append(unknown, column)
+	}
+	sort.Strings(unknown)
+	for _, column := range unknown {
+		state.warn(spec.Name, -1, fmt.Sprintf("unknown column %q, ignored", column))
+	}
+
+	state.report.Tables = append(state.report.Tables, report)
+	return nil
+}
+
+// resolveID menentukan id akhir baris: id baris yang sudah ada dengan natural key yang sama,
+// UUID baru untuk fresh_ids atau id yang kosong, atau id dari dokumen.
+func (s *transferService) resolveID(tx repo.TransferRepository, spec tableSpec, row model.Row, state *importState) error {
+	id, _ := row["id"].(string)
+	target := id
+
+	if keys, ok := naturalKey(spec, row); ok {
+		existing, err := tx.FindIDByKey(spec.Name, spec.Key, keys)
+		if err != nil {
+			return err
+		}
+		if existing != "" {
+			target = existing
+		}
+	}
+	if target == id && (id == "" || state.opts.FreshIDs) {
+		target = uuid.New().String()
+	}
+	if target == id {
+		return nil
+	}
+
+	row["id"] = target
+	if id != "" {
+		if state.remap[spec.Name] == nil {
+			state.remap[spec.Name] = make(map[string]string)
+		}
+		state.remap[spec.Name][id] = target
+
+		remapped := model.RemappedID{Table: spec.Name, From: id, To: target}
+		if keys, ok := naturalKey(spec, row); ok {
+			remapped.Key = strings.Join(keys, "|")
+		}
+		state.report.Remapped = append(state.report.Remapped, remapped)
+	}
+	return nil
+}
+
+// rewriteRow mengganti foreign key, UUID di payload section block, dan URL media yang sudah dipindahkan
+func (s *transferService) rewriteRow(spec tableSpec, row model.Row, state *importState) {
+	for column, table := range spec.Refs {
+		if value, ok := row[column].(string); ok {
+			if target, ok := state.remap[table][value]; ok {
+				row[column] = target
+			}
+		}
+	}
+
+	if spec.Name == "section_blocks" {
+		if payload, ok := row["payload"].(string); ok {
+			row["payload"] = uuidRegex.ReplaceAllStringFunc(payload, func(id string) string {
+				for _, ids := range state.remap {
+					if target, ok := ids[strings.ToLower(id)]; ok {
+						return target
+					}
+				}
+				return id
+			})
+		}
+	}
+
+	for _, column := range spec.Media {
+		if url, ok := row[column].(string); ok {
+			if target, ok := state.media[url]; ok {
+				row[column] = target
+			}
+		}
+	}
+}
+
+// checkRefs memastikan setiap foreign key menunjuk baris di dokumen atau, kecuali tabel tujuannya
+// diganti (mode replace), baris yang sudah ada di database
+func (s *transferService) checkRefs(tx repo.TransferRepository, spec tableSpec, row model.Row, index int, state *importState) (bool, error) {
+	columns := make([]string, 0, len(spec.Refs))
+	for column := range spec.Refs {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	ok := true
+	for _, column := range columns {
+		table := spec.Refs[column]
+		value, isString := row[column].(string)
+		if row[column] == nil {
+			continue
+		}
+		if !isString || value == "" {
+			state.fail(spec.Name, index, fmt.Sprintf("%s must be an id", column))
+			ok = false
+			continue
+		}
+		if state.imported[table][value] {
+			continue
+		}
+		if state.opts.Mode == model.ImportModeReplace && state.present[table] {
+			state.fail(spec.Name, index, fmt.Sprintf("%s %s does not exist in %s", column, value, table))
+			ok = false
+			continue
+		}
+
+		exists, err := tx.Exists(table, "id", value)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			state.fail(spec.Name, index, fmt.Sprintf("%s %s does not exist in %s", column, value, table))
+			ok = false
+		}
+	}
+	return ok, nil
+}
+
+// deleteMissing (mode replace) menghapus baris yang tidak ada di dokumen, mulai dari tabel anak
+func (s *transferService) deleteMissing(tx repo.TransferRepository, state *importState) error {
+	for i := len(transferTables) - 1; i >= 0; i-- {
+		spec := transferTables[i]
+		if !state.present[spec.Name] {
+			continue
+		}
+
+		keep := make([]string, 0, len(state.imported[spec.Name]))
+		for pk := range state.imported[spec.Name] {
+			keep = append(keep, pk)
+		}
+		deleted, err := tx.DeleteExcept(spec.Name, spec.primaryKey(), keep)
+		if err != nil {
+			return err
+		}
+		for j := range state.report.Tables {
+			if state.report.Tables[j].Table == spec.Name {
+				state.report.Tables[j].Deleted = deleted
+			}
+		}
+	}
+	return nil
+}
+
+func (st *importState) fail(table string, row int, message string) {
+	st.report.Errors = append(st.report.Errors, model.ImportProblem{Table: table, Row: row, Message: message})
+}
+
+func (st *importState) warn(table string, row int, message string) {
+	st.report.Warnings = append(st.report.Warnings, model.ImportProblem{Table: table, Row: row, Message: message})
+}
+
+// ============================
+// HELPERS
+// ============================
+
+var uuidRegex = regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+// rowPrimaryKey menyusun primary key baris, gabungan dipisah "|" seperti di DeleteExcept
+func rowPrimaryKey(spec tableSpec, row model.Row) (string, string) {
+	parts := make([]string, 0, len(spec.primaryKey()))
+	for _, column := range spec.primaryKey() {
+		value := scalarString(row[column])
+		if value == "" {
+			return "", fmt.Sprintf("%s is required", column)
+		}
+		parts = append(parts, value)
+	}
+	return strings.Join(parts, "|"), ""
+}
+
+func naturalKey(spec tableSpec, row model.Row) ([]string, bool) {
+	if len(spec.Key) == 0 {
+		return nil, false
+	}
+	values := make([]string, len(spec.Key))
+	for i, column := range spec.Key {
+		values[i] = scalarString(row[column])
+		if values[i] == "" {
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+func scalarString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func normalizeFormat(format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", model.FormatJSON:
+		return model.FormatJSON, nil
+	case model.FormatYAML, "yml":
+		return model.FormatYAML, nil
+	default:
+		return "", fmt.Errorf("%w: %q (use json or yaml)", ErrUnsupportedFormat, format)
+	}
+}
+
+func formatContentType(format string) string {
+	if format == model.FormatYAML {
+		return "application/yaml"
+	}
+	return "application/json"
+}
+
+func encodeDocument(doc *model.Document, format string) ([]byte, error) {
+	if format == model.FormatYAML {
+		// json.Number akan ditulis sebagai string oleh encoder YAML
+		yamlDoc := *doc
+		yamlDoc.Entities = make(map[string][]model.Row, len(doc.Entities))
+		for table, rows := range doc.Entities {
+			converted := make([]model.Row, len(rows))
+			for i, row := range rows {
+				converted[i] = yamlValue(row).(model.Row)
+			}
+			yamlDoc.Entities[table] = converted
+		}
+
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&yamlDoc); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func yamlValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case model.Row:
+		converted := make(model.Row, len(v))
+		for key, item := range v {
+			converted[key] = yamlValue(item)
+		}
+		return converted
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[key] = yamlValue(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = yamlValue(item)
+		}
+		return converted
+	default:
+		return value
+	}
+}
+
+// decodeInput menerima zip (portfolio.json/.yaml + media), JSON, atau YAML
+func decodeInput(data []byte) (*model.Document, map[string]*zip.File, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+		}
+
+		files := make(map[string]*zip.File, len(archive.File))
+		var document *zip.File
+		for _, file := range archive.File {
+			files[file.Name] = file
+			switch file.Name {
+			case "portfolio.json", "portfolio.yaml", "portfolio.yml":
+				document = file
+			}
+		}
+		if document == nil {
+			return nil, nil, fmt.Errorf("%w: archive has no portfolio.json or portfolio.yaml", ErrInvalidDocument)
+		}
+		content, err := readZipFile(document)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+		}
+		doc, err := decodeDocument(content)
+		return doc, files, err
+	}
+
+	doc, err := decodeDocument(data)
+	return doc, nil, err
+}
+
+// decodeDocument membaca JSON atau YAML. YAML diubah ke JSON dulu agar nilainya
+// bertipe sama seperti dokumen JSON (angka sebagai json.Number).
+func decodeDocument(data []byte) (*model.Document, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("%w: empty document", ErrInvalidDocument)
+	}
+
+	if trimmed[0] != '{' {
+		var raw interface{}
+		if err := yaml.Unmarshal(trimmed, &raw); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+		}
+		converted, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+		}
+		trimmed = converted
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	var doc model.Document
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+
+	if doc.Format != model.DocumentFormat {
+		return nil, fmt.Errorf("%w: format must be %q", ErrInvalidDocument, model.DocumentFormat)
+	}
+	if doc.Version < 1 || doc.Version > model.DocumentVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d (supported: %d)", ErrInvalidDocument, doc.Version, model.DocumentVersion)
+	}
+	if doc.Entities == nil {
+		return nil, fmt.Errorf("%w: entities is required", ErrInvalidDocument)
+	}
+	return &doc, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	if file.UncompressedSize64 > maxMediaFileSize {
+		return nil, fmt.Errorf("file %s terlalu besar", file.Name)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(io.LimitReader(reader, maxMediaFileSize))
+}
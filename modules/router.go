@@ -5,6 +5,8 @@ import (
 	"fmt"
 	handlers "gintugas/modules/ServiceRoute"
 	serviceroute "gintugas/modules/ServiceRoute"
+	authMiddleware "gintugas/modules/components/Auth/middleware"
+	middlewarerole "gintugas/modules/components/Auth/middleware/middlewarerole"
 	projectRPO "gintugas/modules/components/Project/repository"
 	repositoryprojek "gintugas/modules/components/Project/repository"
 	projectServsc "gintugas/modules/components/Project/service"
@@ -18,8 +20,11 @@ import (
 	"time"
 
 	// Import portfolio components
+	portfolioModel "gintugas/modules/components/all/models"
 	portfolioRepo "gintugas/modules/components/all/repo"
 	portfolioService "gintugas/modules/components/all/service"
+	transferRepo "gintugas/modules/components/transfer/repo"
+	transferService "gintugas/modules/components/transfer/service"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -32,8 +37,8 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "X-Requested-With"},
-		ExposeHeaders:    []string{"Content-Length", "Content-Disposition"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "X-Requested-With", "If-None-Match"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Disposition", "ETag"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -124,6 +129,9 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 	}
 	projectHandler := handlers.NewProjectHandler(projectService)
 
+	githubService := projectServsc.NewGitHubService(projectRepo, projectServsc.NewGitHubClientFromEnv(), db != nil)
+	githubSyncHandler := handlers.NewGitHubSyncHandler(githubService)
+
 	memberRepo := repositoryprojek.NewProjectMemberRepo(gormDB)
 	memberService := projectServsc.NewProjectMemberService(memberRepo, projectRepo)
 
@@ -154,18 +162,38 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 	}
 	skillHandler := handlers.NewSkillHandler(skillService)
 
+	// Skill categories
+	skillCategoryRepo := portfolioRepo.NewSkillCategoryRepository(gormDB)
+	skillCategoryService := portfolioService.NewSkillCategoryService(skillCategoryRepo, skillRepo)
+	skillCategoryHandler := handlers.NewSkillCategoryHandler(skillCategoryService)
+
 	// Certificates with upload service
 	certRepo := portfolioRepo.NewCertificateRepository(gormDB)
 	var certService portfolioService.CertificateService
 	if uploadProvider == "supabase" && supabaseUploadService != nil {
 		supabaseWrapper := portfolioService.NewSupabaseUploadWrapper(supabaseUploadService)
-		certService = portfolioService.NewCertificateServiceWithUpload(certRepo, supabaseWrapper, "certificates")
+		certService = portfolioService.NewCertificateServiceWithUpload(certRepo, supabaseWrapper, "certificates", gormDB != nil)
 	} else {
 		localPath := filepath.Join(uploadBasePath, "certificates")
-		certService = portfolioService.NewCertificateService(certRepo, localPath)
+		certService = portfolioService.NewCertificateService(certRepo, localPath, gormDB != nil)
 	}
 	certHandler := handlers.NewCertificateHandler(certService)
 
+	// Profile with upload service (avatar)
+	profileRepo := portfolioRepo.NewProfileRepository(gormDB)
+	var profileService portfolioService.ProfileService
+	if uploadProvider == "supabase" && supabaseUploadService != nil {
+		supabaseWrapper := portfolioService.NewSupabaseUploadWrapper(supabaseUploadService)
+		profileService = portfolioService.NewProfileServiceWithUpload(profileRepo, supabaseWrapper, "profile")
+	} else {
+		localPath := filepath.Join(uploadBasePath, "profile")
+		profileService = portfolioService.NewProfileService(profileRepo, localPath)
+	}
+	profileHandler := handlers.NewProfileHandler(profileService)
+
+	// Export/import seluruh portfolio (backup & migrasi antar database)
+	transferHandler := handlers.NewTransferHandler(newTransferService(gormDB, supabaseUploadService, uploadBasePath))
+
 	// Education (no upload needed)
 	eduRepo := portfolioRepo.NewEducationRepository(gormDB)
 	eduService := portfolioService.NewEducationService(eduRepo)
@@ -178,13 +206,36 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 
 	// Blog (no upload needed)
 	blogRepo := portfolioRepo.NewBlogRepository(gormDB)
-	blogService := portfolioService.NewBlogService(blogRepo)
+	blogAnalyticsRepo := portfolioRepo.NewBlogAnalyticsRepository(gormDB)
+	// Mode upload-only (tanpa database): recorder tidak dijalankan agar goroutine-nya tidak panic
+	var blogViewRecorder *portfolioService.BlogViewRecorder
+	if gormDB != nil {
+		blogViewRecorder = portfolioService.NewBlogViewRecorder(blogAnalyticsRepo)
+	}
+	blogService := portfolioService.NewBlogService(blogRepo, blogViewRecorder)
 	blogHandler := handlers.NewBlogHandler(blogService)
+	blogAnalyticsService := portfolioService.NewBlogAnalyticsService(blogAnalyticsRepo)
+	blogAnalyticsHandler := handlers.NewBlogAnalyticsHandler(blogAnalyticsService)
+
+	// Draft preview links (blog posts & projects)
+	previewLinkRepo := portfolioRepo.NewPreviewLinkRepository(gormDB)
+	previewLinkService := portfolioService.NewPreviewLinkService(previewLinkRepo)
+	previewLinkHandler := handlers.NewPreviewLinkHandler(previewLinkService)
+	optionalAuth := authMiddleware.OptionalAuthMiddleware()
+	previewAccess := previewLinkHandler.PreviewAccess()
+
+	// Blog comments (no upload needed)
+	commentRepo := portfolioRepo.NewCommentRepository(gormDB)
+	commentService := portfolioService.NewCommentService(commentRepo, blogRepo)
+	commentHandler := handlers.NewCommentHandler(commentService)
 
 	// Sections (no upload needed)
 	sectionRepo := portfolioRepo.NewSectionRepository(gormDB)
 	sectionService := portfolioService.NewSectionService(sectionRepo)
 	sectionHandler := handlers.NewSectionHandler(sectionService)
+	sectionBlockRepo := portfolioRepo.NewSectionBlockRepository(gormDB)
+	sectionBlockService := portfolioService.NewSectionBlockService(sectionBlockRepo, sectionRepo, skillRepo)
+	sectionBlockHandler := handlers.NewSectionBlockHandler(sectionBlockService)
 
 	// Social Links (no upload needed)
 	socialLinkRepo := portfolioRepo.NewSocialLinkRepository(gormDB)
@@ -196,6 +247,19 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 	settingService := portfolioService.NewSettingService(settingRepo)
 	settingHandler := handlers.NewSettingHandler(settingService)
 
+	// Timeline (experience, education, certificate, project dalam satu feed)
+	timelineRepo := portfolioRepo.NewTimelineRepository(gormDB)
+	timelineService := portfolioService.NewTimelineService(timelineRepo)
+	timelineHandler := handlers.NewTimelineHandler(timelineService)
+
+	// Display order (drag-and-drop reorder untuk semua entity yang punya display_order)
+	displayOrderRepo := portfolioRepo.NewDisplayOrderRepository(gormDB)
+	displayOrderService := portfolioService.NewDisplayOrderService(displayOrderRepo)
+	displayOrderHandler := handlers.NewDisplayOrderHandler(displayOrderService)
+
+	// Snapshot seluruh konten publik dalam satu request (di-cache, dihapus setiap ada write)
+	snapshotHandler := handlers.NewPortfolioSnapshotHandler(router)
+
 	// ============================
 	// SWAGGER
 	// ============================
@@ -205,6 +269,7 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 	// API ROUTES
 	// ============================
 	api := router.Group("/api")
+	api.Use(snapshotHandler.InvalidateOnWrite())
 	{
 		// Health check
 		api.GET("/health", func(c *gin.Context) {
@@ -221,9 +286,17 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 		// ============================
 		projectRoutes := api.Group("/v1/projects")
 		{
-			projectRoutes.GET("", projectHandler.GetAllProjects)
-			projectRoutes.GET("/:id", projectHandler.GetProject)
+			projectRoutes.GET("", optionalAuth, projectHandler.GetAllProjects)
+			projectRoutes.GET("/:id", optionalAuth, previewAccess, projectHandler.GetProject)
+			projectRoutes.GET("/slug/:slug", optionalAuth, previewAccess, projectHandler.GetProjectBySlug)
+			projectRoutes.GET("/:id/related", projectHandler.GetRelatedProjects)
+			projectRoutes.GET("/:id/media", optionalAuth, previewAccess, projectHandler.GetProjectMedia)
+			projectRoutes.PUT("/:id/media/order", projectHandler.ReorderProjectMedia)
+			projectRoutes.DELETE("/:id/media/:mediaId", projectHandler.DeleteProjectMedia)
+			projectRoutes.PUT("/:id/experience", projectHandler.SetProjectExperience)
+			projectRoutes.PUT("/:id/skills", projectHandler.SetProjectSkills)
 			projectRoutes.POST("/with-image", projectHandler.CreateProjectWithImage)
+			projectRoutes.PUT("/order", displayOrderHandler.Reorder(portfolioModel.OrderEntityProjects))
 			projectRoutes.PUT("/:id", projectHandler.UpdateProject)
 			projectRoutes.DELETE("/:id", projectHandler.DeleteProject)
 		}
@@ -239,6 +312,10 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 		{
 			tags.POST("", tagsHandler.CreateTags)
 			tags.GET("", projectHandler.GetAllTags)
+			tags.GET("/usage", tagsHandler.GetTagUsage)
+			tags.GET("/:id/projects", optionalAuth, memberService.GetProjectsByTag)
+			tags.PUT("/:id", tagsHandler.UpdateTags)
+			tags.DELETE("/:id", tagsHandler.DeleteTags)
 		}
 
 		// ============================
@@ -247,10 +324,20 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 		expeRoutes := api.Group("/v1")
 		{
 			expeRoutes.POST("/experiences/with-relations", expeHandler.CreateExperiencesWithRelations)
-			expeRoutes.GET("/experiences/with-relations", expeHandler.GetAllExperiencesWithRelations)
-			expeRoutes.GET("/experiences/with-relations/:id", expeHandler.GetExperiencesByIDWithRelations)
+			expeRoutes.GET("/experiences/with-relations", optionalAuth, expeHandler.GetAllExperiencesWithRelations)
+			expeRoutes.GET("/experiences/with-relations/:id", optionalAuth, expeHandler.GetExperiencesByIDWithRelations)
 			expeRoutes.PUT("/experiences/with-relations/:id", expeHandler.UpdateExperiencesWithRelations)
 			expeRoutes.DELETE("/experiences/with-relations/:id", expeHandler.DeleteExperiencesWithRelations)
+			expeRoutes.GET("/experiences/summary", expeHandler.GetExperienceSummary)
+			expeRoutes.PUT("/experiences/order", displayOrderHandler.Reorder(portfolioModel.OrderEntityExperiences))
+
+			expeRoutes.GET("/experiences/:id/responsibilities", expeHandler.GetResponsibilities)
+			expeRoutes.POST("/experiences/:id/responsibilities", expeHandler.CreateResponsibility)
+			expeRoutes.PUT("/experiences/:id/responsibilities/order", expeHandler.ReorderResponsibilities)
+			expeRoutes.PUT("/experiences/:id/responsibilities/:responsibilityId", expeHandler.UpdateResponsibility)
+			expeRoutes.DELETE("/experiences/:id/responsibilities/:responsibilityId", expeHandler.DeleteResponsibility)
+			expeRoutes.POST("/experiences/:id/skills", expeHandler.AddSkill)
+			expeRoutes.DELETE("/experiences/:id/skills/:skillId", expeHandler.RemoveSkill)
 		}
 
 		// ============================
@@ -266,21 +353,41 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 			skills.PUT("/:id/with-icon", skillHandler.UpdateWithIcon)
 			skills.GET("", skillHandler.GetAll)
 			skills.GET("/featured", skillHandler.GetFeatured)
+			skills.GET("/grouped", skillCategoryHandler.GetGrouped)
+			skills.GET("/proficiency", skillHandler.GetProficiency)
 			skills.GET("/category/:category", skillHandler.GetByCategory)
+			skills.PUT("/order", displayOrderHandler.Reorder(portfolioModel.OrderEntitySkills))
 			skills.GET("/:id", skillHandler.GetByID)
+			skills.GET("/:id/usage", optionalAuth, skillHandler.GetUsage)
 			skills.PUT("/:id", skillHandler.Update)
 			skills.DELETE("/:id", skillHandler.Delete)
 		}
 
+		// SKILL CATEGORIES ROUTES
+		skillCategories := v1.Group("/skill-categories")
+		{
+			skillCategories.POST("", skillCategoryHandler.Create)
+			skillCategories.GET("", skillCategoryHandler.GetAll)
+			skillCategories.PUT("/order", displayOrderHandler.Reorder(portfolioModel.OrderEntitySkillCategories))
+			skillCategories.GET("/:id", skillCategoryHandler.GetByID)
+			skillCategories.PUT("/:id", skillCategoryHandler.Update)
+			skillCategories.DELETE("/:id", skillCategoryHandler.Delete)
+		}
+
 		// CERTIFICATES ROUTES
+		// Penulisan khusus admin karena credential_url diambil oleh job verifikasi server,
+		// hasil verifikasinya hanya terlihat oleh admin.
+		certificateAdmin := []gin.HandlerFunc{authMiddleware.AuthMiddleware(), middlewarerole.RequireRole("admin")}
 		certificates := v1.Group("/certificates")
 		{
-			certificates.POST("", certHandler.Create)
-			certificates.POST("/with-image", certHandler.CreateWithImage)
-			certificates.GET("", certHandler.GetAll)
-			certificates.GET("/:id", certHandler.GetByID)
-			certificates.PUT("/:id", certHandler.Update)
-			certificates.DELETE("/:id", certHandler.Delete)
+			certificates.POST("", append(certificateAdmin, certHandler.Create)...)
+			certificates.POST("/with-image", append(certificateAdmin, certHandler.CreateWithImage)...)
+			certificates.GET("", optionalAuth, certHandler.GetAll)
+			certificates.PUT("/order", append(certificateAdmin, displayOrderHandler.Reorder(portfolioModel.OrderEntityCertificates))...)
+			certificates.GET("/:id", optionalAuth, certHandler.GetByID)
+			certificates.PUT("/:id", append(certificateAdmin, certHandler.Update)...)
+			certificates.PUT("/:id/with-image", append(certificateAdmin, certHandler.UpdateWithImage)...)
+			certificates.DELETE("/:id", append(certificateAdmin, certHandler.Delete)...)
 		}
 
 		// EDUCATION ROUTES
@@ -288,6 +395,7 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 		{
 			education.POST("", eduHandler.CreateWithAchievements)
 			education.GET("", eduHandler.GetAllWithAchievements)
+			education.PUT("/order", displayOrderHandler.Reorder(portfolioModel.OrderEntityEducation))
 			education.GET("/:id", eduHandler.GetByIDWithAchievements)
 			education.PUT("/:id", eduHandler.UpdateWithAchievements)
 			education.DELETE("/:id", eduHandler.DeleteWithAchievements)
@@ -300,6 +408,7 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 			testimonials.GET("", testHandler.GetAll)
 			testimonials.GET("/featured", testHandler.GetFeatured)
 			testimonials.GET("/status/:status", testHandler.GetByStatus)
+			testimonials.PUT("/order", displayOrderHandler.Reorder(portfolioModel.OrderEntityTestimonials))
 			testimonials.GET("/:id", testHandler.GetByID)
 			testimonials.PUT("/:id", testHandler.Update)
 			testimonials.DELETE("/:id", testHandler.Delete)
@@ -309,11 +418,14 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 		blog := v1.Group("/blog")
 		{
 			blog.POST("", blogHandler.CreateWithTags)
-			blog.GET("", blogHandler.GetAllWithTags)
+			blog.GET("", optionalAuth, blogHandler.GetAllWithTags)
 			blog.GET("/published", blogHandler.GetPublishedWithTags)
 			blog.GET("/tags", blogHandler.GetAllTags)
-			blog.GET("/:id", blogHandler.GetByIDWithTags)
-			blog.GET("/slug/:slug", blogHandler.GetBySlugWithTags)
+			blog.GET("/:id", optionalAuth, previewAccess, blogHandler.GetByIDWithTags)
+			blog.GET("/:id/related", optionalAuth, previewAccess, blogHandler.GetRelated)
+			blog.GET("/:id/comments", optionalAuth, previewAccess, commentHandler.GetByPost)
+			blog.POST("/:id/comments", commentHandler.Create)
+			blog.GET("/slug/:slug", optionalAuth, previewAccess, blogHandler.GetBySlugWithTags)
 			blog.PUT("/:id", blogHandler.UpdateWithTags)
 			blog.DELETE("/:id", blogHandler.DeleteWithTags)
 		}
@@ -323,23 +435,115 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 		{
 			sections.POST("", sectionHandler.Create)
 			sections.GET("", sectionHandler.GetAll)
+			sections.GET("/active", sectionHandler.GetActive)
+			sections.PUT("/order", displayOrderHandler.Reorder(portfolioModel.OrderEntitySections))
+			sections.GET("/:id", sectionHandler.GetByID)
+			sections.PUT("/:id", sectionHandler.Update)
+			sections.PATCH("/:id", sectionHandler.Patch)
 			sections.DELETE("/:id", sectionHandler.Delete)
+
+			// Content block aktif milik section (page builder), daftar lengkap dan perubahan lewat /admin/sections
+			sections.GET("/:id/blocks", sectionBlockHandler.GetBySection)
 		}
 
+		// PAGE ROUTES (section + block yang sudah di-resolve)
+		v1.GET("/page", sectionBlockHandler.GetPage)
+
 		// SOCIAL LINKS ROUTES
 		socialLinks := v1.Group("/social-links")
 		{
 			socialLinks.POST("", socialLinkHandler.Create)
 			socialLinks.GET("", socialLinkHandler.GetAll)
+			socialLinks.GET("/active", socialLinkHandler.GetActive)
+			socialLinks.PUT("/order", displayOrderHandler.Reorder(portfolioModel.OrderEntitySocialLinks))
+			socialLinks.GET("/:id", socialLinkHandler.GetByID)
+			socialLinks.PUT("/:id", socialLinkHandler.Update)
+			socialLinks.PATCH("/:id", socialLinkHandler.Patch)
 			socialLinks.DELETE("/:id", socialLinkHandler.Delete)
 		}
 
+		// PORTFOLIO SNAPSHOT ROUTES
+		v1.GET("/portfolio", snapshotHandler.GetSnapshot)
+
+		// PROFILE ROUTES
+		v1.GET("/profile", profileHandler.Get)
+
+		// TIMELINE ROUTES
+		v1.GET("/timeline", timelineHandler.GetTimeline)
+
 		// SETTINGS ROUTES
+		// Baca publik hanya melihat setting is_public. Penulisan khusus admin karena
+		// response-nya memuat value, termasuk untuk setting privat.
+		settingAdmin := []gin.HandlerFunc{authMiddleware.AuthMiddleware(), middlewarerole.RequireRole("admin")}
 		settings := v1.Group("/settings")
 		{
-			settings.POST("", settingHandler.Create)
+			settings.POST("", append(settingAdmin, settingHandler.Create)...)
 			settings.GET("", settingHandler.GetAll)
-			settings.DELETE("/:id", settingHandler.Delete)
+			settings.GET("/:key", settingHandler.GetByKey)
+			settings.PUT("/:key", append(settingAdmin, settingHandler.Update)...)
+			settings.PATCH("/:key", append(settingAdmin, settingHandler.Patch)...)
+			settings.DELETE("/:key", append(settingAdmin, settingHandler.Delete)...)
+		}
+
+		// ============================
+		// ADMIN ROUTES
+		// ============================
+		admin := api.Group("/admin")
+		admin.Use(authMiddleware.AuthMiddleware(), middlewarerole.RequireRole("admin"))
+		{
+			// COMMENT MODERATION
+			admin.GET("/comments", commentHandler.GetAll)
+			admin.PUT("/comments/:id/status", commentHandler.UpdateStatus)
+			admin.DELETE("/comments/:id", commentHandler.Delete)
+
+			// DRAFT PREVIEW LINKS
+			admin.POST("/preview-links", previewLinkHandler.Create)
+			admin.GET("/preview-links", previewLinkHandler.GetActive)
+			admin.DELETE("/preview-links/:id", previewLinkHandler.Revoke)
+
+			// PROJECT GITHUB SYNC
+			admin.POST("/projects/github/sync", githubSyncHandler.SyncAll)
+			admin.POST("/projects/:id/github/sync", githubSyncHandler.SyncProject)
+			admin.GET("/projects/:id/github", githubSyncHandler.GetSyncStatus)
+			admin.GET("/projects/:id/github/tag-suggestions", githubSyncHandler.SuggestTags)
+
+			// CERTIFICATE CREDENTIAL VERIFICATION
+			admin.POST("/certificates/verify", certHandler.VerifyAll)
+			admin.POST("/certificates/:id/verify", certHandler.Verify)
+
+			// PROFILE
+			admin.PUT("/profile", profileHandler.Update)
+			admin.PATCH("/profile", profileHandler.Patch)
+			admin.PUT("/profile/avatar", profileHandler.UploadAvatar)
+			admin.DELETE("/profile/avatar", profileHandler.DeleteAvatar)
+
+			// PORTFOLIO EXPORT / IMPORT
+			admin.GET("/export", transferHandler.Export)
+			admin.POST("/import", transferHandler.Import)
+
+			// SETTINGS (termasuk yang privat)
+			admin.GET("/settings", settingHandler.GetAllAdmin)
+			admin.GET("/settings/:key", settingHandler.GetByKeyAdmin)
+
+			// SECTION BLOCKS (page builder)
+			admin.GET("/sections/:id/blocks", sectionBlockHandler.GetAllBySection)
+			admin.POST("/sections/:id/blocks", sectionBlockHandler.Create)
+			admin.PUT("/sections/:id/blocks/order", sectionBlockHandler.Reorder)
+			admin.PUT("/sections/:id/blocks/:blockId", sectionBlockHandler.Update)
+			admin.PATCH("/sections/:id/blocks/:blockId", sectionBlockHandler.Patch)
+			admin.DELETE("/sections/:id/blocks/:blockId", sectionBlockHandler.Delete)
+
+			// BLOG TAGS
+			admin.GET("/blog/tags", blogHandler.GetTagUsage)
+			admin.PUT("/blog/tags/:id", blogHandler.RenameTag)
+			admin.POST("/blog/tags/:id/merge", blogHandler.MergeTags)
+			admin.DELETE("/blog/tags/unused", blogHandler.DeleteUnusedTags)
+			admin.DELETE("/blog/tags/:id", blogHandler.DeleteTag)
+
+			// BLOG ANALYTICS
+			admin.GET("/blog/analytics/views", blogAnalyticsHandler.GetViewsOverTime)
+			admin.GET("/blog/analytics/top-posts", blogAnalyticsHandler.GetTopPosts)
+			admin.GET("/blog/analytics/referrers", blogAnalyticsHandler.GetTopReferrers)
 		}
 	}
 
@@ -354,6 +558,28 @@ func Initiator(router *gin.Engine, db *sql.DB, gormDB *gorm.DB) {
 	}
 }
 
+// NewTransferService membuat service export/import dengan storage dari environment,
+// dipakai oleh subcommand CLI "portfolio" yang berjalan tanpa router
+func NewTransferService(gormDB *gorm.DB) transferService.TransferService {
+	var supabaseUploadService *utils.SupabaseUploadService
+	supabaseURL := os.Getenv("SUPABASE_URL")
+	supabaseServiceKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
+	if supabaseURL != "" && supabaseServiceKey != "" {
+		supabaseUploadService = utils.NewSupabaseUploadService(supabaseURL, supabaseServiceKey, os.Getenv("SUPABASE_STORAGE_BUCKET"))
+	}
+	return newTransferService(gormDB, supabaseUploadService, getUploadPath())
+}
+
+func newTransferService(gormDB *gorm.DB, supabaseUploadService *utils.SupabaseUploadService, uploadBasePath string) transferService.TransferService {
+	repository := transferRepo.NewTransferRepository(gormDB)
+	if supabaseUploadService != nil {
+		store := portfolioService.NewSupabaseUploadWrapper(supabaseUploadService)
+		return transferService.NewTransferService(repository, store, uploadBasePath, supabaseUploadService.GetPublicURL(""))
+	}
+	store := portfolioService.NewLocalUploadWrapper(utils.NewLocalUploadService(uploadBasePath))
+	return transferService.NewTransferService(repository, store, uploadBasePath, "")
+}
+
 func getUploadPath() string {
 	if os.Getenv("GIN_MODE") == "release" {
 		if path := os.Getenv("UPLOAD_PATH"); path != "" {
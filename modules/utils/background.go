@@ -0,0 +1,15 @@
+package utils
+
+import "fmt"
+
+// RunSafely menjalankan satu langkah job background (ticker, worker antrean).
+// gin.Recovery tidak menjangkau goroutine di luar request, jadi panic di sini akan
+// mematikan seluruh proses; panic dicatat lalu job lanjut ke langkah berikutnya.
+func RunSafely(job string, fn func()) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			fmt.Printf("🚨 PANIC in %s: %v\n", job, recovered)
+		}
+	}()
+	fn()
+}
@@ -0,0 +1,451 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ============================================
+// JSON SCHEMA (SUBSET)
+// ============================================
+
+// JSONSchema adalah subset JSON Schema (draft 2020-12) untuk validasi nilai setting.
+// Keyword yang didukung: type, enum, const, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// multipleOf, minLength, maxLength, pattern, format (email, uri, date, date-time), properties,
+// required, additionalProperties, minProperties, maxProperties, items, minItems, maxItems, uniqueItems.
+// Keyword anotasi ($schema, $id, title, description, default, examples, $comment) diabaikan,
+// keyword lain ditolak agar schema tidak terlihat memvalidasi sesuatu yang sebenarnya dilewati.
+type JSONSchema struct {
+	// nil berarti schema boolean: true menerima semua nilai, false menolak semua nilai
+	always *bool
+
+	types            []string
+	enum             []interface{}
+	constValue       *interface{}
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	multipleOf       *float64
+	minLength        *int
+	maxLength        *int
+	pattern          *regexp.Regexp
+	format           string
+	properties       map[string]*JSONSchema
+	required         []string
+	additional       *JSONSchema
+	minProperties    *int
+	maxProperties    *int
+	items            *JSONSchema
+	minItems         *int
+	maxItems         *int
+	uniqueItems      bool
+}
+
+var ErrInvalidSchema = errors.New("invalid JSON schema")
+
+var jsonSchemaTypes = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true,
+	"number": true, "integer": true, "string": true,
+}
+
+var jsonSchemaAnnotations = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true,
+	"description": true, "default": true, "examples": true,
+}
+
+// CompileJSONSchema mem-parse schema dan memastikan hanya memakai keyword yang didukung
+func CompileJSONSchema(raw []byte) (*JSONSchema, error) {
+	var doc interface{}
+	if err := decodeJSON(raw, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	schema, err := compileSchemaNode(doc, "#")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	return schema, nil
+}
+
+// Validate memeriksa nilai hasil json.Unmarshal (angka berupa float64) dan mengembalikan
+// semua pelanggaran dalam satu error
+func (s *JSONSchema) Validate(value interface{}) error {
+	var problems []string
+	s.validate(value, "value", &problems)
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(problems, "; "))
+}
+
+func decodeJSON(raw []byte, target interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if err := decoder.Decode(target); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("unexpected data after JSON value")
+	}
+	return nil
+}
+
+func compileSchemaNode(node interface{}, path string) (*JSONSchema, error) {
+	if b, ok := node.(bool); ok {
+		return &JSONSchema{always: &b}, nil
+	}
+	doc, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: schema must be an object or boolean", path)
+	}
+
+	schema := &JSONSchema{}
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := doc[key]
+		at := path + "/" + key
+		var err error
+
+		switch key {
+		case "type":
+			schema.types, err = schemaTypeList(value, at)
+		case "enum":
+			list, ok := value.([]interface{})
+			if !ok || len(list) == 0 {
+				err = fmt.Errorf("%s: must be a non-empty array", at)
+			}
+			schema.enum = list
+		case "const":
+			v := value
+			schema.constValue = &v
+		case "minimum":
+			schema.minimum, err = schemaNumber(value, at)
+		case "maximum":
+			schema.maximum, err = schemaNumber(value, at)
+		case "exclusiveMinimum":
+			schema.exclusiveMinimum, err = schemaNumber(value, at)
+		case "exclusiveMaximum":
+			schema.exclusiveMaximum, err = schemaNumber(value, at)
+		case "multipleOf":
+			schema.multipleOf, err = schemaNumber(value, at)
+			if err == nil && *schema.multipleOf <= 0 {
+				err = fmt.Errorf("%s: must be greater than 0", at)
+			}
+		case "minLength":
+			schema.minLength, err = schemaCount(value, at)
+		case "maxLength":
+			schema.maxLength, err = schemaCount(value, at)
+		case "minProperties":
+			schema.minProperties, err = schemaCount(value, at)
+		case "maxProperties":
+			schema.maxProperties, err = schemaCount(value, at)
+		case "minItems":
+			schema.minItems, err = schemaCount(value, at)
+		case "maxItems":
+			schema.maxItems, err = schemaCount(value, at)
+		case "pattern":
+			text, ok := value.(string)
+			if !ok {
+				err = fmt.Errorf("%s: must be a string", at)
+				break
+			}
+			schema.pattern, err = regexp.Compile(text)
+			if err != nil {
+				err = fmt.Errorf("%s: %v", at, err)
+			}
+		case "format":
+			text, ok := value.(string)
+			if !ok {
+				err = fmt.Errorf("%s: must be a string", at)
+			}
+			schema.format = text
+		case "uniqueItems":
+			unique, ok := value.(bool)
+			if !ok {
+				err = fmt.Errorf("%s: must be a boolean", at)
+			}
+			schema.uniqueItems = unique
+		case "required":
+			schema.required, err = schemaStringList(value, at)
+		case "properties":
+			props, ok := value.(map[string]interface{})
+			if !ok {
+				err = fmt.Errorf("%s: must be an object", at)
+				break
+			}
+			schema.properties = make(map[string]*JSONSchema, len(props))
+			for name, prop := range props {
+				if schema.properties[name], err = compileSchemaNode(prop, at+"/"+name); err != nil {
+					break
+				}
+			}
+		case "additionalProperties":
+			schema.additional, err = compileSchemaNode(value, at)
+		case "items":
+			schema.items, err = compileSchemaNode(value, at)
+		default:
+			if !jsonSchemaAnnotations[key] {
+				err = fmt.Errorf("%s: unsupported keyword", at)
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return schema, nil
+}
+
+func schemaTypeList(value interface{}, path string) ([]string, error) {
+	var types []string
+	switch v := value.(type) {
+	case string:
+		types = []string{v}
+	case []interface{}:
+		list, err := schemaStringList(v, path)
+		if err != nil {
+			return nil, err
+		}
+		types = list
+	default:
+		return nil, fmt.Errorf("%s: must be a string or array of strings", path)
+	}
+	for _, t := range types {
+		if !jsonSchemaTypes[t] {
+			return nil, fmt.Errorf("%s: unknown type %q", path, t)
+		}
+	}
+	return types, nil
+}
+
+func schemaStringList(value interface{}, path string) ([]string, error) {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: must be an array of strings", path)
+	}
+	result := make([]string, len(list))
+	for i, item := range list {
+		text, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: must be an array of strings", path)
+		}
+		result[i] = text
+	}
+	return result, nil
+}
+
+func schemaNumber(value interface{}, path string) (*float64, error) {
+	number, ok := value.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be a number", path)
+	}
+	return &number, nil
+}
+
+func schemaCount(value interface{}, path string) (*int, error) {
+	number, ok := value.(float64)
+	if !ok || number < 0 || number != math.Trunc(number) {
+		return nil, fmt.Errorf("%s: must be a non-negative integer", path)
+	}
+	count := int(number)
+	return &count, nil
+}
+
+func (s *JSONSchema) validate(value interface{}, path string, problems *[]string) {
+	fail := func(format string, args ...interface{}) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if s.always != nil {
+		if !*s.always {
+			fail("no value is allowed")
+		}
+		return
+	}
+
+	if len(s.types) > 0 && !matchesSchemaType(value, s.types) {
+		fail("must be of type %s", strings.Join(s.types, " or "))
+		return
+	}
+	if len(s.enum) > 0 && !containsJSONValue(s.enum, value) {
+		fail("must be one of the allowed values")
+	}
+	if s.constValue != nil && !reflect.DeepEqual(*s.constValue, value) {
+		fail("must be equal to the constant value")
+	}
+
+	switch v := value.(type) {
+	case float64:
+		s.validateNumber(v, fail)
+	case string:
+		s.validateString(v, fail)
+	case []interface{}:
+		s.validateArray(v, path, problems, fail)
+	case map[string]interface{}:
+		s.validateObject(v, path, problems, fail)
+	}
+}
+
+func (s *JSONSchema) validateNumber(v float64, fail func(string, ...interface{})) {
+	if s.minimum != nil && v < *s.minimum {
+		fail("must be >= %v", *s.minimum)
+	}
+	if s.maximum != nil && v > *s.maximum {
+		fail("must be <= %v", *s.maximum)
+	}
+	if s.exclusiveMinimum != nil && v <= *s.exclusiveMinimum {
+		fail("must be > %v", *s.exclusiveMinimum)
+	}
+	if s.exclusiveMaximum != nil && v >= *s.exclusiveMaximum {
+		fail("must be < %v", *s.exclusiveMaximum)
+	}
+	if s.multipleOf != nil {
+		quotient := v / *s.multipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			fail("must be a multiple of %v", *s.multipleOf)
+		}
+	}
+}
+
+func (s *JSONSchema) validateString(v string, fail func(string, ...interface{})) {
+	length := utf8.RuneCountInString(v)
+	if s.minLength != nil && length < *s.minLength {
+		fail("must be at least %d characters", *s.minLength)
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		fail("must be at most %d characters", *s.maxLength)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(v) {
+		fail("must match pattern %s", s.pattern.String())
+	}
+	if s.format != "" && !matchesFormat(s.format, v) {
+		fail("must be a valid %s", s.format)
+	}
+}
+
+func (s *JSONSchema) validateArray(v []interface{}, path string, problems *[]string, fail func(string, ...interface{})) {
+	if s.minItems != nil && len(v) < *s.minItems {
+		fail("must have at least %d items", *s.minItems)
+	}
+	if s.maxItems != nil && len(v) > *s.maxItems {
+		fail("must have at most %d items", *s.maxItems)
+	}
+	if s.uniqueItems {
+		for i := range v {
+			if containsJSONValue(v[:i], v[i]) {
+				fail("items must be unique")
+				break
+			}
+		}
+	}
+	if s.items != nil {
+		for i, item := range v {
+			s.items.validate(item, fmt.Sprintf("%s[%d]", path, i), problems)
+		}
+	}
+}
+
+func (s *JSONSchema) validateObject(v map[string]interface{}, path string, problems *[]string, fail func(string, ...interface{})) {
+	if s.minProperties != nil && len(v) < *s.minProperties {
+		fail("must have at least %d properties", *s.minProperties)
+	}
+	if s.maxProperties != nil && len(v) > *s.maxProperties {
+		fail("must have at most %d properties", *s.maxProperties)
+	}
+	for _, name := range s.required {
+		if _, ok := v[name]; !ok {
+			fail("missing required property %q", name)
+		}
+	}
+
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if prop, ok := s.properties[name]; ok {
+			prop.validate(v[name], path+"."+name, problems)
+		} else if s.additional != nil {
+			s.additional.validate(v[name], path+"."+name, problems)
+		}
+	}
+}
+
+func matchesSchemaType(value interface{}, types []string) bool {
+	for _, t := range types {
+		switch v := value.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case []interface{}:
+			if t == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsJSONValue(list []interface{}, value interface{}) bool {
+	for _, item := range list {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesFormat mengecek format yang dikenal, format lain diperlakukan sebagai anotasi
+func matchesFormat(format, value string) bool {
+	switch format {
+	case "email":
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	case "uri":
+		parsed, err := url.Parse(value)
+		return err == nil && parsed.Scheme != "" && (parsed.Host != "" || parsed.Opaque != "")
+	case "date":
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	default:
+		return true
+	}
+}
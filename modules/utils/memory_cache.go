@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryCache adalah cache in-memory sederhana dengan TTL per item
+type MemoryCache struct {
+	mu    sync.RWMutex
+	ttl   time.Duration
+	items map[string]memoryCacheItem
+}
+
+type memoryCacheItem struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		ttl:   ttl,
+		items: make(map[string]memoryCacheItem),
+	}
+}
+
+// Get mengambil value dari cache, false jika tidak ada atau sudah expired
+func (c *MemoryCache) Get(key string) (interface{}, bool) {
+	c.mu.RLock()
+	item, ok := c.items[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(item.expiresAt) {
+		c.mu.Lock()
+		delete(c.items, key)
+		c.mu.Unlock()
+		return nil, false
+	}
+
+	return item.value, true
+}
+
+func (c *MemoryCache) Set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items[key] = memoryCacheItem{
+		value:     value,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.items, key)
+}
+
+// Flush menghapus semua item di cache
+func (c *MemoryCache) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[string]memoryCacheItem)
+}
@@ -0,0 +1,227 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MonthLayout format tanggal presisi bulan yang dipakai di request/response
+const MonthLayout = "2006-01"
+
+var monthNames = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "mei": time.May, "jun": time.June, "jul": time.July,
+	"aug": time.August, "agu": time.August, "sep": time.September, "oct": time.October,
+	"okt": time.October, "nov": time.November, "dec": time.December, "des": time.December,
+}
+
+var (
+	ErrInvalidMonth     = errors.New("invalid month, use YYYY-MM")
+	ErrInvalidDateRange = errors.New("end date must not be before start date")
+)
+
+// Teks end date lama yang berarti periode masih berjalan
+var ongoingMonthTexts = map[string]bool{
+	"present": true, "now": true, "current": true, "sekarang": true, "saat ini": true,
+}
+
+// IsOngoingMonthText true untuk teks seperti "Present" atau "Sekarang"
+func IsOngoingMonthText(text string) bool {
+	return ongoingMonthTexts[strings.ToLower(strings.TrimSpace(text))]
+}
+
+// ValidateMonthRange memastikan end tidak lebih awal dari start
+func ValidateMonthRange(start time.Time, end *time.Time) error {
+	if end != nil && end.Before(start) {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
+// ParseMonth membaca tanggal presisi bulan: "2021-03", "2021-03-15", "03/2021", "Mar 2021", "Maret 2021", atau "2021".
+// Tahun tanpa bulan menjadi Januari, kecuali endOfYear bernilai true (Desember).
+// Hasil selalu tanggal 1 pukul 00:00 UTC.
+func ParseMonth(text string, endOfYear bool) (time.Time, error) {
+	value := strings.ToLower(strings.TrimSpace(text))
+
+	for _, layout := range []string{MonthLayout, "2006-01-02", "2006/01", "01/2006", "1/2006", "01-2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return StartOfMonth(t), nil
+		}
+	}
+
+	if fields := strings.Fields(strings.ReplaceAll(value, ".", " ")); len(fields) == 2 && len(fields[0]) >= 3 {
+		month, ok := monthNames[fields[0][:3]]
+		year, err := strconv.Atoi(fields[1])
+		if ok && err == nil && len(fields[1]) == 4 {
+			return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+
+	if year, err := strconv.Atoi(value); err == nil && len(value) == 4 {
+		month := time.January
+		if endOfYear {
+			month = time.December
+		}
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidMonth, text)
+}
+
+// StartOfMonth membulatkan t ke tanggal 1 bulan yang sama (UTC)
+func StartOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// FormatMonth mengubah tanggal menjadi "YYYY-MM", nil tetap nil
+func FormatMonth(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(MonthLayout)
+	return &formatted
+}
+
+// MonthLabel teks tampilan seperti "Mar 2021" untuk kolom start_year/end_year lama
+func MonthLabel(t time.Time) string {
+	return t.Format("Jan 2006")
+}
+
+// MonthsBetween jumlah bulan dari start sampai last, keduanya ikut dihitung (Jan–Mar = 3 bulan)
+func MonthsBetween(start, last time.Time) int {
+	last = StartOfMonth(last)
+	months := (last.Year()-start.Year())*12 + int(last.Month()) - int(start.Month()) + 1
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+// ParseMonthInput membaca format baru (YYYY-MM) atau teks lama; teks lama disimpan apa adanya sebagai label
+func ParseMonthInput(date, legacy string, endOfYear bool) (time.Time, string, error) {
+	if date != "" {
+		parsed, err := ParseMonth(date, endOfYear)
+		return parsed, MonthLabel(parsed), err
+	}
+	parsed, err := ParseMonth(legacy, endOfYear)
+	return parsed, legacy, err
+}
+
+// FormatMonthDuration menulis durasi seperti "2 yrs 3 mos", "1 yr", atau "5 mos"
+func FormatMonthDuration(months int) string {
+	years, rest := months/12, months%12
+
+	var parts []string
+	if years > 0 {
+		parts = append(parts, pluralize(years, "yr", "yrs"))
+	}
+	if rest > 0 || years == 0 {
+		parts = append(parts, pluralize(rest, "mo", "mos"))
+	}
+	return strings.Join(parts, " ")
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// MonthRange periode presisi bulan. End nil hanya berarti masih berjalan jika Current true
+// (current_job / is_current); tanpa itu tanggal selesainya tidak diketahui, misalnya data lama
+// yang gagal diparse, dan periode tidak ikut dihitung durasinya. Start kosong untuk data tanpa tanggal.
+type MonthRange struct {
+	Start   time.Time
+	End     *time.Time
+	Current bool
+}
+
+// NewMonthRange membuat MonthRange dari kolom start_date/end_date yang bisa NULL
+func NewMonthRange(start, end *time.Time, current bool) MonthRange {
+	r := MonthRange{End: end, Current: current}
+	if start != nil {
+		r.Start = *start
+	}
+	return r
+}
+
+// LastMonth bulan terakhir periode: bulan ini jika masih berjalan, false jika tidak diketahui
+func (r MonthRange) LastMonth() (time.Time, bool) {
+	switch {
+	case r.Current:
+		return StartOfMonth(time.Now()), true
+	case r.End != nil:
+		return StartOfMonth(*r.End), true
+	}
+	return time.Time{}, false
+}
+
+// Months lama periode dalam bulan, 0 jika start atau bulan terakhirnya tidak diketahui
+func (r MonthRange) Months() (int, bool) {
+	last, ok := r.LastMonth()
+	if !ok || r.Start.IsZero() {
+		return 0, false
+	}
+	return MonthsBetween(r.Start, last), true
+}
+
+// NewerFirst urutan kronologis terbaru dulu untuk sort.SliceStable: periode yang masih berjalan,
+// lalu end date terbaru, lalu start date terbaru. Tanggal yang tidak diketahui ditaruh di belakang.
+func NewerFirst(a, b MonthRange) bool {
+	if rankA, rankB := a.rank(), b.rank(); rankA != rankB {
+		return rankA < rankB
+	}
+	if !a.Current && a.End != nil && !a.End.Equal(*b.End) {
+		return a.End.After(*b.End)
+	}
+	return a.Start.After(b.Start)
+}
+
+func (r MonthRange) rank() int {
+	switch {
+	case r.Current:
+		return 0
+	case r.End != nil:
+		return 1
+	}
+	return 2
+}
+
+// TotalMonths menjumlahkan bulan dari beberapa periode tanpa menghitung ganda bulan yang tumpang tindih.
+// Periode yang tanggal selesainya tidak diketahui dilewati.
+func TotalMonths(ranges []MonthRange) int {
+	type span struct{ from, to int }
+
+	spans := make([]span, 0, len(ranges))
+	for _, r := range ranges {
+		end, ok := r.LastMonth()
+		if !ok || r.Start.IsZero() {
+			continue
+		}
+		from := r.Start.Year()*12 + int(r.Start.Month())
+		to := end.Year()*12 + int(end.Month())
+		if to >= from {
+			spans = append(spans, span{from, to})
+		}
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].from < spans[j].from })
+
+	total, coveredTo := 0, -1
+	for _, s := range spans {
+		if s.from <= coveredTo {
+			s.from = coveredTo + 1
+		}
+		if s.to >= s.from {
+			total += s.to - s.from + 1
+			coveredTo = s.to
+		}
+	}
+	return total
+}
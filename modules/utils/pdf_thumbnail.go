@@ -0,0 +1,303 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ============================================
+// PDF THUMBNAIL
+// ============================================
+
+const (
+	DefaultThumbnailWidth = 600
+	thumbnailJPEGQuality  = 85
+	pdftoppmTimeout       = 30 * time.Second
+	// Batas ukuran gambar tertanam yang mau di-decode (~100 MB RGBA), dicek dari header sebelum decode
+	maxEmbeddedImagePixels = 25_000_000
+)
+
+var ErrNotPDF = errors.New("file bukan PDF")
+
+// PDFThumbnailRenderer merender halaman pertama PDF menjadi gambar
+type PDFThumbnailRenderer interface {
+	RenderFirstPage(pdf []byte) (image.Image, error)
+}
+
+// IsPDF mengecek signature %PDF- di awal file
+func IsPDF(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("%PDF-"))
+}
+
+// NewPDFThumbnailRendererFromEnv memilih renderer lewat PDF_THUMBNAIL_RENDERER:
+//   - "auto" (default): pdftoppm jika terpasang, lalu gambar tertanam, lalu placeholder
+//   - "pdftoppm": pdftoppm (path bisa diatur lewat PDFTOPPM_PATH), fallback placeholder
+//   - "embedded": gambar JPEG tertanam (pure Go), fallback placeholder
+//   - "placeholder": selalu placeholder
+func NewPDFThumbnailRendererFromEnv() PDFThumbnailRenderer {
+	pdftoppmPath := os.Getenv("PDFTOPPM_PATH")
+	if pdftoppmPath == "" {
+		pdftoppmPath = "pdftoppm"
+	}
+
+	switch strings.ToLower(os.Getenv("PDF_THUMBNAIL_RENDERER")) {
+	case "pdftoppm":
+		return ChainRenderer{PdftoppmRenderer{Path: pdftoppmPath}, PlaceholderRenderer{}}
+	case "embedded":
+		return ChainRenderer{EmbeddedImageRenderer{}, PlaceholderRenderer{}}
+	case "placeholder":
+		return PlaceholderRenderer{}
+	default:
+		chain := ChainRenderer{}
+		if path, err := exec.LookPath(pdftoppmPath); err == nil {
+			chain = append(chain, PdftoppmRenderer{Path: path})
+		}
+		return append(chain, EmbeddedImageRenderer{}, PlaceholderRenderer{})
+	}
+}
+
+// GeneratePDFThumbnail merender halaman pertama lalu mengecilkan ke maxWidth dan meng-encode sebagai JPEG
+func GeneratePDFThumbnail(renderer PDFThumbnailRenderer, pdf []byte, maxWidth int) ([]byte, error) {
+	if !IsPDF(pdf) {
+		return nil, ErrNotPDF
+	}
+
+	img, err := renderer.RenderFirstPage(pdf)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, scaleToWidth(img, maxWidth), &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+		return nil, fmt.Errorf("gagal encode thumbnail: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ChainRenderer mencoba renderer satu per satu sampai ada yang berhasil
+type ChainRenderer []PDFThumbnailRenderer
+
+func (c ChainRenderer) RenderFirstPage(pdf []byte) (image.Image, error) {
+	errs := make([]error, 0, len(c))
+	for _, renderer := range c {
+		img, err := renderer.RenderFirstPage(pdf)
+		if err == nil {
+			return img, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("tidak ada renderer PDF")
+	}
+	return nil, errors.Join(errs...)
+}
+
+// PdftoppmRenderer merender lewat binary pdftoppm (poppler-utils)
+type PdftoppmRenderer struct {
+	Path string
+}
+
+func (r PdftoppmRenderer) RenderFirstPage(pdf []byte) (image.Image, error) {
+	dir, err := os.MkdirTemp("", "pdf-thumbnail-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.pdf")
+	if err := os.WriteFile(input, pdf, 0600); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pdftoppmTimeout)
+	defer cancel()
+
+	output := filepath.Join(dir, "page")
+	cmd := exec.CommandContext(ctx, r.Path, "-png", "-f", "1", "-l", "1", "-singlefile",
+		"-scale-to", fmt.Sprint(DefaultThumbnailWidth*2), input, output)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("pdftoppm gagal: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	file, err := os.Open(output + ".png")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return png.Decode(file)
+}
+
+// EmbeddedImageRenderer tidak benar-benar merender halaman: ia mengambil gambar JPEG (filter DCTDecode)
+// terbesar yang tertanam di mana pun dalam PDF, tidak harus di halaman pertama. Sertifikat hasil scan
+// atau export desain umumnya berupa satu halaman dengan gambar latar penuh, sehingga untuk kasus itu
+// gambar ini cukup mewakili halamannya. Gambar di atas maxEmbeddedImagePixels dilewati tanpa di-decode.
+type EmbeddedImageRenderer struct{}
+
+func (EmbeddedImageRenderer) RenderFirstPage(pdf []byte) (image.Image, error) {
+	bestStart, bestArea, oversized := -1, 0, false
+	for _, start := range embeddedJPEGOffsets(pdf) {
+		config, err := jpeg.DecodeConfig(bytes.NewReader(pdf[start:]))
+		if err != nil {
+			continue
+		}
+		area := int64(config.Width) * int64(config.Height)
+		if area > maxEmbeddedImagePixels {
+			oversized = true
+			continue
+		}
+		if int(area) > bestArea {
+			bestStart, bestArea = start, int(area)
+		}
+	}
+	if bestStart < 0 {
+		if oversized {
+			return nil, fmt.Errorf("gambar JPEG tertanam melebihi batas %d piksel", maxEmbeddedImagePixels)
+		}
+		return nil, errors.New("PDF tidak memiliki gambar JPEG tertanam")
+	}
+
+	// Decoder JPEG berhenti di marker EOI, sisa stream PDF setelahnya diabaikan
+	return jpeg.Decode(bytes.NewReader(pdf[bestStart:]))
+}
+
+// embeddedJPEGOffsets mencari awal data stream yang dictionary-nya memakai /DCTDecode
+func embeddedJPEGOffsets(pdf []byte) []int {
+	var offsets []int
+	filter := []byte("/DCTDecode")
+	keyword := []byte("stream")
+
+	for pos := 0; ; {
+		idx := bytes.Index(pdf[pos:], filter)
+		if idx < 0 {
+			return offsets
+		}
+		pos += idx + len(filter)
+
+		// Dictionary stream tidak mungkin sepanjang ini, hindari melompat ke object lain
+		window := pdf[pos:min(len(pdf), pos+2048)]
+		streamIdx := bytes.Index(window, keyword)
+		if streamIdx < 0 || bytes.Contains(window[:streamIdx], []byte("endobj")) {
+			continue
+		}
+
+		start := pos + streamIdx + len(keyword)
+		if start < len(pdf) && pdf[start] == '\r' {
+			start++
+		}
+		if start < len(pdf) && pdf[start] == '\n' {
+			start++
+		}
+		if start+2 <= len(pdf) && pdf[start] == 0xFF && pdf[start+1] == 0xD8 {
+			offsets = append(offsets, start)
+		}
+		pos = start
+	}
+}
+
+// PlaceholderRenderer menggambar ikon dokumen PDF generik, dipakai jika renderer lain gagal
+type PlaceholderRenderer struct{}
+
+func (PlaceholderRenderer) RenderFirstPage(pdf []byte) (image.Image, error) {
+	const width, height = 420, 594 // rasio A4
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	border := color.RGBA{R: 0xD0, G: 0xD4, B: 0xDA, A: 0xFF}
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: border}, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(4, 4, width-4, height-4), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+
+	// Garis-garis abu-abu sebagai representasi teks
+	line := color.RGBA{R: 0xE5, G: 0xE7, B: 0xEB, A: 0xFF}
+	for y := 340; y < height-60; y += 28 {
+		draw.Draw(img, image.Rect(60, y, width-60, y+10), &image.Uniform{C: line}, image.Point{}, draw.Src)
+	}
+
+	// Label "PDF" di atas pita merah
+	red := color.RGBA{R: 0xDC, G: 0x26, B: 0x26, A: 0xFF}
+	draw.Draw(img, image.Rect(0, 150, width, 280), &image.Uniform{C: red}, image.Point{}, draw.Src)
+	drawBitmapText(img, "PDF", 74, 159, 16, color.White)
+
+	return img, nil
+}
+
+// Glyph 5x7 untuk label placeholder
+var bitmapGlyphs = map[rune][7]string{
+	'P': {"11110", "10001", "10001", "11110", "10000", "10000", "10000"},
+	'D': {"11110", "10001", "10001", "10001", "10001", "10001", "11110"},
+	'F': {"11111", "10000", "10000", "11110", "10000", "10000", "10000"},
+}
+
+func drawBitmapText(img draw.Image, text string, x, y, scale int, c color.Color) {
+	for _, r := range text {
+		glyph, ok := bitmapGlyphs[r]
+		if ok {
+			for row, bits := range glyph {
+				for col, bit := range bits {
+					if bit != '1' {
+						continue
+					}
+					rect := image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale)
+					draw.Draw(img, rect, &image.Uniform{C: c}, image.Point{}, draw.Src)
+				}
+			}
+		}
+		x += 6 * scale
+	}
+}
+
+// scaleToWidth mengecilkan gambar dengan rata-rata area (box filter) di atas latar putih.
+// Gambar yang lebih kecil dari maxWidth tidak diperbesar.
+func scaleToWidth(src image.Image, maxWidth int) image.Image {
+	bounds := src.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, bounds.Min, draw.Over)
+
+	srcW, srcH := flat.Bounds().Dx(), flat.Bounds().Dy()
+	if maxWidth <= 0 || srcW <= maxWidth {
+		return flat
+	}
+
+	dstW := maxWidth
+	dstH := max(1, srcH*dstW/srcW)
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for dy := 0; dy < dstH; dy++ {
+		y0, y1 := dy*srcH/dstH, max((dy+1)*srcH/dstH, dy*srcH/dstH+1)
+		for dx := 0; dx < dstW; dx++ {
+			x0, x1 := dx*srcW/dstW, max((dx+1)*srcW/dstW, dx*srcW/dstW+1)
+
+			var r, g, b, n int
+			for sy := y0; sy < y1; sy++ {
+				offset := flat.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += int(flat.Pix[offset])
+					g += int(flat.Pix[offset+1])
+					b += int(flat.Pix[offset+2])
+					offset += 4
+					n++
+				}
+			}
+
+			offset := dst.PixOffset(dx, dy)
+			dst.Pix[offset] = uint8(r / n)
+			dst.Pix[offset+1] = uint8(g / n)
+			dst.Pix[offset+2] = uint8(b / n)
+			dst.Pix[offset+3] = 0xFF
+		}
+	}
+
+	return dst
+}
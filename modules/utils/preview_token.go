@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	utitjwt "gintugas/modules/components/Auth/util"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PreviewContextKey menyimpan *PreviewAccess di gin.Context setelah preview token divalidasi
+const PreviewContextKey = "preview_access"
+
+// PreviewAccess entity yang boleh dilihat (termasuk draft) oleh pemegang preview token
+type PreviewAccess struct {
+	LinkID     uuid.UUID
+	EntityType string
+	EntityID   uuid.UUID
+}
+
+func previewTokenSecret() []byte {
+	if secret := os.Getenv("PREVIEW_TOKEN_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return utitjwt.JwtSecret
+}
+
+// SignPreviewToken membuat token "<link id>.<signature>". Signature mengikat entity dan waktu kedaluwarsa,
+// sehingga token yang sama bisa dibuat ulang dari data link di database.
+func SignPreviewToken(linkID uuid.UUID, entityType string, entityID uuid.UUID, expiresAt time.Time) string {
+	mac := hmac.New(sha256.New, previewTokenSecret())
+	fmt.Fprintf(mac, "%s|%s|%s|%d", linkID, entityType, entityID, expiresAt.Unix())
+	return linkID.String() + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// PreviewTokenLinkID mengambil ID link dari token tanpa memverifikasi signature
+func PreviewTokenLinkID(token string) (uuid.UUID, error) {
+	id, _, found := strings.Cut(token, ".")
+	if !found {
+		return uuid.Nil, errors.New("invalid preview token")
+	}
+	return uuid.Parse(id)
+}
+
+// VerifyPreviewToken membandingkan token dengan signature yang dihitung ulang dari data link
+func VerifyPreviewToken(token string, linkID uuid.UUID, entityType string, entityID uuid.UUID, expiresAt time.Time) bool {
+	expected := SignPreviewToken(linkID, entityType, entityID, expiresAt)
+	return hmac.Equal([]byte(token), []byte(expected))
+}
+
+// CanViewDraft true jika request berasal dari admin atau membawa preview token untuk entity tersebut
+func CanViewDraft(ctx *gin.Context, entityType string, entityID uuid.UUID) bool {
+	if ctx.GetString("user_role") == "admin" {
+		return true
+	}
+
+	value, exists := ctx.Get(PreviewContextKey)
+	if !exists {
+		return false
+	}
+	access, ok := value.(*PreviewAccess)
+	return ok && access.EntityType == entityType && access.EntityID == entityID
+}
+
+// IsPreviewRequest true jika request membawa preview token yang valid
+func IsPreviewRequest(ctx *gin.Context) bool {
+	_, exists := ctx.Get(PreviewContextKey)
+	return exists
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"time"
+)
+
+// Maksimal redirect yang diikuti oleh client dari NewPublicHTTPClient
+const publicHTTPMaxRedirects = 5
+
+var ErrNonPublicAddress = errors.New("alamat tujuan bukan alamat publik")
+
+// Rentang khusus yang tidak tercakup oleh method netip.Addr (CGNAT, benchmark, reserved, NAT64)
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// IsPublicAddr false untuk loopback, jaringan privat, link-local, multicast, dan rentang reserved
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewPublicHTTPClient membuat client untuk mengambil URL yang diisi user. Host di-resolve saat dial
+// dan hanya alamat publik yang dihubungi, termasuk di setiap redirect, sehingga URL tidak bisa
+// dipakai untuk menjangkau layanan internal. Proxy dari environment tidak dipakai.
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialPublic
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= publicHTTPMaxRedirects {
+				return fmt.Errorf("lebih dari %d redirect", publicHTTPMaxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect ke skema %q tidak diizinkan", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// dialPublic menghubungi IP hasil resolve secara langsung agar hasil DNS tidak berubah di antara
+// pengecekan dan koneksi
+func dialPublic(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	var lastErr error
+	for _, addr := range addrs {
+		if !IsPublicAddr(addr) {
+			continue
+		}
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(addr.Unmap().String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNonPublicAddress, host)
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Huruf yang tidak bisa diurai oleh NFD menjadi huruf dasar + tanda aksen
+var slugTransliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d",
+	'ł': "l", 'þ': "th", 'ı': "i", '&': " and ",
+}
+
+const maxSlugLength = 180
+
+// Slugify mengubah teks bebas menjadi slug URL: huruf kecil ASCII, angka, dan tanda "-".
+// Huruf beraksen ditransliterasi (é -> e, ß -> ss).
+func Slugify(text string) string {
+	var builder strings.Builder
+	lastDash := true
+
+	for _, r := range norm.NFD.String(strings.ToLower(text)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+
+		if replacement, ok := slugTransliterations[r]; ok {
+			for _, rr := range replacement {
+				lastDash = writeSlugRune(&builder, rr, lastDash)
+			}
+			continue
+		}
+
+		lastDash = writeSlugRune(&builder, r, lastDash)
+	}
+
+	slug := strings.Trim(builder.String(), "-")
+	if len(slug) > maxSlugLength {
+		slug = strings.Trim(slug[:maxSlugLength], "-")
+	}
+	return slug
+}
+
+func writeSlugRune(builder *strings.Builder, r rune, lastDash bool) bool {
+	if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+		builder.WriteRune(r)
+		return false
+	}
+	if !lastDash {
+		builder.WriteRune('-')
+	}
+	return true
+}
+
+// UniqueSlug membuat slug dari teks lalu menambahkan suffix -2, -3, ... sampai exists mengembalikan false
+func UniqueSlug(text, fallback string, exists func(slug string) (bool, error)) (string, error) {
+	base := Slugify(text)
+	if base == "" {
+		base = fallback
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		taken, err := exists(slug)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// SlugMovedError dikembalikan saat slug yang diminta adalah slug lama, Slug berisi slug terbaru
+type SlugMovedError struct {
+	Slug string
+}
+
+func (e *SlugMovedError) Error() string {
+	return fmt.Sprintf("slug telah dipindahkan ke %s", e.Slug)
+}
@@ -326,7 +326,8 @@ func (s *SupabaseUploadService) UploadBytes(data []byte, filename, folder string
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.apiKey))
-	req.Header.Set("Content-Type", "application/octet-stream")
+	// Deteksi dari isi agar file hasil generate (misal thumbnail) tampil inline, bukan terunduh
+	req.Header.Set("Content-Type", http.DetectContentType(data))
 
 	resp, err := s.client.Do(req)
 	if err != nil {
@@ -407,6 +408,36 @@ func (s *LocalUploadService) UploadFile(file *multipart.FileHeader, folder strin
 	return fmt.Sprintf("/uploads/%s", filename), nil
 }
 
+// UploadBytes untuk simpan data byte langsung (misal thumbnail hasil generate)
+func (s *LocalUploadService) UploadBytes(data []byte, filename, folder string) (string, error) {
+	if len(data) == 0 {
+		return "", errors.New("data kosong")
+	}
+
+	ext := filepath.Ext(filename)
+	if ext == "" {
+		ext = ".dat"
+	}
+	uniqueName := fmt.Sprintf("%s%s", uuid.New().String(), ext)
+
+	uploadDir := s.uploadPath
+	if folder != "" {
+		uploadDir = filepath.Join(s.uploadPath, folder)
+		if err := os.MkdirAll(uploadDir, 0755); err != nil {
+			return "", fmt.Errorf("gagal membuat folder: %v", err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(uploadDir, uniqueName), data, 0644); err != nil {
+		return "", fmt.Errorf("gagal menyimpan file: %v", err)
+	}
+
+	if folder != "" {
+		return fmt.Sprintf("/uploads/%s/%s", folder, uniqueName), nil
+	}
+	return fmt.Sprintf("/uploads/%s", uniqueName), nil
+}
+
 func (s *LocalUploadService) DeleteFile(fileURL string) error {
 	// Hapus prefix /uploads/
 	relativePath := strings.TrimPrefix(fileURL, "/uploads/")
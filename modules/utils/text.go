@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TextSimilarity menghitung kemiripan dua teks (Jaccard index dari kata-kata), hasilnya 0..1
+func TextSimilarity(a, b string) float64 {
+	wordsA := tokenize(a)
+	wordsB := tokenize(b)
+
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	intersection := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			intersection++
+		}
+	}
+
+	union := len(wordsA) + len(wordsB) - intersection
+	return float64(intersection) / float64(union)
+}
+
+func tokenize(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	result := make(map[string]bool, len(words))
+	for _, word := range words {
+		// Abaikan kata yang terlalu pendek (dan, di, of, the, ...)
+		if len([]rune(word)) < 3 {
+			continue
+		}
+		result[word] = true
+	}
+	return result
+}
+
+// ParseLimit mengubah query param limit ke int dengan nilai default dan batas maksimal
+func ParseLimit(value string, defaultLimit, maxLimit int) int {
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return defaultLimit
+	}
+	if limit > maxLimit {
+		return maxLimit
+	}
+	return limit
+}
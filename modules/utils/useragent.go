@@ -0,0 +1,26 @@
+package utils
+
+import "strings"
+
+// Potongan User-Agent crawler, preview bot, dan HTTP client umum (huruf kecil)
+var botUserAgentMarkers = []string{
+	"bot", "crawl", "spider", "slurp", "facebookexternalhit", "embedly",
+	"preview", "headless", "lighthouse", "pingdom", "uptime", "monitor",
+	"curl/", "wget/", "python-requests", "go-http-client", "okhttp", "axios/",
+	"postman", "insomnia", "httpclient", "java/", "libwww", "scrapy",
+}
+
+// IsBotUserAgent menganggap User-Agent kosong atau yang mengandung penanda crawler sebagai bot
+func IsBotUserAgent(userAgent string) bool {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return true
+	}
+
+	for _, marker := range botUserAgentMarkers {
+		if strings.Contains(ua, marker) {
+			return true
+		}
+	}
+	return false
+}